@@ -3,6 +3,7 @@ package apis
 const (
 	Namespace      = "NAMESPACE"
 	BackupSession  = "BACKUP_SESSION"
+	BackupTags     = "BACKUP_TAGS"
 	RestoreSession = "RESTORE_SESSION"
 
 	RepositoryName       = "REPOSITORY_NAME"
@@ -23,14 +24,29 @@ const (
 	TargetMountPath  = "TARGET_MOUNT_PATH"
 	TargetPaths      = "TARGET_PATHS"
 
+	TargetIncludePatterns  = "TARGET_INCLUDE_PATTERNS"
+	TargetExcludePatterns  = "TARGET_EXCLUDE_PATTERNS"
+	TargetIExcludePatterns = "TARGET_IEXCLUDE_PATTERNS"
+	TargetExcludeFiles     = "TARGET_EXCLUDE_FILES"
+	TargetExcludeCaches    = "TARGET_EXCLUDE_CACHES"
+	TargetExcludeIfPresent = "TARGET_EXCLUDE_IF_PRESENT"
+	TargetOneFileSystem    = "TARGET_ONE_FILE_SYSTEM"
+
 	TargetAppVersion  = "TARGET_APP_VERSION"
 	TargetAppType     = "TARGET_APP_TYPE"
 	TargetAppGroup    = "TARGET_APP_GROUP"
 	TargetAppResource = "TARGET_APP_RESOURCE"
 	TargetAppReplicas = "TARGET_APP_REPLICAS"
 
-	RestorePaths     = "RESTORE_PATHS"
-	RestoreSnapshots = "RESTORE_SNAPSHOTS"
+	RestorePaths           = "RESTORE_PATHS"
+	RestoreSnapshots       = "RESTORE_SNAPSHOTS"
+	RestoreDestination     = "RESTORE_DESTINATION"
+	RestorePathMapping     = "RESTORE_PATH_MAPPING"
+	RestoreIncludePatterns = "RESTORE_INCLUDE_PATTERNS"
+	RestoreExcludePatterns = "RESTORE_EXCLUDE_PATTERNS"
+	RestoreBefore          = "RESTORE_BEFORE"
+	RestoreAfter           = "RESTORE_AFTER"
+	RestoreTags            = "RESTORE_TAGS"
 
 	RetentionKeepLast    = "RETENTION_KEEP_LAST"
 	RetentionKeepHourly  = "RETENTION_KEEP_HOURLY"
@@ -42,10 +58,16 @@ const (
 	RetentionPrune       = "RETENTION_PRUNE"
 	RetentionDryRun      = "RETENTION_DRY_RUN"
 
+	// true when the respective operation is done by the maintenance jobs of the Repository
+	SkipRepositoryCheck = "SKIP_REPOSITORY_CHECK"
+	SkipRepositoryStats = "SKIP_REPOSITORY_STATS"
+
 	// default true
 	// false when TmpDir.DisableCaching is true in backupConfig/restoreSession
 	EnableCache    = "ENABLE_CACHE"
 	MaxConnections = "MAX_CONNECTIONS"
+	LimitUpload    = "LIMIT_UPLOAD"
+	LimitDownload  = "LIMIT_DOWNLOAD"
 
 	// from runtime settings
 	NiceAdjustment  = "NICE_ADJUSTMENT"
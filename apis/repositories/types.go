@@ -17,11 +17,12 @@ limitations under the License.
 package repositories
 
 import (
+	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // +genclient
-// +genclient:skipVerbs=create,update,patch,deleteCollection,watch
+// +genclient:skipVerbs=create,update,patch,deleteCollection
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type Snapshot struct {
@@ -47,3 +48,19 @@ type SnapshotList struct {
 	metav1.ListMeta
 	Items []Snapshot
 }
+
+// +genclient
+// +genclient:onlyVerbs=get
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ResolvedJob struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	Status ResolvedJobStatus
+}
+
+type ResolvedJobStatus struct {
+	Task                string
+	PodSpec             core.PodSpec
+	UnresolvedVariables []string
+}
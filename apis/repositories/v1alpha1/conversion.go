@@ -0,0 +1,33 @@
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Field selectors supported by Snapshot
+const (
+	SnapshotFieldName      = "metadata.name"
+	SnapshotFieldNamespace = "metadata.namespace"
+	SnapshotFieldHostname  = "status.hostname"
+	SnapshotFieldPaths     = "status.paths"
+	SnapshotFieldTags      = "status.tags"
+)
+
+func addFieldLabelConversionFuncs(scheme *runtime.Scheme) error {
+	return scheme.AddFieldLabelConversionFunc(SchemeGroupVersion.WithKind(ResourceKindSnapshot),
+		func(label, value string) (string, string, error) {
+			switch label {
+			case SnapshotFieldName,
+				SnapshotFieldNamespace,
+				SnapshotFieldHostname,
+				SnapshotFieldPaths,
+				SnapshotFieldTags:
+				return label, value, nil
+			default:
+				return "", "", fmt.Errorf("field label not supported: %s", label)
+			}
+		},
+	)
+}
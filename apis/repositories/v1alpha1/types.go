@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -27,7 +28,7 @@ const (
 )
 
 // +genclient
-// +genclient:skipVerbs=create,update,patch,deleteCollection,watch
+// +genclient:skipVerbs=create,update,patch,deleteCollection
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -54,3 +55,32 @@ type SnapshotList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Snapshot `json:"items"`
 }
+
+const (
+	ResourceKindResolvedJob     = "ResolvedJob"
+	ResourcePluralResolvedJob   = "resolvedjobs"
+	ResourceSingularResolvedJob = "resolvedjob"
+)
+
+// +genclient
+// +genclient:onlyVerbs=get
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ResolvedJob is the PodSpec of the backup job of a BackupConfiguration generated from its Task and Functions
+// without creating anything. The name of a ResolvedJob is the name of the respective BackupConfiguration.
+type ResolvedJob struct {
+	metav1.TypeMeta   `json:",inline,omitempty"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            ResolvedJobStatus `json:"status,omitempty"`
+}
+
+type ResolvedJobStatus struct {
+	// Task is the name of the Task that has been resolved
+	Task string `json:"task"`
+	// PodSpec is the PodSpec of the job generated from the Task and its Functions
+	PodSpec core.PodSpec `json:"podSpec"`
+	// UnresolvedVariables are the variables of the Task and its Functions that have neither an input nor a default value.
+	// They are left as it is in the PodSpec.
+	UnresolvedVariables []string `json:"unresolvedVariables,omitempty"`
+}
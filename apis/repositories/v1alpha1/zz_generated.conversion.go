@@ -35,6 +35,26 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*ResolvedJob)(nil), (*repositories.ResolvedJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResolvedJob_To_repositories_ResolvedJob(a.(*ResolvedJob), b.(*repositories.ResolvedJob), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.ResolvedJob)(nil), (*ResolvedJob)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_ResolvedJob_To_v1alpha1_ResolvedJob(a.(*repositories.ResolvedJob), b.(*ResolvedJob), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResolvedJobStatus)(nil), (*repositories.ResolvedJobStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_ResolvedJobStatus_To_repositories_ResolvedJobStatus(a.(*ResolvedJobStatus), b.(*repositories.ResolvedJobStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*repositories.ResolvedJobStatus)(nil), (*ResolvedJobStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_repositories_ResolvedJobStatus_To_v1alpha1_ResolvedJobStatus(a.(*repositories.ResolvedJobStatus), b.(*ResolvedJobStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Snapshot)(nil), (*repositories.Snapshot)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_Snapshot_To_repositories_Snapshot(a.(*Snapshot), b.(*repositories.Snapshot), scope)
 	}); err != nil {
@@ -68,6 +88,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	return nil
 }
 
+func autoConvert_v1alpha1_ResolvedJob_To_repositories_ResolvedJob(in *ResolvedJob, out *repositories.ResolvedJob, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1alpha1_ResolvedJobStatus_To_repositories_ResolvedJobStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1alpha1_ResolvedJob_To_repositories_ResolvedJob is an autogenerated conversion function.
+func Convert_v1alpha1_ResolvedJob_To_repositories_ResolvedJob(in *ResolvedJob, out *repositories.ResolvedJob, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResolvedJob_To_repositories_ResolvedJob(in, out, s)
+}
+
+func autoConvert_repositories_ResolvedJob_To_v1alpha1_ResolvedJob(in *repositories.ResolvedJob, out *ResolvedJob, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_repositories_ResolvedJobStatus_To_v1alpha1_ResolvedJobStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_repositories_ResolvedJob_To_v1alpha1_ResolvedJob is an autogenerated conversion function.
+func Convert_repositories_ResolvedJob_To_v1alpha1_ResolvedJob(in *repositories.ResolvedJob, out *ResolvedJob, s conversion.Scope) error {
+	return autoConvert_repositories_ResolvedJob_To_v1alpha1_ResolvedJob(in, out, s)
+}
+
+func autoConvert_v1alpha1_ResolvedJobStatus_To_repositories_ResolvedJobStatus(in *ResolvedJobStatus, out *repositories.ResolvedJobStatus, s conversion.Scope) error {
+	out.Task = in.Task
+	out.PodSpec = in.PodSpec
+	out.UnresolvedVariables = *(*[]string)(unsafe.Pointer(&in.UnresolvedVariables))
+	return nil
+}
+
+// Convert_v1alpha1_ResolvedJobStatus_To_repositories_ResolvedJobStatus is an autogenerated conversion function.
+func Convert_v1alpha1_ResolvedJobStatus_To_repositories_ResolvedJobStatus(in *ResolvedJobStatus, out *repositories.ResolvedJobStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_ResolvedJobStatus_To_repositories_ResolvedJobStatus(in, out, s)
+}
+
+func autoConvert_repositories_ResolvedJobStatus_To_v1alpha1_ResolvedJobStatus(in *repositories.ResolvedJobStatus, out *ResolvedJobStatus, s conversion.Scope) error {
+	out.Task = in.Task
+	out.PodSpec = in.PodSpec
+	out.UnresolvedVariables = *(*[]string)(unsafe.Pointer(&in.UnresolvedVariables))
+	return nil
+}
+
+// Convert_repositories_ResolvedJobStatus_To_v1alpha1_ResolvedJobStatus is an autogenerated conversion function.
+func Convert_repositories_ResolvedJobStatus_To_v1alpha1_ResolvedJobStatus(in *repositories.ResolvedJobStatus, out *ResolvedJobStatus, s conversion.Scope) error {
+	return autoConvert_repositories_ResolvedJobStatus_To_v1alpha1_ResolvedJobStatus(in, out, s)
+}
+
 func autoConvert_v1alpha1_Snapshot_To_repositories_Snapshot(in *Snapshot, out *repositories.Snapshot, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha1_SnapshotStatus_To_repositories_SnapshotStatus(&in.Status, &out.Status, s); err != nil {
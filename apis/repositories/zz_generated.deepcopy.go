@@ -24,6 +24,55 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedJob) DeepCopyInto(out *ResolvedJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedJob.
+func (in *ResolvedJob) DeepCopy() *ResolvedJob {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ResolvedJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedJobStatus) DeepCopyInto(out *ResolvedJobStatus) {
+	*out = *in
+	in.PodSpec.DeepCopyInto(&out.PodSpec)
+	if in.UnresolvedVariables != nil {
+		in, out := &in.UnresolvedVariables, &out.UnresolvedVariables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedJobStatus.
+func (in *ResolvedJobStatus) DeepCopy() *ResolvedJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Snapshot) DeepCopyInto(out *Snapshot) {
 	*out = *in
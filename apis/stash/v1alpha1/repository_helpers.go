@@ -1,7 +1,9 @@
 package v1alpha1
 
 import (
+	core "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	crdutils "kmodules.xyz/client-go/apiextensions/v1beta1"
 	"stash.appscode.dev/stash/apis"
 )
@@ -35,6 +37,11 @@ func (c Repository) CustomResourceDefinition() *apiextensions.CustomResourceDefi
 		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
 		EnableStatusSubresource: apis.EnableStatusSubresource,
 		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
+			{
+				Name:     "Ready",
+				Type:     "string",
+				JSONPath: `.status.conditions[?(@.type=="Ready")].status`,
+			},
 			{
 				Name:     "Integrity",
 				Type:     "boolean",
@@ -64,3 +71,34 @@ func (c Repository) CustomResourceDefinition() *apiextensions.CustomResourceDefi
 		},
 	})
 }
+
+// GetCondition returns the condition of the given type. It returns nil if the condition is not present.
+func (s RepositoryStatus) GetCondition(condType RepositoryConditionType) *RepositoryCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == condType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates a condition. The transition time is updated only when the status of the condition changes.
+func (s *RepositoryStatus) SetCondition(condType RepositoryConditionType, status core.ConditionStatus, reason, message string) {
+	newCond := RepositoryCondition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == condType {
+			if s.Conditions[i].Status == status {
+				newCond.LastTransitionTime = s.Conditions[i].LastTransitionTime
+			}
+			s.Conditions[i] = newCond
+			return
+		}
+	}
+	s.Conditions = append(s.Conditions, newCond)
+}
@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	"github.com/appscode/go/encoding/json/types"
+	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	store "kmodules.xyz/objectstore-api/api/v1"
 )
@@ -29,6 +30,149 @@ type RepositorySpec struct {
 	// If true, delete respective restic repository
 	// +optional
 	WipeOut bool `json:"wipeOut,omitempty"`
+	// Maintenance specifies the schedules of the integrity check and the prune of the repository.
+	// If it is specified, the respective operations are not run after every backup. Instead, they are
+	// run by separate CronJobs on the given schedules.
+	// +optional
+	Maintenance *RepositoryMaintenance `json:"maintenance,omitempty"`
+	// CopyTo specifies the Repositories where the snapshots of this repository are replicated to (i.e. an off-site bucket
+	// for disaster recovery). The new snapshots are copied using the credentials of both Repositories. If both backends
+	// require different values for the same credential (i.e. two S3 buckets with different access keys), the new snapshots
+	// are staged in the scratch directory of the copy job. So, the scratch directory must be large enough to hold them.
+	// +optional
+	CopyTo []RepositoryReplica `json:"copyTo,omitempty"`
+}
+
+type RepositoryReplica struct {
+	// Name of the Repository where the snapshots are copied to. It must be in the same namespace.
+	Name string `json:"name"`
+	// Schedule specifies when to copy the new snapshots in cron format.
+	// If it is not specified, the new snapshots are copied after each successful BackupSession that uses this Repository.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+type RepositoryMaintenance struct {
+	// Check specifies the schedule of the integrity check of the repository
+	// +optional
+	Check *RepositoryCheck `json:"check,omitempty"`
+	// Prune specifies the schedule to remove the data that are not referenced by any snapshot.
+	// The snapshots are still removed according to the retention policy after every backup.
+	// +optional
+	Prune *RepositoryPrune `json:"prune,omitempty"`
+	// KeyRotation specifies the schedule to replace the key of the repository with a newly generated one.
+	// The new password is written in the storage secret. So, the storage secret must not be shared with other Repositories
+	// or copied into other namespaces to restore from the Repository.
+	// +optional
+	KeyRotation *RepositoryKeyRotation `json:"keyRotation,omitempty"`
+	// Paused suspends the maintenance CronJobs. Default value is 'false'
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+type RepositoryCheck struct {
+	// Schedule specifies when to check the repository in cron format
+	Schedule string `json:"schedule"`
+	// ReadDataSubset specifies the subset of the data packs to read and verify (i.e. "1/5").
+	// Only the structure of the repository is checked if it is not specified.
+	// +optional
+	ReadDataSubset string `json:"readDataSubset,omitempty"`
+}
+
+type RepositoryPrune struct {
+	// Schedule specifies when to prune the repository in cron format
+	Schedule string `json:"schedule"`
+}
+
+type RepositoryKeyRotation struct {
+	// Schedule specifies when to rotate the key of the repository in cron format.
+	Schedule string `json:"schedule"`
+	// GracePeriod specifies how long the old key is kept after the new password has been written in the storage secret.
+	// The pods that mount the storage secret get the new password only after it has been propagated to them.
+	// Default value is 5 minutes.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+type KeyRotationPhase string
+
+const (
+	KeyRotationAddingKey      KeyRotationPhase = "AddingKey"
+	KeyRotationUpdatingSecret KeyRotationPhase = "UpdatingSecret"
+	KeyRotationVerifyingKey   KeyRotationPhase = "VerifyingKey"
+	KeyRotationWaitingGrace   KeyRotationPhase = "WaitingGracePeriod"
+	KeyRotationRemovingOldKey KeyRotationPhase = "RemovingOldKey"
+	KeyRotationSucceeded      KeyRotationPhase = "Succeeded"
+	KeyRotationFailed         KeyRotationPhase = "Failed"
+)
+
+type KeyRotationStatus struct {
+	// Phase indicates the step the latest key rotation is in or the result of the latest key rotation
+	// +optional
+	Phase KeyRotationPhase `json:"phase,omitempty"`
+	// StartTime indicates the timestamp when the latest key rotation has started
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// LastRotationTime indicates the timestamp when the key has been rotated successfully last time
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	// CurrentKeyID shows the ID of the key stored in the storage secret
+	// +optional
+	CurrentKeyID string `json:"currentKeyID,omitempty"`
+	// RetiredKeyID shows the ID of the replaced key that is removed after the grace period.
+	// If the key rotation fails before removing it, the next key rotation removes it first.
+	// +optional
+	RetiredKeyID string `json:"retiredKeyID,omitempty"`
+	// Reason shows the reason of the failure of the latest key rotation
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+type ReplicationPhase string
+
+const (
+	ReplicationSucceeded ReplicationPhase = "Succeeded"
+	ReplicationFailed    ReplicationPhase = "Failed"
+)
+
+type ReplicaStatus struct {
+	// Name of the Repository where the snapshots are copied to
+	Name string `json:"name"`
+	// Phase indicates the result of the latest copy
+	// +optional
+	Phase ReplicationPhase `json:"phase,omitempty"`
+	// LastCopyTime indicates the timestamp when the latest copy has completed
+	// +optional
+	LastCopyTime *metav1.Time `json:"lastCopyTime,omitempty"`
+	// LastSuccessfulCopyTime indicates the timestamp when the snapshots have been copied successfully last time
+	// +optional
+	LastSuccessfulCopyTime *metav1.Time `json:"lastSuccessfulCopyTime,omitempty"`
+	// LatestCopiedSnapshotTime indicates the creation time of the latest snapshot that has been copied to the replica
+	// +optional
+	LatestCopiedSnapshotTime *metav1.Time `json:"latestCopiedSnapshotTime,omitempty"`
+	// Lag shows how far the replica is behind this repository, i.e. the difference between the creation time of the
+	// latest snapshot of this repository and the latest snapshot copied to the replica when the latest copy has completed
+	// +optional
+	Lag string `json:"lag,omitempty"`
+	// SnapshotsCopied shows the number of snapshots copied by the latest copy
+	// +optional
+	SnapshotsCopied int `json:"snapshotsCopied,omitempty"`
+	// Reason shows the reason of the failure of the latest copy
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+type RepositoryLock struct {
+	// ID of the lock in the repository
+	ID string `json:"id"`
+	// Hostname of the process holding the lock. For the backup jobs and sidecars, it is the name of the pod.
+	Hostname string `json:"hostname,omitempty"`
+	// PID of the process holding the lock
+	PID int `json:"pid,omitempty"`
+	// Exclusive indicates whether the lock is an exclusive lock
+	Exclusive bool `json:"exclusive,omitempty"`
+	// CreationTime indicates the timestamp when the lock has been created or last refreshed
+	CreationTime metav1.Time `json:"creationTime,omitempty"`
 }
 
 type RepositoryStatus struct {
@@ -48,6 +192,31 @@ type RepositoryStatus struct {
 	SnapshotCount int `json:"snapshotCount,omitempty"`
 	// SnapshotsRemovedOnLastCleanup shows number of old snapshots cleaned up according to retention policy on last backup session
 	SnapshotsRemovedOnLastCleanup int `json:"snapshotsRemovedOnLastCleanup,omitempty"`
+	// LastCheckTime indicates the timestamp when the integrity of the repository has been checked by the maintenance job
+	// +optional
+	LastCheckTime *metav1.Time `json:"lastCheckTime,omitempty"`
+	// LastPruneTime indicates the timestamp when the repository has been pruned by the maintenance job
+	// +optional
+	LastPruneTime *metav1.Time `json:"lastPruneTime,omitempty"`
+	// Locks shows the locks found in the repository when the operator has last checked for stale locks.
+	// The operator checks the locks when a backup fails because the repository is locked.
+	// +optional
+	Locks []RepositoryLock `json:"locks,omitempty"`
+	// LastLockCheckTime indicates the timestamp when the operator has last checked the locks of the repository
+	// +optional
+	LastLockCheckTime *metav1.Time `json:"lastLockCheckTime,omitempty"`
+	// KeyRotation shows the progress and the result of the key rotation
+	// +optional
+	KeyRotation *KeyRotationStatus `json:"keyRotation,omitempty"`
+	// Replicas shows the status of the replication to the Repositories specified in spec.copyTo
+	// +optional
+	Replicas []ReplicaStatus `json:"replicas,omitempty"`
+	// Conditions shows the result of the latest health probe of the repository
+	// +optional
+	Conditions []RepositoryCondition `json:"conditions,omitempty"`
+	// LastProbeTime indicates the timestamp when the operator has last probed the health of the repository
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
 
 	// Deprecated
 	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
@@ -57,6 +226,35 @@ type RepositoryStatus struct {
 	BackupCount int64 `json:"backupCount,omitempty"`
 }
 
+type RepositoryConditionType string
+
+const (
+	// SecretValid indicates whether the storage secret has the keys required by the backend
+	SecretValid RepositoryConditionType = "SecretValid"
+	// BackendReachable indicates whether the bucket of the backend is reachable with the credentials of the storage secret
+	BackendReachable RepositoryConditionType = "BackendReachable"
+	// RepositoryAccessible indicates whether the restic repository can be opened with the password of the storage secret
+	RepositoryAccessible RepositoryConditionType = "RepositoryAccessible"
+	// RepositoryReady indicates whether all the other conditions are satisfied
+	RepositoryReady RepositoryConditionType = "Ready"
+)
+
+type RepositoryCondition struct {
+	// Type of the condition
+	Type RepositoryConditionType `json:"type"`
+	// Status of the condition. One of "True", "False" or "Unknown".
+	Status core.ConditionStatus `json:"status"`
+	// LastTransitionTime indicates the last time the condition has transitioned from one status to another
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a brief CamelCase reason for the condition's last transition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message indicating details about the last transition
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type RepositoryList struct {
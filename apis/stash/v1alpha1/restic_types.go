@@ -63,6 +63,35 @@ type FileGroup struct {
 	Tags []string `json:"tags,omitempty"`
 	// retention policy of snapshots
 	RetentionPolicyName string `json:"retentionPolicyName,omitempty"`
+	// FileFilter specifies which files inside the path should be backed up
+	FileFilter `json:",inline"`
+}
+
+// FileFilter specifies the patterns to select/ignore files inside the target paths during backup
+type FileFilter struct {
+	// Includes specifies a list of glob patterns relative to the target path.
+	// If specified, only the files matching these patterns will be backed up.
+	// +optional
+	Includes []string `json:"includes,omitempty"`
+	// Excludes specifies a list of patterns for the files to ignore during backup.
+	// +optional
+	Excludes []string `json:"excludes,omitempty"`
+	// IExcludes is same as Excludes but the patterns are case insensitive.
+	// +optional
+	IExcludes []string `json:"iexcludes,omitempty"`
+	// ExcludeFiles specifies a list of files that contain exclude patterns (one pattern per line).
+	// +optional
+	ExcludeFiles []string `json:"excludeFiles,omitempty"`
+	// ExcludeCaches specifies whether to ignore the directories containing a CACHEDIR.TAG file.
+	// +optional
+	ExcludeCaches bool `json:"excludeCaches,omitempty"`
+	// ExcludeIfPresent specifies a list of files in "filename[:header]" format.
+	// A directory will be ignored if it contains any of these files.
+	// +optional
+	ExcludeIfPresent []string `json:"excludeIfPresent,omitempty"`
+	// OneFileSystem specifies whether to ignore the directories that are in different file system.
+	// +optional
+	OneFileSystem bool `json:"oneFileSystem,omitempty"`
 }
 
 type BackupType string
@@ -2,11 +2,15 @@ package v1alpha1
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"strings"
 
 	cron "github.com/robfig/cron/v3"
 )
 
+var readDataSubsetRegex = regexp.MustCompile(`^[1-9][0-9]*/[1-9][0-9]*$`)
+
 func (r Restic) IsValid() error {
 	for i, fg := range r.Spec.FileGroups {
 		if fg.RetentionPolicyName == "" {
@@ -25,6 +29,12 @@ func (r Restic) IsValid() error {
 		}
 	}
 
+	for i, fg := range r.Spec.FileGroups {
+		if err := fg.FileFilter.IsValid(); err != nil {
+			return fmt.Errorf("spec.fileGroups[%d] has invalid file filter. Reason: %s", i, err)
+		}
+	}
+
 	_, err := cron.ParseStandard(r.Spec.Schedule)
 	if err != nil {
 		return fmt.Errorf("spec.schedule %s is invalid. Reason: %s", r.Spec.Schedule, err)
@@ -73,5 +83,99 @@ func (r Repository) IsValid() error {
 			return fmt.Errorf("wipe out operation is not supported for B2 backend")
 		}
 	}
+	if m := r.Spec.Maintenance; m != nil {
+		if m.Check != nil {
+			if _, err := cron.ParseStandard(m.Check.Schedule); err != nil {
+				return fmt.Errorf("spec.maintenance.check.schedule %s is invalid. Reason: %s", m.Check.Schedule, err)
+			}
+			if m.Check.ReadDataSubset != "" && !readDataSubsetRegex.MatchString(m.Check.ReadDataSubset) {
+				return fmt.Errorf("spec.maintenance.check.readDataSubset %s is invalid. It must be in \"n/t\" format (i.e. \"1/5\")", m.Check.ReadDataSubset)
+			}
+		}
+		if m.Prune != nil {
+			if _, err := cron.ParseStandard(m.Prune.Schedule); err != nil {
+				return fmt.Errorf("spec.maintenance.prune.schedule %s is invalid. Reason: %s", m.Prune.Schedule, err)
+			}
+		}
+		if m.KeyRotation != nil {
+			if _, err := cron.ParseStandard(m.KeyRotation.Schedule); err != nil {
+				return fmt.Errorf("spec.maintenance.keyRotation.schedule %s is invalid. Reason: %s", m.KeyRotation.Schedule, err)
+			}
+		}
+	}
+	replicas := map[string]bool{}
+	for _, replica := range r.Spec.CopyTo {
+		if replica.Name == "" {
+			return fmt.Errorf("spec.copyTo.name must be specified")
+		}
+		if replica.Name == r.Name {
+			return fmt.Errorf("spec.copyTo.name %s is invalid. Reason: a Repository can't be copied to itself", replica.Name)
+		}
+		if replicas[replica.Name] {
+			return fmt.Errorf("spec.copyTo.name %s is invalid. Reason: duplicate replica", replica.Name)
+		}
+		replicas[replica.Name] = true
+		if replica.Schedule != "" {
+			if _, err := cron.ParseStandard(replica.Schedule); err != nil {
+				return fmt.Errorf("spec.copyTo.schedule %s of replica %s is invalid. Reason: %s", replica.Schedule, replica.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (f FileFilter) IsValid() error {
+	for _, pattern := range f.Includes {
+		if filepath.IsAbs(pattern) {
+			return fmt.Errorf("include pattern %q must be relative to the target path", pattern)
+		}
+		if err := validatePattern(pattern); err != nil {
+			return fmt.Errorf("include pattern %q is invalid. Reason: %s", pattern, err)
+		}
+		// restic expands the include patterns with filepath.Glob which does not support "**"
+		for _, part := range strings.Split(filepath.ToSlash(pattern), "/") {
+			if part == "**" {
+				return fmt.Errorf("include pattern %q is invalid. Reason: \"**\" is supported only in exclude patterns", pattern)
+			}
+		}
+	}
+	for _, pattern := range f.Excludes {
+		if err := validatePattern(pattern); err != nil {
+			return fmt.Errorf("exclude pattern %q is invalid. Reason: %s", pattern, err)
+		}
+	}
+	for _, pattern := range f.IExcludes {
+		if err := validatePattern(pattern); err != nil {
+			return fmt.Errorf("iexclude pattern %q is invalid. Reason: %s", pattern, err)
+		}
+	}
+	for _, file := range f.ExcludeFiles {
+		if strings.TrimSpace(file) == "" {
+			return fmt.Errorf("exclude file path must not be empty")
+		}
+	}
+	for _, file := range f.ExcludeIfPresent {
+		// format is "filename[:header]"
+		if strings.TrimSpace(strings.SplitN(file, ":", 2)[0]) == "" {
+			return fmt.Errorf("file name is missing in excludeIfPresent entry %q", file)
+		}
+	}
+	return nil
+}
+
+// validatePattern checks whether a pattern can be used by restic for matching files.
+// restic matches each component of the path separately and "**" matches any number of directories.
+func validatePattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("pattern must not be empty")
+	}
+	for _, part := range strings.Split(filepath.ToSlash(pattern), "/") {
+		if part == "**" {
+			continue
+		}
+		if _, err := filepath.Match(part, ""); err != nil {
+			return err
+		}
+	}
 	return nil
 }
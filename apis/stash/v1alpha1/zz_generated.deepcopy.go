@@ -22,10 +22,52 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	v1 "kmodules.xyz/objectstore-api/api/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileFilter) DeepCopyInto(out *FileFilter) {
+	*out = *in
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Excludes != nil {
+		in, out := &in.Excludes, &out.Excludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IExcludes != nil {
+		in, out := &in.IExcludes, &out.IExcludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeFiles != nil {
+		in, out := &in.ExcludeFiles, &out.ExcludeFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeIfPresent != nil {
+		in, out := &in.ExcludeIfPresent, &out.ExcludeIfPresent
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileFilter.
+func (in *FileFilter) DeepCopy() *FileFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(FileFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileGroup) DeepCopyInto(out *FileGroup) {
 	*out = *in
@@ -34,6 +76,7 @@ func (in *FileGroup) DeepCopyInto(out *FileGroup) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.FileFilter.DeepCopyInto(&out.FileFilter)
 	return
 }
 
@@ -47,6 +90,30 @@ func (in *FileGroup) DeepCopy() *FileGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotationStatus) DeepCopyInto(out *KeyRotationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationStatus.
+func (in *KeyRotationStatus) DeepCopy() *KeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalTypedReference) DeepCopyInto(out *LocalTypedReference) {
 	*out = *in
@@ -190,6 +257,34 @@ func (in *RecoveryStatus) DeepCopy() *RecoveryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
+	*out = *in
+	if in.LastCopyTime != nil {
+		in, out := &in.LastCopyTime, &out.LastCopyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulCopyTime != nil {
+		in, out := &in.LastSuccessfulCopyTime, &out.LastSuccessfulCopyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LatestCopiedSnapshotTime != nil {
+		in, out := &in.LatestCopiedSnapshotTime, &out.LatestCopiedSnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaStatus.
+func (in *ReplicaStatus) DeepCopy() *ReplicaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Repository) DeepCopyInto(out *Repository) {
 	*out = *in
@@ -218,6 +313,60 @@ func (in *Repository) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryCheck) DeepCopyInto(out *RepositoryCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryCheck.
+func (in *RepositoryCheck) DeepCopy() *RepositoryCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryCondition) DeepCopyInto(out *RepositoryCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryCondition.
+func (in *RepositoryCondition) DeepCopy() *RepositoryCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryKeyRotation) DeepCopyInto(out *RepositoryKeyRotation) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryKeyRotation.
+func (in *RepositoryKeyRotation) DeepCopy() *RepositoryKeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryKeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepositoryList) DeepCopyInto(out *RepositoryList) {
 	*out = *in
@@ -251,10 +400,100 @@ func (in *RepositoryList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryLock) DeepCopyInto(out *RepositoryLock) {
+	*out = *in
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryLock.
+func (in *RepositoryLock) DeepCopy() *RepositoryLock {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryLock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryMaintenance) DeepCopyInto(out *RepositoryMaintenance) {
+	*out = *in
+	if in.Check != nil {
+		in, out := &in.Check, &out.Check
+		*out = new(RepositoryCheck)
+		**out = **in
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(RepositoryPrune)
+		**out = **in
+	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(RepositoryKeyRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryMaintenance.
+func (in *RepositoryMaintenance) DeepCopy() *RepositoryMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryPrune) DeepCopyInto(out *RepositoryPrune) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryPrune.
+func (in *RepositoryPrune) DeepCopy() *RepositoryPrune {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryPrune)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryReplica) DeepCopyInto(out *RepositoryReplica) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryReplica.
+func (in *RepositoryReplica) DeepCopy() *RepositoryReplica {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryReplica)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
 	*out = *in
 	in.Backend.DeepCopyInto(&out.Backend)
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(RepositoryMaintenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CopyTo != nil {
+		in, out := &in.CopyTo, &out.CopyTo
+		*out = make([]RepositoryReplica, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -288,6 +527,48 @@ func (in *RepositoryStatus) DeepCopyInto(out *RepositoryStatus) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LastCheckTime != nil {
+		in, out := &in.LastCheckTime, &out.LastCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastPruneTime != nil {
+		in, out := &in.LastPruneTime, &out.LastPruneTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Locks != nil {
+		in, out := &in.Locks, &out.Locks
+		*out = make([]RepositoryLock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastLockCheckTime != nil {
+		in, out := &in.LastLockCheckTime, &out.LastLockCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(KeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]ReplicaStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]RepositoryCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
 	if in.LastSuccessfulBackupTime != nil {
 		in, out := &in.LastSuccessfulBackupTime, &out.LastSuccessfulBackupTime
 		*out = (*in).DeepCopy()
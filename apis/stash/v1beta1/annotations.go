@@ -8,9 +8,17 @@ const (
 	KeyTargetPaths     = StashKey + "/target-paths"
 	KeyVolumeMounts    = StashKey + "/volume-mounts"
 
+	// KeyExcludeFromAutoBackup opts a target out of the selectors of the BackupBlueprints
+	KeyExcludeFromAutoBackup = StashKey + "/exclude-from-auto-backup"
+
+	// KeyAllowedRestoreNamespaces is set on a Repository to list the namespaces (comma separated) whose RestoreSessions
+	// can restore from it. "*" allows all namespaces. A Repository can't be used from other namespaces without it.
+	KeyAllowedRestoreNamespaces = StashKey + "/allowed-restore-namespaces"
+
 	KeyLastAppliedRestoreSession      = StashKey + "/last-applied-restoresession"
 	KeyLastAppliedBackupConfiguration = StashKey + "/last-applied-backupconfiguration"
 
 	AppliedBackupConfigurationSpecHash = StashKey + "/last-applied-backupconfiguration-hash"
 	AppliedRestoreSessionSpecHash      = StashKey + "/last-applied-restoresession-hash"
+	AppliedBackupBlueprintSpecHash     = StashKey + "/last-applied-backupblueprint-hash"
 )
@@ -5,8 +5,12 @@ import (
 	"strconv"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	hashutil "k8s.io/kubernetes/pkg/util/hash"
 	crdutils "kmodules.xyz/client-go/apiextensions/v1beta1"
+	"stash.appscode.dev/stash/apis"
 )
 
 func (bb BackupBlueprint) GetSpecHash() string {
@@ -34,9 +38,10 @@ func (bb BackupBlueprint) CustomResourceDefinition() *apiextensions.CustomResour
 		Labels: crdutils.Labels{
 			LabelsMap: map[string]string{"app": "stash"},
 		},
-		SpecDefinitionName:    "stash.appscode.dev/stash/apis/stash/v1beta1.BackupBlueprint",
-		EnableValidation:      true,
-		GetOpenAPIDefinitions: GetOpenAPIDefinitions,
+		SpecDefinitionName:      "stash.appscode.dev/stash/apis/stash/v1beta1.BackupBlueprint",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: apis.EnableStatusSubresource,
 		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
 			{
 				Name:     "Task",
@@ -48,6 +53,16 @@ func (bb BackupBlueprint) CustomResourceDefinition() *apiextensions.CustomResour
 				Type:     "string",
 				JSONPath: ".spec.schedule",
 			},
+			{
+				Name:     "Targets",
+				Type:     "integer",
+				JSONPath: ".status.totalTargets",
+			},
+			{
+				Name:     "In-Sync",
+				Type:     "integer",
+				JSONPath: ".status.inSyncTargets",
+			},
 			{
 				Name:     "Age",
 				Type:     "date",
@@ -56,3 +71,43 @@ func (bb BackupBlueprint) CustomResourceDefinition() *apiextensions.CustomResour
 		},
 	})
 }
+
+// DefaultBackupBlueprintSelectorKinds are the kinds of the targets selected by a BackupBlueprint when it does not specify any
+var DefaultBackupBlueprintSelectorKinds = []string{
+	apis.KindDeployment,
+	apis.KindStatefulSet,
+	apis.KindPersistentVolumeClaim,
+	apis.KindAppBinding,
+}
+
+// TargetKinds returns the kinds of the targets selected by the selector
+func (s BackupBlueprintSelector) TargetKinds() []string {
+	if len(s.Kinds) == 0 {
+		return DefaultBackupBlueprintSelectorKinds
+	}
+	return s.Kinds
+}
+
+// Selects returns true if a target of the given kind, namespace and labels is selected by the selector
+func (s BackupBlueprintSelector) Selects(kind, namespace string, namespaceLabels, targetLabels map[string]string) (bool, error) {
+	if !sets.NewString(s.TargetKinds()...).Has(kind) {
+		return false, nil
+	}
+	if len(s.Namespaces) > 0 && !sets.NewString(s.Namespaces...).Has(namespace) {
+		return false, nil
+	}
+	if s.NamespaceSelector != nil {
+		nsSelector, err := metav1.LabelSelectorAsSelector(s.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		if !nsSelector.Matches(labels.Set(namespaceLabels)) {
+			return false, nil
+		}
+	}
+	selector, err := metav1.LabelSelectorAsSelector(s.LabelSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(targetLabels)), nil
+}
@@ -0,0 +1,174 @@
+package v1beta1
+
+import (
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"stash.appscode.dev/stash/apis"
+)
+
+func TestBackupBlueprintSelectorSelects(t *testing.T) {
+	appSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+	testCases := []struct {
+		name            string
+		selector        BackupBlueprintSelector
+		kind            string
+		namespace       string
+		namespaceLabels map[string]string
+		targetLabels    map[string]string
+		want            bool
+		wantErr         bool
+	}{
+		{
+			name:         "default kinds select a Deployment",
+			selector:     BackupBlueprintSelector{LabelSelector: appSelector},
+			kind:         apis.KindDeployment,
+			namespace:    "demo",
+			targetLabels: map[string]string{"app": "db"},
+			want:         true,
+		},
+		{
+			name:         "default kinds don't select a DaemonSet",
+			selector:     BackupBlueprintSelector{LabelSelector: appSelector},
+			kind:         apis.KindDaemonSet,
+			namespace:    "demo",
+			targetLabels: map[string]string{"app": "db"},
+		},
+		{
+			name:         "kinds restrict the selected targets",
+			selector:     BackupBlueprintSelector{Kinds: []string{apis.KindPersistentVolumeClaim}, LabelSelector: appSelector},
+			kind:         apis.KindDeployment,
+			namespace:    "demo",
+			targetLabels: map[string]string{"app": "db"},
+		},
+		{
+			name:         "target labels must match",
+			selector:     BackupBlueprintSelector{LabelSelector: appSelector},
+			kind:         apis.KindStatefulSet,
+			namespace:    "demo",
+			targetLabels: map[string]string{"app": "web"},
+		},
+		{
+			name:         "empty label selector selects every target",
+			selector:     BackupBlueprintSelector{LabelSelector: &metav1.LabelSelector{}},
+			kind:         apis.KindAppBinding,
+			namespace:    "demo",
+			targetLabels: nil,
+			want:         true,
+		},
+		{
+			name:         "namespaces restrict the selected targets",
+			selector:     BackupBlueprintSelector{Namespaces: []string{"prod"}, LabelSelector: appSelector},
+			kind:         apis.KindDeployment,
+			namespace:    "demo",
+			targetLabels: map[string]string{"app": "db"},
+		},
+		{
+			name: "namespace selector matches the namespace labels",
+			selector: BackupBlueprintSelector{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"backup": "enabled"}},
+				LabelSelector:     appSelector,
+			},
+			kind:            apis.KindDeployment,
+			namespace:       "demo",
+			namespaceLabels: map[string]string{"backup": "enabled"},
+			targetLabels:    map[string]string{"app": "db"},
+			want:            true,
+		},
+		{
+			name: "namespace selector does not match the namespace labels",
+			selector: BackupBlueprintSelector{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"backup": "enabled"}},
+				LabelSelector:     appSelector,
+			},
+			kind:         apis.KindDeployment,
+			namespace:    "demo",
+			targetLabels: map[string]string{"app": "db"},
+		},
+		{
+			name: "invalid label selector returns error",
+			selector: BackupBlueprintSelector{LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+			}},
+			kind:      apis.KindDeployment,
+			namespace: "demo",
+			wantErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.selector.Selects(tc.kind, tc.namespace, tc.namespaceLabels, tc.targetLabels)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error: %v, found: %v", tc.wantErr, err)
+			}
+			if got != tc.want {
+				t.Errorf("expected %v, found %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestBackupBlueprintIsValidSelector(t *testing.T) {
+	appSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+	paths := []string{"/data"}
+	volumeMounts := []core.VolumeMount{{Name: "data", MountPath: "/data"}}
+	testCases := []struct {
+		name     string
+		selector *BackupBlueprintSelector
+		wantErr  bool
+	}{
+		{
+			name: "no selector",
+		},
+		{
+			name:     "default kinds with paths and volumeMounts",
+			selector: &BackupBlueprintSelector{LabelSelector: appSelector, Paths: paths, VolumeMounts: volumeMounts},
+		},
+		{
+			name:     "default kinds without paths",
+			selector: &BackupBlueprintSelector{LabelSelector: appSelector, VolumeMounts: volumeMounts},
+			wantErr:  true,
+		},
+		{
+			name:     "workload kind without volumeMounts",
+			selector: &BackupBlueprintSelector{Kinds: []string{apis.KindDaemonSet}, LabelSelector: appSelector, Paths: paths},
+			wantErr:  true,
+		},
+		{
+			name:     "PersistentVolumeClaim and AppBinding don't need paths and volumeMounts",
+			selector: &BackupBlueprintSelector{Kinds: []string{apis.KindPersistentVolumeClaim, apis.KindAppBinding}, LabelSelector: appSelector},
+		},
+		{
+			name:     "unsupported kind",
+			selector: &BackupBlueprintSelector{Kinds: []string{"CronJob"}, LabelSelector: appSelector},
+			wantErr:  true,
+		},
+		{
+			name:     "missing label selector",
+			selector: &BackupBlueprintSelector{Kinds: []string{apis.KindPersistentVolumeClaim}},
+			wantErr:  true,
+		},
+		{
+			name: "invalid namespace selector",
+			selector: &BackupBlueprintSelector{
+				Kinds:         []string{apis.KindPersistentVolumeClaim},
+				LabelSelector: appSelector,
+				NamespaceSelector: &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "backup", Operator: "Unknown"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := BackupBlueprint{Spec: BackupBlueprintSpec{Selector: tc.selector}}
+			if err := b.isValidSelector(); (err != nil) != tc.wantErr {
+				t.Errorf("expected error: %v, found: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
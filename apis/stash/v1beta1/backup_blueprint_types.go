@@ -1,6 +1,7 @@
 package v1beta1
 
 import (
+	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ofst "kmodules.xyz/offshoot-api/api/v1"
 	"stash.appscode.dev/stash/apis/stash/v1alpha1"
@@ -20,7 +21,8 @@ const (
 type BackupBlueprint struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Spec              BackupBlueprintSpec `json:"spec,omitempty"`
+	Spec              BackupBlueprintSpec   `json:"spec,omitempty"`
+	Status            BackupBlueprintStatus `json:"status,omitempty"`
 }
 
 type BackupBlueprintSpec struct {
@@ -32,13 +34,64 @@ type BackupBlueprintSpec struct {
 	Task TaskRef `json:"task,omitempty"`
 	// RetentionPolicy indicates the policy to follow to clean old backup snapshots
 	RetentionPolicy v1alpha1.RetentionPolicy `json:"retentionPolicy,omitempty"`
+	// Tags specifies the tags that will be added to the snapshots taken by the respective BackupConfiguration
+	// +optional
+	Tags []string `json:"tags,omitempty"`
 	// RuntimeSettings allow to specify Resources, NodeSelector, Affinity, Toleration, ReadinessProbe etc.
 	//+optional
 	RuntimeSettings ofst.RuntimeSettings `json:"runtimeSettings,omitempty"`
+	// BandwidthLimit specifies the maximum upload and download rate to the backend
+	// +optional
+	BandwidthLimit *BandwidthLimit `json:"bandwidthLimit,omitempty"`
 	// Temp directory configuration for functions/sidecar
 	// An `EmptyDir` will always be mounted at /tmp with this settings
 	// +optional
 	TempDir EmptyDirSettings `json:"tempDir,omitempty"`
+	// Selector selects the targets that are backed up using this BackupBlueprint without any annotation on them
+	// +optional
+	Selector *BackupBlueprintSelector `json:"selector,omitempty"`
+}
+
+type BackupBlueprintSelector struct {
+	// Namespaces specifies the namespaces of the targets. The targets of every namespace are selected if it is empty.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// NamespaceSelector selects the namespaces of the targets by their labels
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Kinds specifies the kinds of the targets.
+	// Deployment, StatefulSet, PersistentVolumeClaim and AppBinding are selected if it is empty.
+	// +optional
+	Kinds []string `json:"kinds,omitempty"`
+	// LabelSelector selects the targets by their labels. Use an empty selector to select every target of the namespaces.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// Paths specifies the directories to backup from the selected workloads. It is required if any workload kind is selected.
+	// The target-paths annotation of a workload takes precedence over it.
+	// +optional
+	Paths []string `json:"paths,omitempty"`
+	// VolumeMounts specifies the volumes of the selected workloads to mount into the backup sidecar.
+	// It is required if any workload kind is selected.
+	// The volume-mounts annotation of a workload takes precedence over it.
+	// +optional
+	VolumeMounts []core.VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+type BackupBlueprintStatus struct {
+	// ObservedGeneration is the most recent generation of the BackupBlueprint observed by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// SpecHash is the hash of the spec of the BackupBlueprint that the targets are synced with
+	// +optional
+	SpecHash string `json:"specHash,omitempty"`
+	// TotalTargets is the number of targets whose Repository and BackupConfiguration have been generated from this BackupBlueprint
+	// +optional
+	TotalTargets int32 `json:"totalTargets"`
+	// InSyncTargets is the number of targets whose Repository and BackupConfiguration are up to date with the current spec
+	// +optional
+	InSyncTargets int32 `json:"inSyncTargets"`
+	// LastSyncTime is the time when the generated resources have been last reconciled with this BackupBlueprint
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -4,10 +4,13 @@ import (
 	"hash/fnv"
 	"strconv"
 
+	core "k8s.io/api/core/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	hashutil "k8s.io/kubernetes/pkg/util/hash"
 	crdutils "kmodules.xyz/client-go/apiextensions/v1beta1"
 	meta_util "kmodules.xyz/client-go/meta"
+	"stash.appscode.dev/stash/apis"
 )
 
 func (b BackupConfiguration) GetSpecHash() string {
@@ -35,9 +38,10 @@ func (b BackupConfiguration) CustomResourceDefinition() *apiextensions.CustomRes
 		Labels: crdutils.Labels{
 			LabelsMap: map[string]string{"app": "stash"},
 		},
-		SpecDefinitionName:    "stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfiguration",
-		EnableValidation:      true,
-		GetOpenAPIDefinitions: GetOpenAPIDefinitions,
+		SpecDefinitionName:      "stash.appscode.dev/stash/apis/stash/v1beta1.BackupConfiguration",
+		EnableValidation:        true,
+		GetOpenAPIDefinitions:   GetOpenAPIDefinitions,
+		EnableStatusSubresource: apis.EnableStatusSubresource,
 		AdditionalPrinterColumns: []apiextensions.CustomResourceColumnDefinition{
 			{
 				Name:     "Task",
@@ -54,6 +58,11 @@ func (b BackupConfiguration) CustomResourceDefinition() *apiextensions.CustomRes
 				Type:     "boolean",
 				JSONPath: ".spec.paused",
 			},
+			{
+				Name:     "Last-Successful-Backup",
+				Type:     "date",
+				JSONPath: ".status.lastSuccessfulBackupTime",
+			},
 			{
 				Name:     "Age",
 				Type:     "date",
@@ -73,6 +82,48 @@ func (b BackupConfiguration) OffshootLabels() map[string]string {
 	return upsertLabels(b.Labels, overrides)
 }
 
+// GetCondition returns the condition of the given type. It returns nil if the condition is not present.
+func (s BackupConfigurationStatus) GetCondition(condType BackupConfigurationConditionType) *BackupConfigurationCondition {
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == condType {
+			return &s.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds or updates a condition. The transition time is updated only when the status of the condition changes.
+func (s *BackupConfigurationStatus) SetCondition(condType BackupConfigurationConditionType, status core.ConditionStatus, reason, message string) {
+	newCond := BackupConfigurationCondition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: metav1.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+	for i := range s.Conditions {
+		if s.Conditions[i].Type == condType {
+			if s.Conditions[i].Status == status {
+				newCond.LastTransitionTime = s.Conditions[i].LastTransitionTime
+			}
+			s.Conditions[i] = newCond
+			return
+		}
+	}
+	s.Conditions = append(s.Conditions, newCond)
+}
+
+// RemoveCondition removes the condition of the given type
+func (s *BackupConfigurationStatus) RemoveCondition(condType BackupConfigurationConditionType) {
+	conditions := make([]BackupConfigurationCondition, 0, len(s.Conditions))
+	for _, c := range s.Conditions {
+		if c.Type != condType {
+			conditions = append(conditions, c)
+		}
+	}
+	s.Conditions = conditions
+}
+
 func upsertLabels(originalLabels, overrides map[string]string) map[string]string {
 	if originalLabels == nil {
 		originalLabels = make(map[string]string, len(overrides))
@@ -21,7 +21,8 @@ const (
 type BackupConfiguration struct {
 	metav1.TypeMeta   `json:",inline,omitempty"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
-	Spec              BackupConfigurationSpec `json:"spec,omitempty"`
+	Spec              BackupConfigurationSpec   `json:"spec,omitempty"`
+	Status            BackupConfigurationStatus `json:"status,omitempty"`
 }
 
 type BackupConfigurationSpec struct {
@@ -42,18 +43,173 @@ type BackupConfigurationSpec struct {
 	Target *BackupTarget `json:"target,omitempty"`
 	// RetentionPolicy indicates the policy to follow to clean old backup snapshots
 	RetentionPolicy v1alpha1.RetentionPolicy `json:"retentionPolicy,omitempty"`
+	// Tags specifies the tags that will be added to the snapshots in addition to the tags Stash adds automatically.
+	// These tags can be used in the rules of a RestoreSession and in the "keepTags" of the retention policy.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// Timeout specifies the maximum duration of a BackupSession. If the BackupSession does not complete within
+	// this duration, it is marked as "Failed" and the backup job is killed. It is counted from the creation of the BackupSession.
+	// So, it includes the time spent waiting in "Pending" phase and the time taken by the retries.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// BackoffLimit specifies the number of times the backup of a failed host will be retried before marking the
+	// BackupSession as "Failed". The retries are performed with exponential backoff. Default value is 0.
+	// +optional
+	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ConcurrencyPolicy specifies how to treat a new BackupSession when the previous BackupSession is still running.
+	// Supported values are "Allow", "Forbid" and "Replace". Default value is "Allow".
+	// +optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+	// BackupHistoryLimit specifies how many completed BackupSessions to keep for this BackupConfiguration.
+	// If not specified, the completed BackupSessions are never removed.
+	// +optional
+	BackupHistoryLimit *BackupHistoryLimit `json:"backupHistoryLimit,omitempty"`
+	// Hooks specifies the actions to perform before and after taking backup.
+	// They are supported only for the workloads that are backed up by the sidecar using Restic driver.
+	// +optional
+	Hooks *BackupHooks `json:"hooks,omitempty"`
 	// Indicates that the BackupConfiguration is paused from taking backup. Default value is 'false'
 	// +optional
 	Paused bool `json:"paused,omitempty"`
 	// RuntimeSettings allow to specify Resources, NodeSelector, Affinity, Toleration, ReadinessProbe etc.
 	//+optional
 	RuntimeSettings ofst.RuntimeSettings `json:"runtimeSettings,omitempty"`
+	// BandwidthLimit specifies the maximum upload and download rate to the backend
+	// +optional
+	BandwidthLimit *BandwidthLimit `json:"bandwidthLimit,omitempty"`
 	// Temp directory configuration for functions/sidecar
 	// An `EmptyDir` will always be mounted at /tmp with this settings
 	//+optional
 	TempDir EmptyDirSettings `json:"tempDir,omitempty"`
 }
 
+const (
+	// Keys of the tags that Stash adds to every snapshot. The tags are formatted as "<key>=<value>".
+	SnapshotTagBackupSession       = "backup-session"
+	SnapshotTagBackupConfiguration = "backup-configuration"
+	SnapshotTagTargetKind          = "target-kind"
+	SnapshotTagTargetName          = "target-name"
+	SnapshotTagAppVersion          = "app-version"
+)
+
+type BackupConfigurationStatus struct {
+	// ObservedGeneration is the most recent generation observed for this BackupConfiguration. It corresponds to the
+	// BackupConfiguration's generation, which is updated on mutation by the API Server.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions shows the current state of the resources required to take backup
+	// +optional
+	Conditions []BackupConfigurationCondition `json:"conditions,omitempty"`
+	// LastBackupSession indicates the name of the last completed BackupSession
+	// +optional
+	LastBackupSession string `json:"lastBackupSession,omitempty"`
+	// LastSuccessfulBackupTime indicates the time when the last successful BackupSession has completed
+	// +optional
+	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
+	// NextScheduledTime indicates the time when the next BackupSession will be created according to the schedule
+	// +optional
+	NextScheduledTime *metav1.Time `json:"nextScheduledTime,omitempty"`
+	// Verification shows the result of the last restore verification of the backups
+	// +optional
+	Verification *RestoreVerificationStatus `json:"verification,omitempty"`
+}
+
+type BackupConfigurationConditionType string
+
+const (
+	// CronJobCreated indicates whether the CronJob that triggers the BackupSessions has been created
+	CronJobCreated BackupConfigurationConditionType = "CronJobCreated"
+	// SidecarInjected indicates whether the backup sidecar has been injected into the target workload.
+	// It is set only for the targets that are backed up by a sidecar.
+	SidecarInjected BackupConfigurationConditionType = "SidecarInjected"
+	// RepositoryReady indicates whether the Repository referred by the BackupConfiguration exists
+	RepositoryReady BackupConfigurationConditionType = "RepositoryReady"
+	// BackendReachable indicates whether the backend was reachable during the last backup
+	BackendReachable BackupConfigurationConditionType = "BackendReachable"
+)
+
+type BackupConfigurationCondition struct {
+	// Type of the condition
+	Type BackupConfigurationConditionType `json:"type"`
+	// Status of the condition. One of "True", "False" or "Unknown".
+	Status core.ConditionStatus `json:"status"`
+	// LastTransitionTime indicates the last time the condition has transitioned from one status to another
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is a brief CamelCase reason for the condition's last transition
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable message indicating details about the last transition
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+type VerificationPhase string
+
+const (
+	VerificationSucceeded VerificationPhase = "Succeeded"
+	VerificationFailed    VerificationPhase = "Failed"
+)
+
+type RestoreVerificationStatus struct {
+	// RestoreSession indicates the name of the RestoreSession that has run the verification
+	// +optional
+	RestoreSession string `json:"restoreSession,omitempty"`
+	// Phase indicates whether the verification has succeeded or failed
+	// +optional
+	Phase VerificationPhase `json:"phase,omitempty"`
+	// LastVerificationTime indicates the time when the verification has completed
+	// +optional
+	LastVerificationTime *metav1.Time `json:"lastVerificationTime,omitempty"`
+	// Duration indicates the time taken to complete the verification
+	// +optional
+	Duration string `json:"duration,omitempty"`
+	// Snapshots indicates the ids of the snapshots that has been verified
+	// +optional
+	Snapshots []string `json:"snapshots,omitempty"`
+	// Checks shows the result of the individual checks
+	// +optional
+	Checks []VerificationCheckStatus `json:"checks,omitempty"`
+	// Error indicates string value of error in case of verification failure
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+type VerificationCheckStatus struct {
+	// Name of the check
+	Name string `json:"name"`
+	// Phase indicates whether the check has succeeded or failed
+	Phase VerificationPhase `json:"phase"`
+	// Reason indicates why the check has failed
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+type ConcurrencyPolicy string
+
+const (
+	// AllowConcurrent allows a new BackupSession to run concurrently with the previous BackupSession
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// ForbidConcurrent skips the new BackupSession if the previous BackupSession is still running
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// ReplaceConcurrent marks the running BackupSession as "Failed" and starts the new BackupSession
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+// BackupHistoryLimit specifies the policy to cleanup old BackupSessions.
+// The most recent succeeded BackupSession is never removed.
+type BackupHistoryLimit struct {
+	// Succeeded specifies the number of succeeded BackupSessions to keep
+	// +optional
+	Succeeded *int32 `json:"succeeded,omitempty"`
+	// Failed specifies the number of failed or skipped BackupSessions to keep
+	// +optional
+	Failed *int32 `json:"failed,omitempty"`
+	// TTL specifies the duration after which a completed BackupSession is removed
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+}
+
 type EmptyDirSettings struct {
 	Medium    core.StorageMedium `json:"medium,omitempty"`
 	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
@@ -61,6 +217,16 @@ type EmptyDirSettings struct {
 	DisableCaching bool `json:"disableCaching,omitempty"`
 }
 
+// BandwidthLimit specifies the maximum rate at which restic transfers data to and from the backend
+type BandwidthLimit struct {
+	// LimitUpload specifies the maximum upload rate in KiB/s. No limit is applied if it is zero.
+	// +optional
+	LimitUpload int32 `json:"limitUpload,omitempty"`
+	// LimitDownload specifies the maximum download rate in KiB/s. No limit is applied if it is zero.
+	// +optional
+	LimitDownload int32 `json:"limitDownload,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type BackupConfigurationList struct {
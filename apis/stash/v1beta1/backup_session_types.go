@@ -64,6 +64,12 @@ type BackupSessionStatus struct {
 	// Stats shows statistics of individual hosts for this backup session
 	// +optional
 	Stats []HostBackupStats `json:"stats,omitempty"`
+	// Retried indicates the number of times the backup of the failed hosts has been retried
+	// +optional
+	Retried int32 `json:"retried,omitempty"`
+	// NextRetryTime indicates the time when the backup of the failed hosts will be retried
+	// +optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
 }
 
 type HostBackupStats struct {
@@ -82,6 +88,13 @@ type HostBackupStats struct {
 	// Error indicates string value of error in case of backup failure
 	// +optional
 	Error string `json:"error,omitempty"`
+	// RemovedSnapshots indicates the name of the old snapshots that has been removed for this host
+	// according to the retention policy in current backup session. Currently, it is used by VolumeSnapshotter driver only.
+	// +optional
+	RemovedSnapshots []string `json:"removedSnapshots,omitempty"`
+	// Hooks shows the result of the hooks that has been executed for this host
+	// +optional
+	Hooks []HookStats `json:"hooks,omitempty"`
 }
 
 type SnapshotStats struct {
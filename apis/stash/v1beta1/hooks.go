@@ -0,0 +1,107 @@
+package v1beta1
+
+import (
+	core "k8s.io/api/core/v1"
+)
+
+type HookFailurePolicy string
+
+const (
+	// HookFailurePolicyFail aborts the backup/restore process if the hook fails
+	HookFailurePolicyFail HookFailurePolicy = "Fail"
+	// HookFailurePolicyIgnore ignores the hook failure and continue the backup/restore process
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+)
+
+const (
+	HookPreBackup   = "preBackup"
+	HookPostBackup  = "postBackup"
+	HookPreRestore  = "preRestore"
+	HookPostRestore = "postRestore"
+)
+
+type HookPhase string
+
+const (
+	HookSucceeded HookPhase = "Succeeded"
+	HookFailed    HookPhase = "Failed"
+)
+
+// BackupHooks specifies the hooks to execute before and after backup
+type BackupHooks struct {
+	// PreBackup specifies the hook that will be executed before taking backup
+	// +optional
+	PreBackup *Hook `json:"preBackup,omitempty"`
+	// PostBackup specifies the hook that will be executed after taking backup.
+	// It is executed even if the backup has failed.
+	// +optional
+	PostBackup *Hook `json:"postBackup,omitempty"`
+}
+
+// RestoreHooks specifies the hooks to execute before and after restore
+type RestoreHooks struct {
+	// PreRestore specifies the hook that will be executed before restoring
+	// +optional
+	PreRestore *Hook `json:"preRestore,omitempty"`
+	// PostRestore specifies the hook that will be executed after restoring.
+	// It is executed even if the restore has failed.
+	// +optional
+	PostRestore *Hook `json:"postRestore,omitempty"`
+}
+
+// Hook specifies an action to execute before or after backup/restore.
+// Only one of Exec, HTTPGet and FSFreeze should be specified.
+type Hook struct {
+	// Exec specifies a command to execute
+	// +optional
+	Exec *ExecHook `json:"exec,omitempty"`
+	// HTTPGet specifies the http request to perform.
+	// If host is not specified, the request is sent to the pod where backup/restore is running.
+	// +optional
+	HTTPGet *core.HTTPGetAction `json:"httpGet,omitempty"`
+	// FSFreeze freezes the file systems of the target before backup and unfreezes them once the backup is completed.
+	// It can be used only as preBackup hook. The stash container must run as root with "SYS_ADMIN" capability
+	// or in privileged mode. Set them through runtimeSettings.container.securityContext of the BackupConfiguration.
+	// +optional
+	FSFreeze *FSFreezeHook `json:"fsFreeze,omitempty"`
+	// TimeoutSeconds specifies the number of seconds after which the hook times out.
+	// Default value is 60 seconds.
+	// +optional
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// FailurePolicy specifies what to do if the hook fails.
+	// Supported values are "Fail" and "Ignore". Default value is "Fail".
+	// +optional
+	FailurePolicy HookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+type ExecHook struct {
+	// Container specifies the name of the container where the command will be executed.
+	// The container must be running in the same pod. If not specified, the command is executed in the stash container.
+	// +optional
+	Container string `json:"container,omitempty"`
+	// Command is the command line to execute. The command is not run inside a shell.
+	// To use a shell, you need to explicitly call out to that shell.
+	Command []string `json:"command"`
+}
+
+type FSFreezeHook struct {
+	// MountPaths specifies the mount paths of the file systems to freeze.
+	// If not specified, the mount paths of the target volumeMounts are used.
+	// +optional
+	MountPaths []string `json:"mountPaths,omitempty"`
+}
+
+type HookStats struct {
+	// Name indicates the name of the hook i.e. preBackup, postBackup, preRestore, postRestore
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Phase indicates whether the hook has succeeded or failed
+	// +optional
+	Phase HookPhase `json:"phase,omitempty"`
+	// Duration indicates the time taken to execute the hook
+	// +optional
+	Duration string `json:"duration,omitempty"`
+	// Error indicates string value of error in case of hook failure
+	// +optional
+	Error string `json:"error,omitempty"`
+}
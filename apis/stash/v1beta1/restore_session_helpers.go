@@ -69,3 +69,11 @@ func (r RestoreSession) OffshootLabels() map[string]string {
 
 	return upsertLabels(r.Labels, overrides)
 }
+
+// RepositoryNamespace returns the namespace of the Repository referred by the RestoreSession.
+func (r RestoreSession) RepositoryNamespace() string {
+	if r.Spec.Repository.Namespace != "" {
+		return r.Spec.Repository.Namespace
+	}
+	return r.Namespace
+}
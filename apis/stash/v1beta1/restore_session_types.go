@@ -30,9 +30,12 @@ type RestoreSessionSpec struct {
 	// Default value is "Restic".
 	// +optional
 	Driver Snapshotter `json:"driver,omitempty"`
-	// Repository refer to the Repository crd that hold backend information
+	// Repository refer to the Repository crd that hold backend information.
+	// The Repository can be in a different namespace than the RestoreSession. In this case,
+	// the user creating the RestoreSession must have permission to read the Repository and the Repository
+	// must list the namespace of the RestoreSession in "stash.appscode.com/allowed-restore-namespaces" annotation.
 	// +optional
-	Repository core.LocalObjectReference `json:"repository,omitempty"`
+	Repository RepositoryRef `json:"repository,omitempty"`
 	// Task specify the Task crd that specifies the steps for recovery process
 	// +optional
 	Task TaskRef `json:"task,omitempty"`
@@ -42,13 +45,86 @@ type RestoreSessionSpec struct {
 	// Rules specifies different restore options for different hosts
 	// +optional
 	Rules []Rule `json:"rules,omitempty"`
+	// Hooks specifies the actions to perform before and after restore.
+	// They are supported only for the workloads that are restored by the init-container using Restic driver.
+	// +optional
+	Hooks *RestoreHooks `json:"hooks,omitempty"`
 	// RuntimeSettings allow to specify Resources, NodeSelector, Affinity, Toleration, ReadinessProbe etc.
 	//+optional
 	RuntimeSettings ofst.RuntimeSettings `json:"runtimeSettings,omitempty"`
+	// BandwidthLimit specifies the maximum upload and download rate to the backend
+	// +optional
+	BandwidthLimit *BandwidthLimit `json:"bandwidthLimit,omitempty"`
 	// Temp directory configuration for functions/sidecar
 	// An `EmptyDir` will always be mounted at /tmp with this settings
 	//+optional
 	TempDir EmptyDirSettings `json:"tempDir,omitempty"`
+	// Verification runs the RestoreSession in verify mode. In this mode, the latest snapshots are periodically
+	// restored into a scratch directory instead of the target and the restored data is checked.
+	// The result is recorded in the status of the respective BackupConfiguration.
+	// +optional
+	Verification *RestoreVerification `json:"verification,omitempty"`
+}
+
+type RestoreVerification struct {
+	// Schedule specifies the cron schedule to run the verification
+	Schedule string `json:"schedule"`
+	// BackupConfiguration refers to the BackupConfiguration whose backups are verified.
+	// The snapshots are restored from its Repository for its target host.
+	BackupConfiguration core.LocalObjectReference `json:"backupConfiguration"`
+	// ScratchDir specifies the settings of the EmptyDir where the snapshots are restored.
+	// The restored data is removed after the verification.
+	// +optional
+	ScratchDir EmptyDirSettings `json:"scratchDir,omitempty"`
+	// Checks specifies the checks to run against the restored data.
+	// If no check is specified, the verification only ensures that the snapshots can be restored.
+	// +optional
+	Checks []VerificationCheck `json:"checks,omitempty"`
+	// Paused indicates that the verification is paused. Default value is 'false'
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// VerificationCheck specifies a check to run against the restored data.
+// Exactly one of fileCount, checksum or exec must be specified.
+// The paths are the original paths of the backed up files.
+type VerificationCheck struct {
+	// Name of the check
+	Name string `json:"name"`
+	// FileCount checks the number of files under a directory
+	// +optional
+	FileCount *FileCountCheck `json:"fileCount,omitempty"`
+	// Checksum checks the checksum of a file
+	// +optional
+	Checksum *ChecksumCheck `json:"checksum,omitempty"`
+	// Exec runs a command. The check fails if the command exits with a non-zero code.
+	// The directory where the data has been restored is passed as working directory and
+	// in "STASH_RESTORE_DIR" environment variable.
+	// +optional
+	Exec *ExecCheck `json:"exec,omitempty"`
+}
+
+type FileCountCheck struct {
+	// Path of the directory whose files will be counted recursively
+	Path string `json:"path"`
+	// Min specifies the minimum number of files expected
+	// +optional
+	Min *int64 `json:"min,omitempty"`
+	// Max specifies the maximum number of files expected
+	// +optional
+	Max *int64 `json:"max,omitempty"`
+}
+
+type ChecksumCheck struct {
+	// Path of the file
+	Path string `json:"path"`
+	// SHA256 specifies the expected hex encoded sha256 checksum of the file
+	SHA256 string `json:"sha256"`
+}
+
+type ExecCheck struct {
+	// Command to run
+	Command []string `json:"command"`
 }
 
 type Rule struct {
@@ -67,6 +143,46 @@ type Rule struct {
 	// Don't specify if you have specified snapshots field.
 	// +optional
 	Paths []string `json:"paths,omitempty"`
+	// Before selects the latest snapshot that has been taken before this time for each path.
+	// Don't specify if you have specified snapshots field.
+	// +optional
+	Before *metav1.Time `json:"before,omitempty"`
+	// After selects the latest snapshot that has been taken after this time for each path.
+	// Don't specify if you have specified snapshots field.
+	// +optional
+	After *metav1.Time `json:"after,omitempty"`
+	// Tags selects the latest snapshot that has all of these tags for each path.
+	// Don't specify if you have specified snapshots field.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+	// Destination specifies the directory where the data will be restored.
+	// The files are restored at their original path inside this directory.
+	// Default is "/" which restores the files at their original path.
+	// +optional
+	Destination string `json:"destination,omitempty"`
+	// PathMapping maps a backed up path to the directory where its content will be restored.
+	// i.e. "/source/data": "/restore/data" restores "/source/data/file" at "/restore/data/file".
+	// If it is specified along with snapshots field, only the mapped paths of the snapshots are restored.
+	// +optional
+	PathMapping map[string]string `json:"pathMapping,omitempty"`
+	// Includes specifies a list of patterns for the files to restore.
+	// If specified, only the files matching these patterns will be restored.
+	// +optional
+	Includes []string `json:"includes,omitempty"`
+	// Excludes specifies a list of patterns for the files to ignore during restore.
+	// Don't specify if you have specified includes field.
+	// +optional
+	Excludes []string `json:"excludes,omitempty"`
+}
+
+// RepositoryRef refers to a Repository. If namespace is not specified,
+// the Repository is looked up in the namespace of the referring object.
+type RepositoryRef struct {
+	// Name of the Repository
+	Name string `json:"name"`
+	// Namespace of the Repository
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -128,4 +244,11 @@ type HostRestoreStats struct {
 	// Error indicates string value of error in case of restore failure
 	// +optional
 	Error string `json:"error,omitempty"`
+	// Hooks shows the result of the hooks that has been executed for this host
+	// +optional
+	Hooks []HookStats `json:"hooks,omitempty"`
+	// Snapshots indicates the ids of the snapshots that has been restored for this host.
+	// They can be used in the snapshots field of a rule to reproduce the restore.
+	// +optional
+	Snapshots []string `json:"snapshots,omitempty"`
 }
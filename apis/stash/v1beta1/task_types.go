@@ -35,8 +35,29 @@ type FunctionRef struct {
 	// Inputs specifies the inputs of respective Function
 	// +optional
 	Params []Param `json:"params,omitempty"`
+	// When specifies when this step runs depending on the result of the previous steps.
+	// Allowed values are "OnSuccess", "OnFailure" and "Always". Default is "OnSuccess".
+	// The Function of a step that specifies When or ParallelGroup must specify its Command.
+	// +optional
+	When StepCondition `json:"when,omitempty"`
+	// ParallelGroup specifies the group of this step. Consecutive steps of the same group run in parallel.
+	// The steps of a group start after all the previous steps have completed.
+	// +optional
+	ParallelGroup string `json:"parallelGroup,omitempty"`
 }
 
+// StepCondition specifies when a step runs depending on the result of the previous steps
+type StepCondition string
+
+const (
+	// StepOnSuccess runs the step only if all the previous steps have succeeded
+	StepOnSuccess StepCondition = "OnSuccess"
+	// StepOnFailure runs the step only if any of the previous steps has failed
+	StepOnFailure StepCondition = "OnFailure"
+	// StepAlways runs the step regardless of the result of the previous steps
+	StepAlways StepCondition = "Always"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type TaskList struct {
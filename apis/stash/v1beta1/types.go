@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	core "k8s.io/api/core/v1"
+	"stash.appscode.dev/stash/apis/stash/v1alpha1"
 )
 
 // Param declares a value to use for the Param called Name.
@@ -34,6 +35,10 @@ type BackupTarget struct {
 	// Use this field only if the "driver" field is set to "volumeSnapshotter".
 	// +optional
 	VolumeSnapshotClassName string `json:"snapshotClassName,omitempty"`
+	// FileFilter specifies which files inside the target paths should be backed up.
+	// Use this field only if the "driver" field is set to "Restic".
+	// +optional
+	v1alpha1.FileFilter `json:",inline"`
 }
 
 type RestoreTarget struct {
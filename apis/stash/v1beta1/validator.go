@@ -1,6 +1,386 @@
 package v1beta1
 
-import "fmt"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"stash.appscode.dev/stash/apis"
+)
+
+func (b BackupConfiguration) IsValid() error {
+	if err := isValidSchedule(b.Spec.Schedule); err != nil {
+		return fmt.Errorf("\n\t"+
+			"Error: Invalid BackupConfiguration specification.\n\t"+
+			"Reason: spec.schedule %s.\n\t"+
+			"Hints: Schedule must follow the standard cron format (i.e. \"*/5 * * * *\", \"@daily\").", err)
+	}
+	if err := b.isValidTarget(); err != nil {
+		return fmt.Errorf("\n\t"+
+			"Error: Invalid BackupConfiguration specification.\n\t"+
+			"Reason: %s.\n\t"+
+			"Hints: VolumeSnapshotter driver supports workloads and PersistentVolumeClaim. "+
+			"Restic driver supports workloads, PersistentVolumeClaim and AppBinding. "+
+			"PersistentVolumeClaim and AppBinding require a Task.", err)
+	}
+	if b.Spec.Target != nil {
+		if err := b.Spec.Target.FileFilter.IsValid(); err != nil {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid BackupConfiguration specification.\n\t"+
+				"Reason: spec.target has invalid file filter. %s.\n\t"+
+				"Hints: Patterns must follow the restic pattern format (i.e. \"*.log\", \"cache/**\").", err)
+		}
+	}
+	if (b.Spec.Timeout != nil && b.Spec.Timeout.Duration <= 0) || (b.Spec.BackoffLimit != nil && *b.Spec.BackoffLimit < 0) {
+		return fmt.Errorf("\n\t" +
+			"Error: Invalid BackupConfiguration specification.\n\t" +
+			"Reason: spec.timeout or spec.backoffLimit has invalid value.\n\t" +
+			"Hints: timeout must be a positive duration (i.e. \"1h\") and backoffLimit must be non-negative.")
+	}
+	switch b.Spec.ConcurrencyPolicy {
+	case "", AllowConcurrent, ForbidConcurrent, ReplaceConcurrent:
+	default:
+		return fmt.Errorf("\n\t"+
+			"Error: Invalid BackupConfiguration specification.\n\t"+
+			"Reason: spec.concurrencyPolicy has unknown value %q.\n\t"+
+			"Hints: Supported values are \"Allow\", \"Forbid\" and \"Replace\".", b.Spec.ConcurrencyPolicy)
+	}
+	if limit := b.Spec.BackupHistoryLimit; limit != nil {
+		if (limit.Succeeded != nil && *limit.Succeeded < 0) ||
+			(limit.Failed != nil && *limit.Failed < 0) ||
+			(limit.TTL != nil && limit.TTL.Duration <= 0) {
+			return fmt.Errorf("\n\t" +
+				"Error: Invalid BackupConfiguration specification.\n\t" +
+				"Reason: spec.backupHistoryLimit has invalid value.\n\t" +
+				"Hints: succeeded and failed must be non-negative and ttl must be a positive duration (i.e. \"72h\").")
+		}
+	}
+	if err := isValidTags(b.Spec.Tags); err != nil {
+		return invalidTagsError("BackupConfiguration", err)
+	}
+	if !b.Spec.BandwidthLimit.isValid() {
+		return invalidBandwidthLimitError("BackupConfiguration")
+	}
+	if b.Spec.Hooks != nil {
+		if b.Spec.Target == nil || !runsInWorkload(b.Spec.Driver, b.Spec.Target.Ref) {
+			return invalidHookError("BackupConfiguration", fmt.Errorf("hooks are supported only for the workloads backed up by the sidecar"))
+		}
+		if err := b.Spec.Hooks.PreBackup.isValid(HookPreBackup, true); err != nil {
+			return invalidHookError("BackupConfiguration", err)
+		}
+		if b.Spec.Hooks.PreBackup != nil && b.Spec.Hooks.PreBackup.FSFreeze != nil {
+			var sc *core.SecurityContext
+			if b.Spec.RuntimeSettings.Container != nil {
+				sc = b.Spec.RuntimeSettings.Container.SecurityContext
+			}
+			if !canFreezeFileSystem(sc) {
+				return fmt.Errorf("\n\t" +
+					"Error: Invalid BackupConfiguration specification.\n\t" +
+					"Reason: fsFreeze hook requires the stash container to run as root with SYS_ADMIN capability.\n\t" +
+					"Hints: Set runtimeSettings.container.securityContext.runAsUser to 0 and either set privileged to true or add \"SYS_ADMIN\" to capabilities.")
+			}
+		}
+		if err := b.Spec.Hooks.PostBackup.isValid(HookPostBackup, false); err != nil {
+			return invalidHookError("BackupConfiguration", err)
+		}
+	}
+	return nil
+}
+
+// isValidTarget ensures that the kind of the target is supported by the driver.
+func (b BackupConfiguration) isValidTarget() error {
+	if b.Spec.Driver == VolumeSnapshotter {
+		if b.Spec.Target == nil {
+			return fmt.Errorf("spec.target is required for VolumeSnapshotter driver")
+		}
+		switch b.Spec.Target.Ref.Kind {
+		case apis.KindDeployment, apis.KindDaemonSet, apis.KindStatefulSet, apis.KindReplicaSet,
+			apis.KindReplicationController, apis.KindPersistentVolumeClaim:
+			return nil
+		}
+		return fmt.Errorf("target kind %q is not supported by VolumeSnapshotter driver", b.Spec.Target.Ref.Kind)
+	}
+
+	if b.Spec.Driver != "" && b.Spec.Driver != ResticSnapshotter {
+		return fmt.Errorf("unknown driver %q", b.Spec.Driver)
+	}
+	if b.Spec.Repository.Name == "" {
+		return fmt.Errorf("spec.repository is required for Restic driver")
+	}
+	if b.Spec.Target == nil {
+		// target is nil for cluster backup. the Task does the backup.
+		if b.Spec.Task.Name == "" {
+			return fmt.Errorf("spec.task is required when spec.target is not specified")
+		}
+		return nil
+	}
+	switch b.Spec.Target.Ref.Kind {
+	case apis.KindDeployment, apis.KindDaemonSet, apis.KindStatefulSet, apis.KindReplicaSet,
+		apis.KindReplicationController, apis.KindDeploymentConfig:
+		return nil
+	case apis.KindPersistentVolumeClaim, apis.KindAppBinding:
+		if b.Spec.Task.Name == "" {
+			return fmt.Errorf("spec.task is required for target kind %q", b.Spec.Target.Ref.Kind)
+		}
+		return nil
+	}
+	return fmt.Errorf("target kind %q is not supported by Restic driver", b.Spec.Target.Ref.Kind)
+}
+
+func (b BackupBlueprint) IsValid() error {
+	if err := isValidTags(b.Spec.Tags); err != nil {
+		return invalidTagsError("BackupBlueprint", err)
+	}
+	if !b.Spec.BandwidthLimit.isValid() {
+		return invalidBandwidthLimitError("BackupBlueprint")
+	}
+	if err := b.isValidSelector(); err != nil {
+		return fmt.Errorf("\n\t"+
+			"Error: Invalid BackupBlueprint specification.\n\t"+
+			"Reason: spec.selector %s.\n\t"+
+			"Hints: Selector supports workloads, PersistentVolumeClaim and AppBinding.", err)
+	}
+	// variables of the schedule are resolved for each target. so, it can be validated only when there is none.
+	if strings.Contains(b.Spec.Schedule, "${") {
+		return nil
+	}
+	if err := isValidSchedule(b.Spec.Schedule); err != nil {
+		return fmt.Errorf("\n\t"+
+			"Error: Invalid BackupBlueprint specification.\n\t"+
+			"Reason: spec.schedule %s.\n\t"+
+			"Hints: Schedule must follow the standard cron format (i.e. \"*/5 * * * *\", \"@daily\").", err)
+	}
+	return nil
+}
+
+func (b BackupBlueprint) isValidSelector() error {
+	if b.Spec.Selector == nil {
+		return nil
+	}
+	supportedKinds := sets.NewString(
+		apis.KindDeployment,
+		apis.KindDaemonSet,
+		apis.KindStatefulSet,
+		apis.KindReplicaSet,
+		apis.KindReplicationController,
+		apis.KindDeploymentConfig,
+		apis.KindPersistentVolumeClaim,
+		apis.KindAppBinding,
+	)
+	for _, kind := range b.Spec.Selector.Kinds {
+		if !supportedKinds.Has(kind) {
+			return fmt.Errorf("has unsupported kind %q", kind)
+		}
+	}
+	// the selected workloads can't be backed up without the paths and the volumes to backup
+	for _, kind := range b.Spec.Selector.TargetKinds() {
+		if kind == apis.KindPersistentVolumeClaim || kind == apis.KindAppBinding {
+			continue
+		}
+		if len(b.Spec.Selector.Paths) == 0 {
+			return fmt.Errorf("selects %s but paths is not specified", kind)
+		}
+		if len(b.Spec.Selector.VolumeMounts) == 0 {
+			return fmt.Errorf("selects %s but volumeMounts is not specified", kind)
+		}
+	}
+	if b.Spec.Selector.LabelSelector == nil {
+		return fmt.Errorf("labelSelector is not specified")
+	}
+	if _, err := metav1.LabelSelectorAsSelector(b.Spec.Selector.LabelSelector); err != nil {
+		return fmt.Errorf("labelSelector is invalid. %v", err)
+	}
+	if b.Spec.Selector.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(b.Spec.Selector.NamespaceSelector); err != nil {
+			return fmt.Errorf("namespaceSelector is invalid. %v", err)
+		}
+	}
+	return nil
+}
+
+func (t Task) IsValid() error {
+	if len(t.Spec.Steps) == 0 {
+		return fmt.Errorf("\n\t" +
+			"Error: Invalid Task specification.\n\t" +
+			"Reason: spec.steps is empty.\n\t" +
+			"Hints: Specify at least one Function in spec.steps.")
+	}
+	for i, step := range t.Spec.Steps {
+		if step.Name == "" {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid Task specification.\n\t"+
+				"Reason: steps[%d] has empty name.\n\t"+
+				"Hints: Specify the name of the Function to run in this step.", i)
+		}
+		switch step.When {
+		case "", StepOnSuccess, StepOnFailure, StepAlways:
+		default:
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid Task specification.\n\t"+
+				"Reason: steps[%d] has invalid condition %q.\n\t"+
+				"Hints: Allowed values for when are %q, %q and %q.", i, step.When, StepOnSuccess, StepOnFailure, StepAlways)
+		}
+		// the steps of a parallel group must be next to each other
+		if step.ParallelGroup != "" && i > 1 && t.Spec.Steps[i-1].ParallelGroup != step.ParallelGroup {
+			for _, prev := range t.Spec.Steps[:i-1] {
+				if prev.ParallelGroup == step.ParallelGroup {
+					return fmt.Errorf("\n\t"+
+						"Error: Invalid Task specification.\n\t"+
+						"Reason: steps of parallel group %q are not consecutive.\n\t"+
+						"Hints: Place all the steps of a parallel group next to each other.", step.ParallelGroup)
+				}
+			}
+		}
+		params := make(map[string]bool)
+		for _, param := range step.Params {
+			if param.Name == "" || params[param.Name] {
+				return fmt.Errorf("\n\t"+
+					"Error: Invalid Task specification.\n\t"+
+					"Reason: steps[%d] has empty or duplicate param name %q.\n\t"+
+					"Hints: Each param of a step must have a unique name.", i, param.Name)
+			}
+			params[param.Name] = true
+		}
+	}
+	return nil
+}
+
+func (f Function) IsValid() error {
+	if f.Spec.Image == "" {
+		return fmt.Errorf("\n\t" +
+			"Error: Invalid Function specification.\n\t" +
+			"Reason: spec.image is empty.\n\t" +
+			"Hints: Specify the image that will run this Function.")
+	}
+	return nil
+}
+
+// isValidSchedule ensures that the schedule is a valid cron expression.
+func isValidSchedule(schedule string) error {
+	if schedule == "" {
+		return fmt.Errorf("is empty")
+	}
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return fmt.Errorf("has invalid cron expression %q. Reason: %v", schedule, err)
+	}
+	return nil
+}
+
+// isValidTags ensures that the tags can be passed to restic. Restic splits the tags on comma.
+func isValidTags(tags []string) error {
+	for i, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("tags[%d] is empty", i)
+		}
+		if strings.Contains(tag, ",") {
+			return fmt.Errorf("tags[%d] %q contains comma", i, tag)
+		}
+	}
+	return nil
+}
+
+func invalidTagsError(kind string, err error) error {
+	return fmt.Errorf("\n\t"+
+		"Error: Invalid %s specification.\n\t"+
+		"Reason: spec.%s.\n\t"+
+		"Hints: Tags must be non-empty and must not contain comma (i.e. \"env=prod\").", kind, err)
+}
+
+// isValid ensures that the limits are not negative
+func (l *BandwidthLimit) isValid() bool {
+	return l == nil || (l.LimitUpload >= 0 && l.LimitDownload >= 0)
+}
+
+func invalidBandwidthLimitError(kind string) error {
+	return fmt.Errorf("\n\t"+
+		"Error: Invalid %s specification.\n\t"+
+		"Reason: spec.bandwidthLimit has negative value.\n\t"+
+		"Hints: limitUpload and limitDownload are in KiB/s. Use zero for no limit.", kind)
+}
+
+// isValid ensures that exactly one action has been specified in the hook and
+// fsFreeze has been used only where it is allowed.
+func (h *Hook) isValid(name string, allowFSFreeze bool) error {
+	if h == nil {
+		return nil
+	}
+	actions := 0
+	if h.Exec != nil {
+		actions++
+		if len(h.Exec.Command) == 0 {
+			return fmt.Errorf("%s hook has empty exec command", name)
+		}
+	}
+	if h.HTTPGet != nil {
+		actions++
+	}
+	if h.FSFreeze != nil {
+		actions++
+		if !allowFSFreeze {
+			return fmt.Errorf("fsFreeze is not supported in %s hook", name)
+		}
+	}
+	if actions != 1 {
+		return fmt.Errorf("%s hook must have exactly one of exec, httpGet or fsFreeze action. Found %d", name, actions)
+	}
+	if h.TimeoutSeconds != nil && *h.TimeoutSeconds <= 0 {
+		return fmt.Errorf("%s hook has invalid timeoutSeconds %d", name, *h.TimeoutSeconds)
+	}
+	switch h.FailurePolicy {
+	case "", HookFailurePolicyFail, HookFailurePolicyIgnore:
+	default:
+		return fmt.Errorf("%s hook has unknown failurePolicy %q", name, h.FailurePolicy)
+	}
+	return nil
+}
+
+// runsInWorkload returns true if the backup or restore of the target runs inside the pods of the workload
+// (i.e. in the sidecar or the init-container). The hooks are executed only there. The backup and restore jobs don't run them.
+func runsInWorkload(driver Snapshotter, ref TargetRef) bool {
+	if driver == VolumeSnapshotter {
+		return false
+	}
+	switch ref.Kind {
+	case apis.KindDeployment, apis.KindDaemonSet, apis.KindStatefulSet, apis.KindReplicaSet,
+		apis.KindReplicationController, apis.KindDeploymentConfig:
+		return true
+	}
+	return false
+}
+
+// canFreezeFileSystem checks whether a container with the SecurityContext is allowed to freeze a file system.
+// fsfreeze needs CAP_SYS_ADMIN in the effective set which a non-root user does not get even if it is privileged.
+func canFreezeFileSystem(sc *core.SecurityContext) bool {
+	if sc == nil || sc.RunAsUser == nil || *sc.RunAsUser != 0 {
+		return false
+	}
+	if sc.Privileged != nil && *sc.Privileged {
+		return true
+	}
+	if sc.Capabilities != nil {
+		for _, c := range sc.Capabilities.Add {
+			if c == "SYS_ADMIN" || c == "CAP_SYS_ADMIN" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func invalidHookError(kind string, err error) error {
+	return fmt.Errorf("\n\t"+
+		"Error: Invalid %s specification.\n\t"+
+		"Reason: %s.\n\t"+
+		"Hints: Specify exactly one of exec, httpGet or fsFreeze in a hook. fsFreeze can be used only in preBackup hook. "+
+		"Hooks can't be used with the VolumeSnapshotter driver or with the targets backed up or restored by a job.", kind, err)
+}
 
 // TODO: complete
 func (r BackupSession) IsValid() error {
@@ -9,6 +389,10 @@ func (r BackupSession) IsValid() error {
 
 // TODO: complete
 func (r RestoreSession) IsValid() error {
+	if !r.Spec.BandwidthLimit.isValid() {
+		return invalidBandwidthLimitError("RestoreSession")
+	}
+
 	// ========== spec.Rules validation================
 	// We must ensure following:
 	// 1. There is at most one rule with empty targetHosts field.
@@ -54,6 +438,156 @@ func (r RestoreSession) IsValid() error {
 				"Hints: A snpashot contains backup data of only one directory. So, you can't specify 'paths' if you specify snapshot field.", i)
 		}
 	}
+
+	// ensure that the snapshot selectors are not specified along with snapshots and they select a valid time range
+	for i, rule := range r.Spec.Rules {
+		if err := rule.hasValidSelectors(); err != nil {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: rule[%d] %s.\n\t"+
+				"Hints: 'before', 'after' and 'tags' select the snapshots to restore. So, you can't specify them if you specify snapshots field.", i, err)
+		}
+	}
+
+	for i, rule := range r.Spec.Rules {
+		if err := rule.isValid(); err != nil {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: rule[%d] %s.\n\t"+
+				"Hints: destination and pathMapping must use absolute paths. includes and excludes can't be used together.", i, err)
+		}
+	}
+
+	if r.Spec.Hooks != nil {
+		if r.Spec.Target == nil || !runsInWorkload(r.Spec.Driver, r.Spec.Target.Ref) {
+			return invalidHookError("RestoreSession", fmt.Errorf("hooks are supported only for the workloads restored by the init-container"))
+		}
+		if err := r.Spec.Hooks.PreRestore.isValid(HookPreRestore, false); err != nil {
+			return invalidHookError("RestoreSession", err)
+		}
+		if err := r.Spec.Hooks.PostRestore.isValid(HookPostRestore, false); err != nil {
+			return invalidHookError("RestoreSession", err)
+		}
+	}
+
+	if r.Spec.Verification != nil {
+		if r.Spec.Driver == VolumeSnapshotter {
+			return fmt.Errorf("\n\t" +
+				"Error: Invalid RestoreSession specification.\n\t" +
+				"Reason: verification is not supported for VolumeSnapshotter driver.\n\t" +
+				"Hints: Remove spec.verification or use Restic driver.")
+		}
+		if err := r.Spec.Verification.isValid(); err != nil {
+			return fmt.Errorf("\n\t"+
+				"Error: Invalid RestoreSession specification.\n\t"+
+				"Reason: spec.verification %s.\n\t"+
+				"Hints: Specify schedule, backupConfiguration and exactly one of fileCount, checksum or exec in each check. Use absolute paths in the checks.", err)
+		}
+	}
+	return nil
+}
+
+// isValid ensures that the schedule and the BackupConfiguration have been specified and the checks are valid.
+func (v *RestoreVerification) isValid() error {
+	if v.Schedule == "" {
+		return fmt.Errorf("has empty schedule")
+	}
+	if v.BackupConfiguration.Name == "" {
+		return fmt.Errorf("has empty backupConfiguration")
+	}
+	names := make(map[string]bool)
+	for i, check := range v.Checks {
+		if check.Name == "" {
+			return fmt.Errorf("has empty name in check[%d]", i)
+		}
+		if names[check.Name] {
+			return fmt.Errorf("has multiple checks with name %q", check.Name)
+		}
+		names[check.Name] = true
+		if err := check.isValid(); err != nil {
+			return fmt.Errorf("has invalid check %q. Reason: %v", check.Name, err)
+		}
+	}
+	return nil
+}
+
+func (check VerificationCheck) isValid() error {
+	actions := 0
+	if check.FileCount != nil {
+		actions++
+		if !filepath.IsAbs(check.FileCount.Path) {
+			return fmt.Errorf("relative path %q", check.FileCount.Path)
+		}
+		if check.FileCount.Min == nil && check.FileCount.Max == nil {
+			return fmt.Errorf("neither min nor max has been specified")
+		}
+		if check.FileCount.Min != nil && check.FileCount.Max != nil && *check.FileCount.Min > *check.FileCount.Max {
+			return fmt.Errorf("min %d is greater than max %d", *check.FileCount.Min, *check.FileCount.Max)
+		}
+	}
+	if check.Checksum != nil {
+		actions++
+		if !filepath.IsAbs(check.Checksum.Path) {
+			return fmt.Errorf("relative path %q", check.Checksum.Path)
+		}
+		if sum, err := hex.DecodeString(check.Checksum.SHA256); err != nil || len(sum) != sha256.Size {
+			return fmt.Errorf("invalid sha256 checksum %q", check.Checksum.SHA256)
+		}
+	}
+	if check.Exec != nil {
+		actions++
+		if len(check.Exec.Command) == 0 {
+			return fmt.Errorf("empty exec command")
+		}
+	}
+	if actions != 1 {
+		return fmt.Errorf("found %d actions", actions)
+	}
+	return nil
+}
+
+// isValid ensures that the destination and the path mappings are absolute paths and the file patterns are valid.
+func (rule Rule) isValid() error {
+	if rule.Destination != "" && !filepath.IsAbs(rule.Destination) {
+		return fmt.Errorf("has relative destination %q", rule.Destination)
+	}
+	for src, dst := range rule.PathMapping {
+		if !filepath.IsAbs(src) || !filepath.IsAbs(dst) {
+			return fmt.Errorf("has relative path in pathMapping %q: %q", src, dst)
+		}
+	}
+	if len(rule.Includes) > 0 && len(rule.Excludes) > 0 {
+		return fmt.Errorf("has both includes and excludes")
+	}
+	for _, pattern := range append(rule.Includes, rule.Excludes...) {
+		if strings.TrimSpace(pattern) == "" {
+			return fmt.Errorf("has empty file pattern")
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("has invalid file pattern %q. Reason: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// hasValidSelectors ensures that the snapshot selectors have not been specified along with the snapshots
+// and the "after" time is earlier than the "before" time.
+func (rule Rule) hasValidSelectors() error {
+	if rule.Before == nil && rule.After == nil && len(rule.Tags) == 0 {
+		return nil
+	}
+	if len(rule.Snapshots) != 0 {
+		return fmt.Errorf("has both snapshots and snapshot selectors")
+	}
+	if rule.Before != nil && rule.After != nil && !rule.After.Before(rule.Before) {
+		return fmt.Errorf("has 'after' time %s that is not earlier than 'before' time %s",
+			rule.After.UTC().Format(time.RFC3339), rule.Before.UTC().Format(time.RFC3339))
+	}
+	for _, tag := range rule.Tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("has empty tag")
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,247 @@
+package v1beta1
+
+import (
+	"testing"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"stash.appscode.dev/stash/apis"
+)
+
+func TestIsValidTarget(t *testing.T) {
+	testCases := []struct {
+		name    string
+		driver  Snapshotter
+		kind    string
+		noTask  bool
+		noRepo  bool
+		wantErr bool
+	}{
+		{name: "restic driver supports Deployment", driver: ResticSnapshotter, kind: apis.KindDeployment},
+		{name: "default driver supports StatefulSet", kind: apis.KindStatefulSet},
+		{name: "restic driver supports DeploymentConfig", driver: ResticSnapshotter, kind: apis.KindDeploymentConfig},
+		{name: "restic driver supports PersistentVolumeClaim with Task", driver: ResticSnapshotter, kind: apis.KindPersistentVolumeClaim},
+		{name: "restic driver requires Task for PersistentVolumeClaim", driver: ResticSnapshotter, kind: apis.KindPersistentVolumeClaim, noTask: true, wantErr: true},
+		{name: "restic driver requires Task for AppBinding", driver: ResticSnapshotter, kind: apis.KindAppBinding, noTask: true, wantErr: true},
+		{name: "restic driver requires Repository", driver: ResticSnapshotter, kind: apis.KindDeployment, noRepo: true, wantErr: true},
+		{name: "restic driver doesn't support unknown kind", driver: ResticSnapshotter, kind: "Pod", wantErr: true},
+		{name: "restic driver requires Task for cluster backup", driver: ResticSnapshotter, noTask: true, wantErr: true},
+		{name: "restic driver supports cluster backup with Task", driver: ResticSnapshotter},
+		{name: "volume snapshotter supports DaemonSet", driver: VolumeSnapshotter, kind: apis.KindDaemonSet, noRepo: true},
+		{name: "volume snapshotter supports PersistentVolumeClaim", driver: VolumeSnapshotter, kind: apis.KindPersistentVolumeClaim, noTask: true, noRepo: true},
+		{name: "volume snapshotter doesn't support AppBinding", driver: VolumeSnapshotter, kind: apis.KindAppBinding, wantErr: true},
+		{name: "volume snapshotter doesn't support DeploymentConfig", driver: VolumeSnapshotter, kind: apis.KindDeploymentConfig, wantErr: true},
+		{name: "volume snapshotter requires target", driver: VolumeSnapshotter, wantErr: true},
+		{name: "unknown driver", driver: "Unknown", kind: apis.KindDeployment, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := BackupConfiguration{
+				Spec: BackupConfigurationSpec{
+					Driver:     tc.driver,
+					Repository: core.LocalObjectReference{Name: "repo"},
+					Task:       TaskRef{Name: "task"},
+				},
+			}
+			if tc.kind != "" {
+				b.Spec.Target = &BackupTarget{Ref: TargetRef{Kind: tc.kind, Name: "target"}}
+			}
+			if tc.noTask {
+				b.Spec.Task = TaskRef{}
+			}
+			if tc.noRepo {
+				b.Spec.Repository = core.LocalObjectReference{}
+			}
+			if err := b.isValidTarget(); (err != nil) != tc.wantErr {
+				t.Errorf("expected error: %v, found: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestIsValidSchedule(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schedule string
+		wantErr  bool
+	}{
+		{name: "standard cron expression", schedule: "*/5 * * * *"},
+		{name: "predefined schedule", schedule: "@daily"},
+		{name: "empty schedule", schedule: "", wantErr: true},
+		{name: "too few fields", schedule: "*/5 * * *", wantErr: true},
+		{name: "seconds field is not allowed", schedule: "0 */5 * * * *", wantErr: true},
+		{name: "out of range value", schedule: "0 25 * * *", wantErr: true},
+		{name: "unknown predefined schedule", schedule: "@sometimes", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := isValidSchedule(tc.schedule); (err != nil) != tc.wantErr {
+				t.Errorf("expected error: %v, found: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBackupBlueprintIsValid(t *testing.T) {
+	appSelector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+	testCases := []struct {
+		name    string
+		spec    BackupBlueprintSpec
+		wantErr bool
+	}{
+		{
+			name: "valid schedule",
+			spec: BackupBlueprintSpec{Schedule: "@hourly"},
+		},
+		{
+			name:    "invalid schedule",
+			spec:    BackupBlueprintSpec{Schedule: "every hour"},
+			wantErr: true,
+		},
+		{
+			name: "schedule with variables is not validated",
+			spec: BackupBlueprintSpec{Schedule: "${SCHEDULE}"},
+		},
+		{
+			name:    "tag with comma",
+			spec:    BackupBlueprintSpec{Schedule: "@hourly", Tags: []string{"env=prod,team=db"}},
+			wantErr: true,
+		},
+		{
+			name:    "negative bandwidth limit",
+			spec:    BackupBlueprintSpec{Schedule: "@hourly", BandwidthLimit: &BandwidthLimit{LimitUpload: -1}},
+			wantErr: true,
+		},
+		{
+			name: "selector of volumes",
+			spec: BackupBlueprintSpec{
+				Schedule: "@hourly",
+				Selector: &BackupBlueprintSelector{Kinds: []string{apis.KindPersistentVolumeClaim}, LabelSelector: appSelector},
+			},
+		},
+		{
+			name: "selector of workloads with paths and volumeMounts",
+			spec: BackupBlueprintSpec{
+				Schedule: "@hourly",
+				Selector: &BackupBlueprintSelector{
+					LabelSelector: appSelector,
+					Paths:         []string{"/data"},
+					VolumeMounts:  []core.VolumeMount{{Name: "data", MountPath: "/data"}},
+				},
+			},
+		},
+		{
+			name: "selector of workloads without paths",
+			spec: BackupBlueprintSpec{
+				Schedule: "@hourly",
+				Selector: &BackupBlueprintSelector{
+					LabelSelector: appSelector,
+					VolumeMounts:  []core.VolumeMount{{Name: "data", MountPath: "/data"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "selector with unsupported kind",
+			spec: BackupBlueprintSpec{
+				Schedule: "@hourly",
+				Selector: &BackupBlueprintSelector{Kinds: []string{"Pod"}, LabelSelector: appSelector},
+			},
+			wantErr: true,
+		},
+		{
+			name: "selector without labelSelector",
+			spec: BackupBlueprintSpec{
+				Schedule: "@hourly",
+				Selector: &BackupBlueprintSelector{Kinds: []string{apis.KindAppBinding}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := BackupBlueprint{Spec: tc.spec}
+			if err := b.IsValid(); (err != nil) != tc.wantErr {
+				t.Errorf("expected error: %v, found: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTaskIsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		steps   []FunctionRef
+		wantErr bool
+	}{
+		{
+			name:  "valid steps",
+			steps: []FunctionRef{{Name: "backup"}, {Name: "notify", When: StepAlways, Params: []Param{{Name: "url"}}}},
+		},
+		{
+			name:    "no steps",
+			wantErr: true,
+		},
+		{
+			name:    "step without name",
+			steps:   []FunctionRef{{Name: "backup"}, {}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown condition",
+			steps:   []FunctionRef{{Name: "backup", When: "Sometimes"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate param",
+			steps:   []FunctionRef{{Name: "backup", Params: []Param{{Name: "args"}, {Name: "args"}}}},
+			wantErr: true,
+		},
+		{
+			name:    "empty param name",
+			steps:   []FunctionRef{{Name: "backup", Params: []Param{{Value: "x"}}}},
+			wantErr: true,
+		},
+		{
+			name:  "consecutive parallel group",
+			steps: []FunctionRef{{Name: "init"}, {Name: "a", ParallelGroup: "dump"}, {Name: "b", ParallelGroup: "dump"}, {Name: "upload"}},
+		},
+		{
+			name:    "parallel group split by another step",
+			steps:   []FunctionRef{{Name: "init"}, {Name: "a", ParallelGroup: "dump"}, {Name: "upload"}, {Name: "b", ParallelGroup: "dump"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			task := Task{Spec: TaskSpec{Steps: tc.steps}}
+			if err := task.IsValid(); (err != nil) != tc.wantErr {
+				t.Errorf("expected error: %v, found: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestFunctionIsValid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		image   string
+		wantErr bool
+	}{
+		{name: "image is specified", image: "stashed/stash-postgres:11.2"},
+		{name: "image is empty", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fn := Function{Spec: FunctionSpec{Image: tc.image}}
+			if err := fn.IsValid(); (err != nil) != tc.wantErr {
+				t.Errorf("expected error: %v, found: %v", tc.wantErr, err)
+			}
+		})
+	}
+}
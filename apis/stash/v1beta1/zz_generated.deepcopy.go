@@ -21,7 +21,8 @@ limitations under the License.
 package v1beta1
 
 import (
-	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	apiv1 "kmodules.xyz/offshoot-api/api/v1"
 )
@@ -32,6 +33,7 @@ func (in *BackupBlueprint) DeepCopyInto(out *BackupBlueprint) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -86,14 +88,77 @@ func (in *BackupBlueprintList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupBlueprintSelector) DeepCopyInto(out *BackupBlueprintSelector) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupBlueprintSelector.
+func (in *BackupBlueprintSelector) DeepCopy() *BackupBlueprintSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupBlueprintSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupBlueprintSpec) DeepCopyInto(out *BackupBlueprintSpec) {
 	*out = *in
 	in.RepositorySpec.DeepCopyInto(&out.RepositorySpec)
 	in.Task.DeepCopyInto(&out.Task)
 	in.RetentionPolicy.DeepCopyInto(&out.RetentionPolicy)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.RuntimeSettings.DeepCopyInto(&out.RuntimeSettings)
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		*out = new(BandwidthLimit)
+		**out = **in
+	}
 	in.TempDir.DeepCopyInto(&out.TempDir)
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(BackupBlueprintSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -107,12 +172,33 @@ func (in *BackupBlueprintSpec) DeepCopy() *BackupBlueprintSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupBlueprintStatus) DeepCopyInto(out *BackupBlueprintStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupBlueprintStatus.
+func (in *BackupBlueprintStatus) DeepCopy() *BackupBlueprintStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupBlueprintStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupConfiguration) DeepCopyInto(out *BackupConfiguration) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -134,6 +220,23 @@ func (in *BackupConfiguration) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfigurationCondition) DeepCopyInto(out *BackupConfigurationCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfigurationCondition.
+func (in *BackupConfigurationCondition) DeepCopy() *BackupConfigurationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfigurationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupConfigurationList) DeepCopyInto(out *BackupConfigurationList) {
 	*out = *in
@@ -178,7 +281,37 @@ func (in *BackupConfigurationSpec) DeepCopyInto(out *BackupConfigurationSpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.RetentionPolicy.DeepCopyInto(&out.RetentionPolicy)
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackupHistoryLimit != nil {
+		in, out := &in.BackupHistoryLimit, &out.BackupHistoryLimit
+		*out = new(BackupHistoryLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(BackupHooks)
+		(*in).DeepCopyInto(*out)
+	}
 	in.RuntimeSettings.DeepCopyInto(&out.RuntimeSettings)
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		*out = new(BandwidthLimit)
+		**out = **in
+	}
 	in.TempDir.DeepCopyInto(&out.TempDir)
 	return
 }
@@ -193,6 +326,99 @@ func (in *BackupConfigurationSpec) DeepCopy() *BackupConfigurationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfigurationStatus) DeepCopyInto(out *BackupConfigurationStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]BackupConfigurationCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSuccessfulBackupTime != nil {
+		in, out := &in.LastSuccessfulBackupTime, &out.LastSuccessfulBackupTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextScheduledTime != nil {
+		in, out := &in.NextScheduledTime, &out.NextScheduledTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(RestoreVerificationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfigurationStatus.
+func (in *BackupConfigurationStatus) DeepCopy() *BackupConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupHistoryLimit) DeepCopyInto(out *BackupHistoryLimit) {
+	*out = *in
+	if in.Succeeded != nil {
+		in, out := &in.Succeeded, &out.Succeeded
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHistoryLimit.
+func (in *BackupHistoryLimit) DeepCopy() *BackupHistoryLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupHistoryLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupHooks) DeepCopyInto(out *BackupHooks) {
+	*out = *in
+	if in.PreBackup != nil {
+		in, out := &in.PreBackup, &out.PreBackup
+		*out = new(Hook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostBackup != nil {
+		in, out := &in.PostBackup, &out.PostBackup
+		*out = new(Hook)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupHooks.
+func (in *BackupHooks) DeepCopy() *BackupHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupSession) DeepCopyInto(out *BackupSession) {
 	*out = *in
@@ -290,6 +516,10 @@ func (in *BackupSessionStatus) DeepCopyInto(out *BackupSessionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -314,7 +544,7 @@ func (in *BackupTarget) DeepCopyInto(out *BackupTarget) {
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -324,6 +554,7 @@ func (in *BackupTarget) DeepCopyInto(out *BackupTarget) {
 		*out = new(int32)
 		**out = **in
 	}
+	in.FileFilter.DeepCopyInto(&out.FileFilter)
 	return
 }
 
@@ -337,6 +568,38 @@ func (in *BackupTarget) DeepCopy() *BackupTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BandwidthLimit) DeepCopyInto(out *BandwidthLimit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BandwidthLimit.
+func (in *BandwidthLimit) DeepCopy() *BandwidthLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(BandwidthLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChecksumCheck) DeepCopyInto(out *ChecksumCheck) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChecksumCheck.
+func (in *ChecksumCheck) DeepCopy() *ChecksumCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ChecksumCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmptyDirSettings) DeepCopyInto(out *EmptyDirSettings) {
 	*out = *in
@@ -358,6 +621,95 @@ func (in *EmptyDirSettings) DeepCopy() *EmptyDirSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecCheck) DeepCopyInto(out *ExecCheck) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecCheck.
+func (in *ExecCheck) DeepCopy() *ExecCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecHook) DeepCopyInto(out *ExecHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecHook.
+func (in *ExecHook) DeepCopy() *ExecHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FSFreezeHook) DeepCopyInto(out *FSFreezeHook) {
+	*out = *in
+	if in.MountPaths != nil {
+		in, out := &in.MountPaths, &out.MountPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FSFreezeHook.
+func (in *FSFreezeHook) DeepCopy() *FSFreezeHook {
+	if in == nil {
+		return nil
+	}
+	out := new(FSFreezeHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileCountCheck) DeepCopyInto(out *FileCountCheck) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileCountCheck.
+func (in *FileCountCheck) DeepCopy() *FileCountCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(FileCountCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FileStats) DeepCopyInto(out *FileStats) {
 	*out = *in
@@ -490,19 +842,19 @@ func (in *FunctionSpec) DeepCopyInto(out *FunctionSpec) {
 	}
 	if in.Ports != nil {
 		in, out := &in.Ports, &out.Ports
-		*out = make([]v1.ContainerPort, len(*in))
+		*out = make([]corev1.ContainerPort, len(*in))
 		copy(*out, *in)
 	}
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 	if in.VolumeDevices != nil {
 		in, out := &in.VolumeDevices, &out.VolumeDevices
-		*out = make([]v1.VolumeDevice, len(*in))
+		*out = make([]corev1.VolumeDevice, len(*in))
 		copy(*out, *in)
 	}
 	if in.RuntimeSettings != nil {
@@ -523,6 +875,58 @@ func (in *FunctionSpec) DeepCopy() *FunctionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Hook) DeepCopyInto(out *Hook) {
+	*out = *in
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(corev1.HTTPGetAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FSFreeze != nil {
+		in, out := &in.FSFreeze, &out.FSFreeze
+		*out = new(FSFreezeHook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Hook.
+func (in *Hook) DeepCopy() *Hook {
+	if in == nil {
+		return nil
+	}
+	out := new(Hook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookStats) DeepCopyInto(out *HookStats) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookStats.
+func (in *HookStats) DeepCopy() *HookStats {
+	if in == nil {
+		return nil
+	}
+	out := new(HookStats)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostBackupStats) DeepCopyInto(out *HostBackupStats) {
 	*out = *in
@@ -533,6 +937,16 @@ func (in *HostBackupStats) DeepCopyInto(out *HostBackupStats) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RemovedSnapshots != nil {
+		in, out := &in.RemovedSnapshots, &out.RemovedSnapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookStats, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -549,6 +963,16 @@ func (in *HostBackupStats) DeepCopy() *HostBackupStats {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HostRestoreStats) DeepCopyInto(out *HostRestoreStats) {
 	*out = *in
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = make([]HookStats, len(*in))
+		copy(*out, *in)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -578,6 +1002,48 @@ func (in *Param) DeepCopy() *Param {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositoryRef) DeepCopyInto(out *RepositoryRef) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositoryRef.
+func (in *RepositoryRef) DeepCopy() *RepositoryRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositoryRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreHooks) DeepCopyInto(out *RestoreHooks) {
+	*out = *in
+	if in.PreRestore != nil {
+		in, out := &in.PreRestore, &out.PreRestore
+		*out = new(Hook)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostRestore != nil {
+		in, out := &in.PostRestore, &out.PostRestore
+		*out = new(Hook)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreHooks.
+func (in *RestoreHooks) DeepCopy() *RestoreHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RestoreSession) DeepCopyInto(out *RestoreSession) {
 	*out = *in
@@ -656,8 +1122,23 @@ func (in *RestoreSessionSpec) DeepCopyInto(out *RestoreSessionSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(RestoreHooks)
+		(*in).DeepCopyInto(*out)
+	}
 	in.RuntimeSettings.DeepCopyInto(&out.RuntimeSettings)
+	if in.BandwidthLimit != nil {
+		in, out := &in.BandwidthLimit, &out.BandwidthLimit
+		*out = new(BandwidthLimit)
+		**out = **in
+	}
 	in.TempDir.DeepCopyInto(&out.TempDir)
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(RestoreVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -686,7 +1167,9 @@ func (in *RestoreSessionStatus) DeepCopyInto(out *RestoreSessionStatus) {
 	if in.Stats != nil {
 		in, out := &in.Stats, &out.Stats
 		*out = make([]HostRestoreStats, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -707,7 +1190,7 @@ func (in *RestoreTarget) DeepCopyInto(out *RestoreTarget) {
 	out.Ref = in.Ref
 	if in.VolumeMounts != nil {
 		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+		*out = make([]corev1.VolumeMount, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -719,7 +1202,7 @@ func (in *RestoreTarget) DeepCopyInto(out *RestoreTarget) {
 	}
 	if in.VolumeClaimTemplates != nil {
 		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
-		*out = make([]v1.PersistentVolumeClaim, len(*in))
+		*out = make([]corev1.PersistentVolumeClaim, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -737,6 +1220,61 @@ func (in *RestoreTarget) DeepCopy() *RestoreTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerification) DeepCopyInto(out *RestoreVerification) {
+	*out = *in
+	out.BackupConfiguration = in.BackupConfiguration
+	in.ScratchDir.DeepCopyInto(&out.ScratchDir)
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]VerificationCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerification.
+func (in *RestoreVerification) DeepCopy() *RestoreVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoreVerificationStatus) DeepCopyInto(out *RestoreVerificationStatus) {
+	*out = *in
+	if in.LastVerificationTime != nil {
+		in, out := &in.LastVerificationTime, &out.LastVerificationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Checks != nil {
+		in, out := &in.Checks, &out.Checks
+		*out = make([]VerificationCheckStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoreVerificationStatus.
+func (in *RestoreVerificationStatus) DeepCopy() *RestoreVerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoreVerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rule) DeepCopyInto(out *Rule) {
 	*out = *in
@@ -755,6 +1293,36 @@ func (in *Rule) DeepCopyInto(out *Rule) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Before != nil {
+		in, out := &in.Before, &out.Before
+		*out = (*in).DeepCopy()
+	}
+	if in.After != nil {
+		in, out := &in.After, &out.After
+		*out = (*in).DeepCopy()
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PathMapping != nil {
+		in, out := &in.PathMapping, &out.PathMapping
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Includes != nil {
+		in, out := &in.Includes, &out.Includes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Excludes != nil {
+		in, out := &in.Excludes, &out.Excludes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -894,7 +1462,7 @@ func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	}
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
+		*out = make([]corev1.Volume, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -911,3 +1479,50 @@ func (in *TaskSpec) DeepCopy() *TaskSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationCheck) DeepCopyInto(out *VerificationCheck) {
+	*out = *in
+	if in.FileCount != nil {
+		in, out := &in.FileCount, &out.FileCount
+		*out = new(FileCountCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checksum != nil {
+		in, out := &in.Checksum, &out.Checksum
+		*out = new(ChecksumCheck)
+		**out = **in
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(ExecCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationCheck.
+func (in *VerificationCheck) DeepCopy() *VerificationCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationCheckStatus) DeepCopyInto(out *VerificationCheckStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationCheckStatus.
+func (in *VerificationCheckStatus) DeepCopy() *VerificationCheckStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationCheckStatus)
+	in.DeepCopyInto(out)
+	return out
+}
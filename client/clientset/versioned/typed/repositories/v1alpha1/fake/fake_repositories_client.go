@@ -28,6 +28,10 @@ type FakeRepositoriesV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeRepositoriesV1alpha1) ResolvedJobs(namespace string) v1alpha1.ResolvedJobInterface {
+	return &FakeResolvedJobs{c, namespace}
+}
+
 func (c *FakeRepositoriesV1alpha1) Snapshots(namespace string) v1alpha1.SnapshotInterface {
 	return &FakeSnapshots{c, namespace}
 }
@@ -22,6 +22,7 @@ import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	watch "k8s.io/apimachinery/pkg/watch"
 	testing "k8s.io/client-go/testing"
 	v1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
 )
@@ -69,6 +70,13 @@ func (c *FakeSnapshots) List(opts v1.ListOptions) (result *v1alpha1.SnapshotList
 	return list, err
 }
 
+// Watch returns a watch.Interface that watches the requested snapshots.
+func (c *FakeSnapshots) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(snapshotsResource, c.ns, opts))
+
+}
+
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
 func (c *FakeSnapshots) UpdateStatus(snapshot *v1alpha1.Snapshot) (*v1alpha1.Snapshot, error) {
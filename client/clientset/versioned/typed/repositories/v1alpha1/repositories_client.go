@@ -27,6 +27,7 @@ import (
 
 type RepositoriesV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	ResolvedJobsGetter
 	SnapshotsGetter
 }
 
@@ -35,6 +36,10 @@ type RepositoriesV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *RepositoriesV1alpha1Client) ResolvedJobs(namespace string) ResolvedJobInterface {
+	return newResolvedJobs(c, namespace)
+}
+
 func (c *RepositoriesV1alpha1Client) Snapshots(namespace string) SnapshotInterface {
 	return newSnapshots(c, namespace)
 }
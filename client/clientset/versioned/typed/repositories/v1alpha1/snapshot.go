@@ -22,6 +22,7 @@ import (
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	watch "k8s.io/apimachinery/pkg/watch"
 	rest "k8s.io/client-go/rest"
 	v1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
 	scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
@@ -39,6 +40,7 @@ type SnapshotInterface interface {
 	Delete(name string, options *v1.DeleteOptions) error
 	Get(name string, options v1.GetOptions) (*v1alpha1.Snapshot, error)
 	List(opts v1.ListOptions) (*v1alpha1.SnapshotList, error)
+	Watch(opts v1.ListOptions) (watch.Interface, error)
 	SnapshotExpansion
 }
 
@@ -86,6 +88,21 @@ func (c *snapshots) List(opts v1.ListOptions) (result *v1alpha1.SnapshotList, er
 	return
 }
 
+// Watch returns a watch.Interface that watches the requested snapshots.
+func (c *snapshots) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("snapshots").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
 
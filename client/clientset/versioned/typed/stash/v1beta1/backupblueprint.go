@@ -39,6 +39,7 @@ type BackupBlueprintsGetter interface {
 type BackupBlueprintInterface interface {
 	Create(*v1beta1.BackupBlueprint) (*v1beta1.BackupBlueprint, error)
 	Update(*v1beta1.BackupBlueprint) (*v1beta1.BackupBlueprint, error)
+	UpdateStatus(*v1beta1.BackupBlueprint) (*v1beta1.BackupBlueprint, error)
 	Delete(name string, options *v1.DeleteOptions) error
 	DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error
 	Get(name string, options v1.GetOptions) (*v1beta1.BackupBlueprint, error)
@@ -125,6 +126,21 @@ func (c *backupBlueprints) Update(backupBlueprint *v1beta1.BackupBlueprint) (res
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *backupBlueprints) UpdateStatus(backupBlueprint *v1beta1.BackupBlueprint) (result *v1beta1.BackupBlueprint, err error) {
+	result = &v1beta1.BackupBlueprint{}
+	err = c.client.Put().
+		Resource("backupblueprints").
+		Name(backupBlueprint.Name).
+		SubResource("status").
+		Body(backupBlueprint).
+		Do().
+		Into(result)
+	return
+}
+
 // Delete takes name of the backupBlueprint and deletes it. Returns an error if one occurs.
 func (c *backupBlueprints) Delete(name string, options *v1.DeleteOptions) error {
 	return c.client.Delete().
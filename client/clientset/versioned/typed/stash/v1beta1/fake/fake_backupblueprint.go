@@ -94,6 +94,17 @@ func (c *FakeBackupBlueprints) Update(backupBlueprint *v1beta1.BackupBlueprint)
 	return obj.(*v1beta1.BackupBlueprint), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeBackupBlueprints) UpdateStatus(backupBlueprint *v1beta1.BackupBlueprint) (*v1beta1.BackupBlueprint, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(backupblueprintsResource, "status", backupBlueprint), &v1beta1.BackupBlueprint{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1beta1.BackupBlueprint), err
+}
+
 // Delete takes name of the backupBlueprint and deletes it. Returns an error if one occurs.
 func (c *FakeBackupBlueprints) Delete(name string, options *v1.DeleteOptions) error {
 	_, err := c.Fake.
@@ -5,6 +5,7 @@ import (
 
 	jsonpatch "github.com/evanphx/json-patch"
 	"github.com/golang/glog"
+	"github.com/pkg/errors"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -79,3 +80,56 @@ func TryUpdateBackupBlueprint(c cs.StashV1beta1Interface, meta metav1.ObjectMeta
 	}
 	return
 }
+
+func UpdateBackupBlueprintStatus(
+	c cs.StashV1beta1Interface,
+	in *api.BackupBlueprint,
+	transform func(*api.BackupBlueprintStatus) *api.BackupBlueprintStatus,
+	useSubresource ...bool,
+) (result *api.BackupBlueprint, err error) {
+	if len(useSubresource) > 1 {
+		return nil, errors.Errorf("invalid value passed for useSubresource: %v", useSubresource)
+	}
+	apply := func(x *api.BackupBlueprint) *api.BackupBlueprint {
+		out := &api.BackupBlueprint{
+			TypeMeta:   x.TypeMeta,
+			ObjectMeta: x.ObjectMeta,
+			Spec:       x.Spec,
+			Status:     *transform(x.Status.DeepCopy()),
+		}
+		return out
+	}
+
+	if len(useSubresource) == 1 && useSubresource[0] {
+		attempt := 0
+		cur := in.DeepCopy()
+		err = wait.PollImmediate(kutil.RetryInterval, kutil.RetryTimeout, func() (bool, error) {
+			attempt++
+			var e2 error
+			result, e2 = c.BackupBlueprints().UpdateStatus(apply(cur))
+			if kerr.IsConflict(e2) {
+				latest, e3 := c.BackupBlueprints().Get(in.Name, metav1.GetOptions{})
+				switch {
+				case e3 == nil:
+					cur = latest
+					return false, nil
+				case kutil.IsRequestRetryable(e3):
+					return false, nil
+				default:
+					return false, e3
+				}
+			} else if e2 != nil && !kutil.IsRequestRetryable(e2) {
+				return false, e2
+			}
+			return e2 == nil, nil
+		})
+
+		if err != nil {
+			err = fmt.Errorf("failed to update status of BackupBlueprint %s after %d attempts due to %v", in.Name, attempt, err)
+		}
+		return
+	}
+
+	result, _, err = PatchBackupBlueprintObject(c, in, apply(in))
+	return
+}
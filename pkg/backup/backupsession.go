@@ -26,6 +26,7 @@ import (
 	stashinformers "stash.appscode.dev/stash/client/informers/externalversions"
 	"stash.appscode.dev/stash/client/listers/stash/v1beta1"
 	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/hooks"
 	"stash.appscode.dev/stash/pkg/restic"
 	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
@@ -133,6 +134,18 @@ func (c *BackupSessionController) initBackupSessionWatcher(backupConfiguration *
 				queue.Enqueue(c.bsQueue.GetQueue(), backupsession)
 			}
 		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldBS, ok1 := oldObj.(*api_v1beta1.BackupSession)
+			newBS, ok2 := newObj.(*api_v1beta1.BackupSession)
+			if !ok1 || !ok2 {
+				return
+			}
+			// operator has admitted the BackupSession or reset the failed hosts to retry the backup
+			if (newBS.Status.Phase == api_v1beta1.BackupSessionRunning && oldBS.Status.Phase != api_v1beta1.BackupSessionRunning) ||
+				newBS.Status.Retried > oldBS.Status.Retried {
+				queue.Enqueue(c.bsQueue.GetQueue(), newBS)
+			}
+		},
 	}, selector))
 	c.bsLister = c.StashInformerFactory.Stash().V1beta1().BackupSessions().Lister()
 	return nil
@@ -156,7 +169,7 @@ func (c *BackupSessionController) processBackupSession(key string) error {
 
 		backupOutput, err := c.startBackupProcess(backupSession)
 		if err != nil {
-			return c.handleBackupFailure(backupSession.Name, err)
+			return c.handleBackupFailure(backupSession.Name, backupOutput, err)
 		}
 
 		if backupOutput != nil {
@@ -182,6 +195,13 @@ func (c *BackupSessionController) startBackupProcess(backupSession *api_v1beta1.
 		return nil, nil
 	}
 
+	// the operator sets the BackupSession "Running" once it has passed the concurrencyPolicy and the backend
+	// concurrency limit. wait until then. the BackupSession is processed again when its phase changes.
+	if backupSession.Status.Phase != api_v1beta1.BackupSessionRunning {
+		log.Infof("Skip processing BackupSession %s/%s. Reason: BackupSession is in %q phase. Waiting for the operator to start it.", backupSession.Namespace, backupSession.Name, backupSession.Status.Phase)
+		return nil, nil
+	}
+
 	// if BackupSession already has been processed for this host then skip further processing
 	if c.isBackupTakenForThisHost(backupSession, c.Host) {
 		log.Infof("Skip processing BackupSession %s/%s. Reason: BackupSession has been processed already for host %q\n", backupSession.Namespace, backupSession.Name, c.Host)
@@ -214,6 +234,8 @@ func (c *BackupSessionController) backup(backupSession *api_v1beta1.BackupSessio
 		SecretDir:   c.SetupOpt.SecretDir,
 		EnableCache: c.SetupOpt.EnableCache,
 		ScratchDir:  c.SetupOpt.ScratchDir,
+
+		BandwidthLimit: backupConfiguration.Spec.BandwidthLimit,
 	}
 
 	// configure setupOption
@@ -238,10 +260,90 @@ func (c *BackupSessionController) backup(backupSession *api_v1beta1.BackupSessio
 		return nil, err
 	}
 
+	// kill the backup process if the BackupSession exceeds the timeout
+	if backupConfiguration.Spec.Timeout != nil {
+		resticWrapper.SetDeadline(backupSession.CreationTimestamp.Add(backupConfiguration.Spec.Timeout.Duration))
+	}
+
 	// BackupOptions configuration
 	backupOpt := util.BackupOptionsForBackupConfig(*backupConfiguration, extraOpt)
-	// Run Backup
-	return resticWrapper.RunBackup(backupOpt)
+	backupOpt.Tags = util.SnapshotTags(*backupConfiguration, backupSession.Name, "")
+	backupOpt = util.SkipRepositoryMaintenance(backupOpt, *repository)
+	if backupConfiguration.Spec.Hooks == nil {
+		// Run Backup
+		return resticWrapper.RunBackup(backupOpt)
+	}
+	// Run Backup along with the hooks
+	return c.backupWithHooks(resticWrapper, backupOpt, backupConfiguration)
+}
+
+// backupWithHooks executes the preBackup hook, takes backup and then executes the postBackup hook.
+// If the preBackup hook fails and its failure policy is "Fail", the backup is not taken. However, the postBackup hook
+// is always executed so that the user can revert the changes made by the preBackup hook. In case of failure, the returned
+// BackupOutput only holds the hook statistics of this host.
+func (c *BackupSessionController) backupWithHooks(resticWrapper *restic.ResticWrapper, backupOpt restic.BackupOptions, backupConfiguration *api_v1beta1.BackupConfiguration) (*restic.BackupOutput, error) {
+	backupHooks := backupConfiguration.Spec.Hooks
+	executor := &hooks.HookExecutor{
+		Config:     c.Config,
+		KubeClient: c.K8sClient,
+		Namespace:  c.Namespace,
+		PodName:    os.Getenv(util.KeyPodName),
+	}
+	for _, mount := range backupConfiguration.Spec.Target.VolumeMounts {
+		executor.MountPaths = append(executor.MountPaths, mount.MountPath)
+	}
+
+	var (
+		hookStats    []api_v1beta1.HookStats
+		backupOutput *restic.BackupOutput
+		errs         []error
+	)
+
+	preBackupStats, err := executor.Execute(api_v1beta1.HookPreBackup, backupHooks.PreBackup)
+	if preBackupStats != nil {
+		hookStats = append(hookStats, *preBackupStats)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		backupOutput, err = resticWrapper.RunBackup(backupOpt)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// unfreeze the file systems as soon as the backup is completed
+	if preBackupStats != nil && preBackupStats.Phase == api_v1beta1.HookSucceeded {
+		if err := executor.Unfreeze(backupHooks.PreBackup); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	postBackupStats, err := executor.Execute(api_v1beta1.HookPostBackup, backupHooks.PostBackup)
+	if postBackupStats != nil {
+		hookStats = append(hookStats, *postBackupStats)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return &restic.BackupOutput{
+			HostBackupStats: []api_v1beta1.HostBackupStats{
+				{
+					Hostname: c.Host,
+					Hooks:    hookStats,
+				},
+			},
+		}, errors.NewAggregate(errs)
+	}
+
+	for i := range backupOutput.HostBackupStats {
+		if backupOutput.HostBackupStats[i].Hostname == c.Host {
+			backupOutput.HostBackupStats[i].Hooks = hookStats
+		}
+	}
+	return backupOutput, nil
 }
 
 func (c *BackupSessionController) electLeaderPod(backupConfiguration *api_v1beta1.BackupConfiguration, stopCh <-chan struct{}) error {
@@ -339,7 +441,7 @@ func (c *BackupSessionController) electBackupLeader(backupSession *api_v1beta1.B
 				// run backup process
 				backupOutput, backupErr := c.backup(backupSession, backupConfiguration)
 				if backupErr != nil {
-					err := c.handleBackupFailure(backupSession.Name, backupErr)
+					err := c.handleBackupFailure(backupSession.Name, backupOutput, backupErr)
 					if err != nil {
 						backupErr = errors.NewAggregate([]error{backupErr, err})
 					}
@@ -347,8 +449,7 @@ func (c *BackupSessionController) electBackupLeader(backupSession *api_v1beta1.B
 					cancel()
 					// log failure. don't fail the container as it may interrupt user's service
 					log.Warningf("failed to complete backup. Reason: %v", backupErr)
-				}
-				if backupOutput != nil {
+				} else if backupOutput != nil {
 					err := c.handleBackupSuccess(backupSession.Name, backupOutput)
 					if err != nil {
 						// log failure. don't fail the container as it may interrupt user's service
@@ -424,9 +525,9 @@ func (c *BackupSessionController) handleBackupSuccess(backupSessionName string,
 	return statusOpt.UpdatePostBackupStatus(backupOutput)
 }
 
-func (c *BackupSessionController) handleBackupFailure(backupSessionName string, backupErr error) error {
+func (c *BackupSessionController) handleBackupFailure(backupSessionName string, failedOutput *restic.BackupOutput, backupErr error) error {
 	// write log
-	log.Warningf("Failed to take backup for BackupSession %s. Reason: ", backupSessionName, backupErr)
+	log.Warningf("Failed to take backup for BackupSession %s. Reason: %v", backupSessionName, backupErr)
 
 	// add/update entry into BackupSession status for this host
 	backupConfig, err := c.StashClient.StashV1beta1().BackupConfigurations(c.Namespace).Get(c.BackupConfigurationName, metav1.GetOptions{})
@@ -443,6 +544,14 @@ func (c *BackupSessionController) handleBackupFailure(backupSessionName string,
 			},
 		},
 	}
+	// keep the statistics of the hooks that has been executed before the failure
+	if failedOutput != nil {
+		for _, hostStats := range failedOutput.HostBackupStats {
+			if hostStats.Hostname == c.Host {
+				backupOutput.HostBackupStats[0].Hooks = hostStats.Hooks
+			}
+		}
+	}
 
 	statusOpt := status.UpdateStatusOptions{
 		Config:        c.Config,
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -10,6 +11,7 @@ import (
 	shell "github.com/codeskyblue/go-sh"
 	"github.com/pkg/errors"
 	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	"stash.appscode.dev/stash/pkg/restic"
 )
 
 const (
@@ -83,7 +85,19 @@ func (w *ResticWrapper) InitRepositoryIfAbsent() error {
 }
 
 func (w *ResticWrapper) Backup(resource *api.Restic, fg api.FileGroup) error {
-	args := []interface{}{"backup", fg.Path, "--force"}
+	args := []interface{}{"backup"}
+	// if include patterns are specified, then backup only the matching files of the path
+	if len(fg.Includes) > 0 {
+		includeFile, err := restic.WriteIncludeFile(w.scratchDir, fg.Path, fg.Includes)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(includeFile)
+		args = append(args, "--files-from", includeFile)
+	} else {
+		args = append(args, fg.Path)
+	}
+	args = append(args, "--force")
 	if w.hostname != "" {
 		args = append(args, "--hostname")
 		args = append(args, w.hostname)
@@ -93,6 +107,7 @@ func (w *ResticWrapper) Backup(resource *api.Restic, fg api.FileGroup) error {
 		args = append(args, "--tag")
 		args = append(args, tag)
 	}
+	args = restic.AppendFileFilterFlags(args, fg.FileFilter)
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCleanupCacheFlag(args)
 	args = w.appendCaCertFlag(args)
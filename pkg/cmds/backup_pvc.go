@@ -59,10 +59,21 @@ func NewCmdBackupPVC() *cobra.Command {
 	cmd.Flags().StringVar(&setupOpt.ScratchDir, "scratch-dir", setupOpt.ScratchDir, "Temporary directory")
 	cmd.Flags().BoolVar(&setupOpt.EnableCache, "enable-cache", setupOpt.EnableCache, "Specify whether to enable caching for restic")
 	cmd.Flags().IntVar(&setupOpt.MaxConnections, "max-connections", setupOpt.MaxConnections, "Specify maximum concurrent connections for GCS, Azure and B2 backend")
+	cmd.Flags().IntVar(&setupOpt.LimitUpload, "limit-upload", setupOpt.LimitUpload, "Limit the upload rate to the backend in KiB/s (0 means no limit)")
+	cmd.Flags().IntVar(&setupOpt.LimitDownload, "limit-download", setupOpt.LimitDownload, "Limit the download rate from the backend in KiB/s (0 means no limit)")
 
 	cmd.Flags().StringVar(&backupOpt.Host, "hostname", backupOpt.Host, "Name of the host machine")
 	cmd.Flags().StringSliceVar(&backupOpt.BackupPaths, "backup-paths", backupOpt.BackupPaths, "List of paths to backup")
 
+	cmd.Flags().StringSliceVar(&backupOpt.FileFilter.Includes, "include", backupOpt.FileFilter.Includes, "List of glob patterns (relative to backup paths) for the files to backup")
+	cmd.Flags().StringSliceVar(&backupOpt.FileFilter.Excludes, "exclude", backupOpt.FileFilter.Excludes, "List of patterns for the files to ignore")
+	cmd.Flags().StringSliceVar(&backupOpt.FileFilter.IExcludes, "iexclude", backupOpt.FileFilter.IExcludes, "Same as --exclude but ignores the case of the patterns")
+	cmd.Flags().StringSliceVar(&backupOpt.FileFilter.ExcludeFiles, "exclude-file", backupOpt.FileFilter.ExcludeFiles, "List of files that contain exclude patterns")
+	cmd.Flags().BoolVar(&backupOpt.FileFilter.ExcludeCaches, "exclude-caches", backupOpt.FileFilter.ExcludeCaches, "Specify whether to ignore the directories containing a CACHEDIR.TAG file")
+	cmd.Flags().StringSliceVar(&backupOpt.FileFilter.ExcludeIfPresent, "exclude-if-present", backupOpt.FileFilter.ExcludeIfPresent, "Ignore a directory if it contains any of these files (filename[:header])")
+	cmd.Flags().BoolVar(&backupOpt.FileFilter.OneFileSystem, "one-file-system", backupOpt.FileFilter.OneFileSystem, "Specify whether to ignore the directories that are in different file system")
+	cmd.Flags().StringSliceVar(&backupOpt.Tags, "tags", backupOpt.Tags, "List of tags that will be added to the snapshots")
+
 	cmd.Flags().IntVar(&backupOpt.RetentionPolicy.KeepLast, "retention-keep-last", backupOpt.RetentionPolicy.KeepLast, "Specify value for retention strategy")
 	cmd.Flags().IntVar(&backupOpt.RetentionPolicy.KeepHourly, "retention-keep-hourly", backupOpt.RetentionPolicy.KeepHourly, "Specify value for retention strategy")
 	cmd.Flags().IntVar(&backupOpt.RetentionPolicy.KeepDaily, "retention-keep-daily", backupOpt.RetentionPolicy.KeepDaily, "Specify value for retention strategy")
@@ -72,6 +83,8 @@ func NewCmdBackupPVC() *cobra.Command {
 	cmd.Flags().StringSliceVar(&backupOpt.RetentionPolicy.KeepTags, "retention-keep-tags", backupOpt.RetentionPolicy.KeepTags, "Specify value for retention strategy")
 	cmd.Flags().BoolVar(&backupOpt.RetentionPolicy.Prune, "retention-prune", backupOpt.RetentionPolicy.Prune, "Specify whether to prune old snapshot data")
 	cmd.Flags().BoolVar(&backupOpt.RetentionPolicy.DryRun, "retention-dry-run", backupOpt.RetentionPolicy.DryRun, "Specify whether to test retention policy without deleting actual data")
+	cmd.Flags().BoolVar(&backupOpt.SkipCheck, "skip-check", backupOpt.SkipCheck, "Specify whether to skip the integrity check of the repository after backup")
+	cmd.Flags().BoolVar(&backupOpt.SkipStats, "skip-stats", backupOpt.SkipStats, "Specify whether to skip reading the statistics of the repository after backup")
 
 	cmd.Flags().StringVar(&outputDir, "output-dir", outputDir, "Directory where output.json file will be written (keep empty if you don't need to write output in file)")
 
@@ -0,0 +1,120 @@
+package cmds
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/appscode/go/flags"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"kmodules.xyz/client-go/meta"
+	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func NewCmdCopyRepository() *cobra.Command {
+	var (
+		masterURL      string
+		kubeconfigPath string
+		replica        string
+		extraOpt       = util.ExtraOptions{
+			ScratchDir:  restic.DefaultScratchDir,
+			EnableCache: false,
+		}
+		statusOpt = status.UpdateStatusOptions{
+			Namespace: meta.Namespace(),
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:               "copy-repository",
+		Short:             "Copy the new snapshots of a repository to its replica",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.EnsureRequiredFlags(cmd, "repository", "replica", "secret-dir")
+
+			config, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfigPath)
+			if err != nil {
+				return err
+			}
+			statusOpt.Config = config
+			statusOpt.KubeClient = kubernetes.NewForConfigOrDie(config)
+			statusOpt.StashClient = cs.NewForConfigOrDie(config)
+			statusOpt.Metrics.JobName = fmt.Sprintf("%s-%s-%s", statusOpt.Namespace, statusOpt.Repository, replica)
+
+			repository, err := statusOpt.StashClient.StashV1alpha1().Repositories(statusOpt.Namespace).Get(statusOpt.Repository, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			setupOpt, err := util.SetupOptionsForRepository(*repository, extraOpt)
+			if err != nil {
+				return err
+			}
+			resticWrapper, err := restic.NewResticWrapper(setupOpt)
+			if err != nil {
+				return err
+			}
+
+			replicaWrapper, err := newReplicaResticWrapper(statusOpt, replica, extraOpt)
+			if err != nil {
+				return statusOpt.UpdatePostCopyStatus(replica, nil, err)
+			}
+			copyStats, err := resticWrapper.CopySnapshots(replicaWrapper)
+			return statusOpt.UpdatePostCopyStatus(replica, copyStats, err)
+		},
+	}
+	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
+	cmd.Flags().StringVar(&statusOpt.Repository, "repository", statusOpt.Repository, "Name of the Repository whose snapshots will be copied")
+	cmd.Flags().StringVar(&replica, "replica", replica, "Name of the Repository where the snapshots will be copied to")
+	cmd.Flags().StringVar(&statusOpt.Namespace, "namespace", statusOpt.Namespace, "Namespace of the Repositories")
+	cmd.Flags().StringVar(&extraOpt.SecretDir, "secret-dir", extraOpt.SecretDir, "Directory where storage secret of the Repository has been mounted")
+	cmd.Flags().StringVar(&extraOpt.ScratchDir, "scratch-dir", extraOpt.ScratchDir, "Temporary directory")
+	cmd.Flags().BoolVar(&extraOpt.EnableCache, "enable-cache", extraOpt.EnableCache, "Specify whether to enable caching for restic")
+	cmd.Flags().BoolVar(&statusOpt.Metrics.Enabled, "metrics-enabled", statusOpt.Metrics.Enabled, "Specify whether to export Prometheus metrics")
+	cmd.Flags().StringVar(&statusOpt.Metrics.PushgatewayURL, "pushgateway-url", statusOpt.Metrics.PushgatewayURL, "Pushgateway URL where the metrics will be pushed")
+	cmd.Flags().StringSliceVar(&statusOpt.Metrics.Labels, "metrics-labels", statusOpt.Metrics.Labels, "Labels to apply in exported metrics")
+
+	return cmd
+}
+
+// newReplicaResticWrapper writes the storage secret of the replica Repository in the scratch directory and
+// configures a restic wrapper for the replica.
+func newReplicaResticWrapper(statusOpt status.UpdateStatusOptions, replica string, extraOpt util.ExtraOptions) (*restic.ResticWrapper, error) {
+	repository, err := statusOpt.StashClient.StashV1alpha1().Repositories(statusOpt.Namespace).Get(replica, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if repository.Spec.Backend.Local != nil {
+		return nil, fmt.Errorf("Repository %s uses local backend which is not supported as a replica", replica)
+	}
+	secret, err := statusOpt.KubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	extraOpt.SecretDir = filepath.Join(extraOpt.ScratchDir, "replica-secret")
+	if err := os.MkdirAll(extraOpt.SecretDir, 0700); err != nil {
+		return nil, err
+	}
+	for key, value := range secret.Data {
+		if err := ioutil.WriteFile(filepath.Join(extraOpt.SecretDir, key), value, 0600); err != nil {
+			return nil, err
+		}
+	}
+	extraOpt.ScratchDir = filepath.Join(extraOpt.ScratchDir, "replica")
+	if err := os.MkdirAll(extraOpt.ScratchDir, 0755); err != nil {
+		return nil, err
+	}
+	setupOpt, err := util.SetupOptionsForRepository(*repository, extraOpt)
+	if err != nil {
+		return nil, err
+	}
+	return restic.NewResticWrapper(setupOpt)
+}
@@ -11,11 +11,14 @@ import (
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	"kmodules.xyz/client-go/meta"
 	"stash.appscode.dev/stash/apis"
+	"stash.appscode.dev/stash/apis/stash/v1alpha1"
 	"stash.appscode.dev/stash/apis/stash/v1beta1"
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 	cs "stash.appscode.dev/stash/client/clientset/versioned"
@@ -139,9 +142,62 @@ func (opt *VSoption) createVolumeSnapshot() (*restic.BackupOutput, error) {
 		}
 
 	}
+
+	// cleanup old VolumeSnapshots according to the retention policy
+	err = opt.cleanupVolumeSnapshots(backupConfig.Name, backupConfig.Spec.RetentionPolicy, backupOutput)
+	if err != nil {
+		return nil, err
+	}
 	return backupOutput, nil
 }
 
+// cleanupVolumeSnapshots removes the old VolumeSnapshots taken for the BackupConfiguration of each PVC according to the retention policy.
+// It updates the respective host stats with the removed snapshots and the repository stats with the total number of kept and removed snapshots.
+func (opt *VSoption) cleanupVolumeSnapshots(backupConfigName string, retentionPolicy v1alpha1.RetentionPolicy, backupOutput *restic.BackupOutput) error {
+	vsList, err := opt.snapshotClient.SnapshotV1alpha1().VolumeSnapshots(opt.namespace).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			util.LabelBackupConfiguration: backupConfigName,
+		}).String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, hostStats := range backupOutput.HostBackupStats {
+		// don't remove old snapshots of a PVC if we have failed to take new snapshot for it.
+		if hostStats.Phase != api_v1beta1.HostBackupSucceeded {
+			continue
+		}
+
+		// hostname is the name of the PVC for VolumeSnapshotter driver
+		snapshots := util.FilterVolumeSnapshotsForPVC(vsList.Items, backupConfigName, hostStats.Hostname)
+		// if no "keep" rule has been specified in the retention policy, then all the snapshots are kept.
+		keep, remove := util.ApplyRetentionPolicy(retentionPolicy, snapshots)
+
+		removed := make([]string, 0, len(remove))
+		for _, snapshot := range remove {
+			// for dry run, we only report the snapshots that would be removed
+			if !retentionPolicy.DryRun {
+				err = opt.snapshotClient.SnapshotV1alpha1().VolumeSnapshots(opt.namespace).Delete(snapshot.Name, meta.DeleteInBackground())
+				if err != nil && !kerr.IsNotFound(err) {
+					backupOutput.HostBackupStats[i].Phase = api_v1beta1.HostBackupFailed
+					backupOutput.HostBackupStats[i].Error = fmt.Sprintf("failed to remove old VolumeSnapshot %s/%s. Reason: %v", snapshot.Namespace, snapshot.Name, err)
+					break
+				}
+			}
+			removed = append(removed, snapshot.Name)
+		}
+		if len(removed) != 0 {
+			log.Infof("Removed old VolumeSnapshots of PVC %s/%s according to retention policy: %s", opt.namespace, hostStats.Hostname, strings.Join(removed, ", "))
+		}
+
+		backupOutput.HostBackupStats[i].RemovedSnapshots = removed
+		backupOutput.RepositoryStats.SnapshotCount += len(keep) + len(remove) - len(removed)
+		backupOutput.RepositoryStats.SnapshotsRemovedOnLastCleanup += len(removed)
+	}
+	return nil
+}
+
 func (opt *VSoption) getTargetPVCNames(targetRef api_v1beta1.TargetRef, replicas *int32) ([]string, error) {
 	var pvcList []string
 
@@ -192,6 +248,11 @@ func (opt *VSoption) getVolumeSnapshotDefinition(backupConfiguration *v1beta1.Ba
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      fmt.Sprintf("%s-%s", pvcName, timestamp),
 			Namespace: backupConfiguration.Namespace,
+			// the label scopes the retention policy to the VolumeSnapshots taken for this BackupConfiguration
+			Labels: map[string]string{
+				util.LabelApp:                 util.AppLabelStash,
+				util.LabelBackupConfiguration: backupConfiguration.Name,
+			},
 		},
 		Spec: vs.VolumeSnapshotSpec{
 			VolumeSnapshotClassName: &backupConfiguration.Spec.Target.VolumeSnapshotClassName,
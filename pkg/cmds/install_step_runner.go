@@ -0,0 +1,49 @@
+package cmds
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func NewCmdInstallStepRunner() *cobra.Command {
+	dir := util.StepsDirMountPath
+
+	cmd := &cobra.Command{
+		Use:               "install-step-runner",
+		Short:             "Install the step runner into a directory shared with the steps of a Task",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return installStepRunner(filepath.Join(dir, filepath.Base(util.StepRunnerBinary)))
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", dir, "Directory where the step runner will be installed")
+
+	return cmd
+}
+
+// installStepRunner copies the running binary to the destination so that it can run in the images of the steps.
+func installStepRunner(dest string) error {
+	src, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,76 @@
+package cmds
+
+import (
+	"github.com/appscode/go/flags"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"kmodules.xyz/client-go/meta"
+	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func NewCmdMaintainRepository() *cobra.Command {
+	var (
+		masterURL      string
+		kubeconfigPath string
+		maintenanceOpt restic.MaintenanceOptions
+		extraOpt       = util.ExtraOptions{
+			ScratchDir:  restic.DefaultScratchDir,
+			EnableCache: false,
+		}
+		statusOpt = status.UpdateStatusOptions{
+			Namespace: meta.Namespace(),
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:               "maintain-repository",
+		Short:             "Check the integrity of a repository, prune the unreferenced data from it or rotate its key",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			flags.EnsureRequiredFlags(cmd, "repository", "operation", "secret-dir")
+
+			config, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfigPath)
+			if err != nil {
+				return err
+			}
+			statusOpt.Config = config
+			statusOpt.KubeClient = kubernetes.NewForConfigOrDie(config)
+			statusOpt.StashClient = cs.NewForConfigOrDie(config)
+
+			repository, err := statusOpt.StashClient.StashV1alpha1().Repositories(statusOpt.Namespace).Get(statusOpt.Repository, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			setupOpt, err := util.SetupOptionsForRepository(*repository, extraOpt)
+			if err != nil {
+				return err
+			}
+			resticWrapper, err := restic.NewResticWrapper(setupOpt)
+			if err != nil {
+				return err
+			}
+
+			if maintenanceOpt.Operation == restic.MaintenanceRotateKey {
+				return newKeyRotator(statusOpt, repository, resticWrapper).rotate()
+			}
+			repoStats, err := resticWrapper.RunMaintenance(maintenanceOpt)
+			return statusOpt.UpdatePostMaintenanceStatus(maintenanceOpt.Operation, repoStats, err)
+		},
+	}
+	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
+	cmd.Flags().StringVar(&statusOpt.Repository, "repository", statusOpt.Repository, "Name of the Repository")
+	cmd.Flags().StringVar(&statusOpt.Namespace, "namespace", statusOpt.Namespace, "Namespace of the Repository")
+	cmd.Flags().StringVar((*string)(&maintenanceOpt.Operation), "operation", string(maintenanceOpt.Operation), "Maintenance operation to run (i.e. check, prune, rotate-key)")
+	cmd.Flags().StringVar(&maintenanceOpt.ReadDataSubset, "read-data-subset", maintenanceOpt.ReadDataSubset, "Subset of the data packs to read and verify during check (i.e. 1/5)")
+	cmd.Flags().StringVar(&extraOpt.SecretDir, "secret-dir", extraOpt.SecretDir, "Directory where storage secret has been mounted")
+	cmd.Flags().StringVar(&extraOpt.ScratchDir, "scratch-dir", extraOpt.ScratchDir, "Temporary directory")
+	cmd.Flags().BoolVar(&extraOpt.EnableCache, "enable-cache", extraOpt.EnableCache, "Specify whether to enable caching for restic")
+
+	return cmd
+}
@@ -0,0 +1,211 @@
+package cmds
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	v "github.com/appscode/go/version"
+	"github.com/spf13/cobra"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	repo_v1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/client/clientset/versioned/fake"
+	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
+	"stash.appscode.dev/stash/pkg/controller"
+	"stash.appscode.dev/stash/pkg/docker"
+)
+
+// resolveTaskObjects holds the objects read from the files given to resolve-task command
+type resolveTaskObjects struct {
+	stashObjects    []runtime.Object
+	repositories    []*api_v1alpha1.Repository
+	backupConfigs   []*api_v1beta1.BackupConfiguration
+	restoreSessions []*api_v1beta1.RestoreSession
+}
+
+func NewCmdResolveTask() *cobra.Command {
+	var (
+		filenames       []string
+		hostname        = "host-0"
+		allowUnresolved = false
+		image           = docker.Docker{
+			Registry: docker.ACRegistry,
+			Image:    docker.ImageStash,
+			Tag:      v.Version.Version,
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:   "resolve-task",
+		Short: "Preview the jobs generated from the Tasks and Functions without applying anything",
+		Long: "Resolve the Tasks and Functions used by the BackupConfigurations and RestoreSessions found in the given files " +
+			"and print the PodSpec of the respective jobs. All the Tasks, Functions and Repositories have to be given in the files. " +
+			"So, the command does not require access to a cluster.",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(filenames) == 0 {
+				return fmt.Errorf("no file has been specified")
+			}
+			objects := &resolveTaskObjects{}
+			for _, filename := range filenames {
+				if err := objects.readFile(filename); err != nil {
+					return err
+				}
+			}
+
+			resolvedJobs, err := objects.resolve(image, hostname)
+			if err != nil {
+				return err
+			}
+			if len(resolvedJobs) == 0 {
+				return fmt.Errorf("no BackupConfiguration or RestoreSession using a Task has been found")
+			}
+
+			var unresolved []string
+			for _, resolvedJob := range resolvedJobs {
+				data, err := json.MarshalIndent(resolvedJob, "", "    ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+				for _, key := range resolvedJob.Status.UnresolvedVariables {
+					unresolved = append(unresolved, fmt.Sprintf("%s/%s: ${%s}", resolvedJob.Namespace, resolvedJob.Name, key))
+				}
+			}
+			if len(unresolved) > 0 && !allowUnresolved {
+				return fmt.Errorf("found unresolved variables:\n\t%s", strings.Join(unresolved, "\n\t"))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&filenames, "filename", "f", filenames, "Files containing the Tasks, Functions, Repositories, BackupConfigurations and RestoreSessions")
+	cmd.Flags().StringVar(&hostname, "hostname", hostname, "Name of the host that will be used to resolve the restore jobs")
+	cmd.Flags().StringVar(&image.Registry, "docker-registry", image.Registry, "Docker image registry of the stash image used by the operator")
+	cmd.Flags().StringVar(&image.Tag, "image-tag", image.Tag, "Tag of the stash image used by the operator")
+	cmd.Flags().BoolVar(&allowUnresolved, "allow-unresolved", allowUnresolved, "Specify whether to succeed even if some variables can't be resolved")
+
+	return cmd
+}
+
+func (o *resolveTaskObjects) readFile(filename string) error {
+	data, err := readFileOrStdin(filename)
+	if err != nil {
+		return err
+	}
+	reader := yaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := stash_scheme.Codecs.UniversalDeserializer().Decode(doc, nil, nil)
+		if err != nil {
+			return fmt.Errorf("failed to decode %s. Reason: %v", filename, err)
+		}
+		o.add(obj)
+	}
+}
+
+func (o *resolveTaskObjects) add(obj runtime.Object) {
+	if accessor, ok := obj.(metav1.Object); ok && accessor.GetNamespace() == "" {
+		switch obj.(type) {
+		case *api_v1beta1.Task, *api_v1beta1.Function:
+		default:
+			accessor.SetNamespace(core.NamespaceDefault)
+		}
+	}
+	switch v := obj.(type) {
+	case *api_v1alpha1.Repository:
+		o.repositories = append(o.repositories, v)
+	case *api_v1beta1.BackupConfiguration:
+		o.backupConfigs = append(o.backupConfigs, v)
+	case *api_v1beta1.RestoreSession:
+		o.restoreSessions = append(o.restoreSessions, v)
+	}
+	o.stashObjects = append(o.stashObjects, obj)
+}
+
+func (o *resolveTaskObjects) getRepository(namespace, name string) (*api_v1alpha1.Repository, error) {
+	for _, repository := range o.repositories {
+		if repository.Namespace == namespace && repository.Name == name {
+			return repository, nil
+		}
+	}
+	return nil, fmt.Errorf("Repository %s/%s has not been found in the files", namespace, name)
+}
+
+func (o *resolveTaskObjects) resolve(image docker.Docker, hostname string) ([]*repo_v1alpha1.ResolvedJob, error) {
+	stashClient := fake.NewSimpleClientset(o.stashObjects...)
+
+	var resolvedJobs []*repo_v1alpha1.ResolvedJob
+	for _, backupConfig := range o.backupConfigs {
+		if backupConfig.Spec.Task.Name == "" {
+			continue
+		}
+		repository, err := o.getRepository(backupConfig.Namespace, backupConfig.Spec.Repository.Name)
+		if err != nil {
+			return nil, err
+		}
+		podSpec, unresolved, err := controller.ResolveBackupTask(stashClient, image, backupConfig, repository)
+		if err != nil {
+			return nil, err
+		}
+		resolvedJobs = append(resolvedJobs, newResolvedJob(backupConfig.ObjectMeta, backupConfig.Spec.Task.Name, podSpec, unresolved))
+	}
+	for _, restoreSession := range o.restoreSessions {
+		if restoreSession.Spec.Task.Name == "" {
+			continue
+		}
+		repository, err := o.getRepository(restoreSession.RepositoryNamespace(), restoreSession.Spec.Repository.Name)
+		if err != nil {
+			return nil, err
+		}
+		podSpec, unresolved, err := controller.ResolveRestoreTask(stashClient, image, restoreSession, repository, hostname)
+		if err != nil {
+			return nil, err
+		}
+		resolvedJobs = append(resolvedJobs, newResolvedJob(restoreSession.ObjectMeta, restoreSession.Spec.Task.Name, podSpec, unresolved))
+	}
+	return resolvedJobs, nil
+}
+
+func newResolvedJob(objMeta metav1.ObjectMeta, taskName string, podSpec core.PodSpec, unresolved []string) *repo_v1alpha1.ResolvedJob {
+	return &repo_v1alpha1.ResolvedJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: repo_v1alpha1.SchemeGroupVersion.String(),
+			Kind:       repo_v1alpha1.ResourceKindResolvedJob,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objMeta.Name,
+			Namespace: objMeta.Namespace,
+		},
+		Status: repo_v1alpha1.ResolvedJobStatus{
+			Task:                taskName,
+			PodSpec:             podSpec,
+			UnresolvedVariables: unresolved,
+		},
+	}
+}
+
+func readFileOrStdin(filename string) ([]byte, error) {
+	if filename == "-" {
+		return ioutil.ReadAll(os.Stdin)
+	}
+	return ioutil.ReadFile(filename)
+}
@@ -47,7 +47,7 @@ func NewCmdRestore() *cobra.Command {
 			// run restore
 			restoreOutput, restoreErr := restore.Restore(opt)
 			if restoreErr != nil {
-				err = opt.HandleRestoreFailure(restoreErr)
+				err = opt.HandleRestoreFailure(restoreOutput, restoreErr)
 				return errors.NewAggregate([]error{restoreErr, err})
 
 			}
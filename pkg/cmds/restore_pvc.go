@@ -1,7 +1,10 @@
 package cmds
 
 import (
+	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/appscode/go/flags"
 	"github.com/spf13/cobra"
@@ -12,8 +15,11 @@ import (
 
 func NewCmdRestorePVC() *cobra.Command {
 	var (
-		outputDir  string
-		restoreOpt = restic.RestoreOptions{
+		outputDir   string
+		pathMapping []string
+		before      string
+		after       string
+		restoreOpt  = restic.RestoreOptions{
 			Host: restic.DefaultHost,
 		}
 		setupOpt = restic.SetupOptions{
@@ -29,8 +35,20 @@ func NewCmdRestorePVC() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			flags.EnsureRequiredFlags(cmd, "restore-dirs", "provider", "secret-dir")
 
+			var err error
+			restoreOpt.PathMapping, err = parsePathMapping(pathMapping)
+			if err != nil {
+				return err
+			}
+			if restoreOpt.Before, err = parseTime(before); err != nil {
+				return err
+			}
+			if restoreOpt.After, err = parseTime(after); err != nil {
+				return err
+			}
+
 			var restoreOutput *restic.RestoreOutput
-			restoreOutput, err := restorePVC(restoreOpt, setupOpt)
+			restoreOutput, err = restorePVC(restoreOpt, setupOpt)
 			if err != nil {
 				restoreOutput = &restic.RestoreOutput{
 					HostRestoreStats: []api_v1beta1.HostRestoreStats{
@@ -58,10 +76,19 @@ func NewCmdRestorePVC() *cobra.Command {
 	cmd.Flags().StringVar(&setupOpt.ScratchDir, "scratch-dir", setupOpt.ScratchDir, "Temporary directory")
 	cmd.Flags().BoolVar(&setupOpt.EnableCache, "enable-cache", setupOpt.EnableCache, "Specify whether to enable caching for restic")
 	cmd.Flags().IntVar(&setupOpt.MaxConnections, "max-connections", setupOpt.MaxConnections, "Specify maximum concurrent connections for GCS, Azure and B2 backend")
+	cmd.Flags().IntVar(&setupOpt.LimitUpload, "limit-upload", setupOpt.LimitUpload, "Limit the upload rate to the backend in KiB/s (0 means no limit)")
+	cmd.Flags().IntVar(&setupOpt.LimitDownload, "limit-download", setupOpt.LimitDownload, "Limit the download rate from the backend in KiB/s (0 means no limit)")
 
 	cmd.Flags().StringVar(&restoreOpt.Host, "hostname", restoreOpt.Host, "Name of the host machine")
 	cmd.Flags().StringSliceVar(&restoreOpt.RestorePaths, "restore-paths", restoreOpt.RestorePaths, "List of paths to restore")
 	cmd.Flags().StringSliceVar(&restoreOpt.Snapshots, "snapshots", restoreOpt.Snapshots, "List of snapshots to be restored")
+	cmd.Flags().StringVar(&restoreOpt.Destination, "destination", restoreOpt.Destination, "Directory where the files will be restored at their original path")
+	cmd.Flags().StringSliceVar(&pathMapping, "path-mapping", pathMapping, "List of backed up paths and the directories where their content will be restored (i.e. /source/data=/restore/data)")
+	cmd.Flags().StringSliceVar(&restoreOpt.Includes, "include", restoreOpt.Includes, "List of patterns for the files to restore")
+	cmd.Flags().StringSliceVar(&restoreOpt.Excludes, "exclude", restoreOpt.Excludes, "List of patterns for the files to ignore during restore")
+	cmd.Flags().StringVar(&before, "before", before, "Restore the latest snapshot taken before this time (RFC3339 format)")
+	cmd.Flags().StringVar(&after, "after", after, "Restore the latest snapshot taken after this time (RFC3339 format)")
+	cmd.Flags().StringSliceVar(&restoreOpt.Tags, "tags", restoreOpt.Tags, "Restore the latest snapshot having all of these tags")
 
 	cmd.Flags().StringVar(&outputDir, "output-dir", outputDir, "Directory where output.json file will be written (keep empty if you don't need to write output in file)")
 
@@ -88,3 +115,31 @@ func restorePVC(restoreOpt restic.RestoreOptions, setupOpt restic.SetupOptions)
 	// Run restore
 	return resticWrapper.RunRestore(restoreOpt)
 }
+
+// parsePathMapping parses the path mappings specified in "source=destination" format.
+func parsePathMapping(mappings []string) (map[string]string, error) {
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	pathMapping := make(map[string]string, len(mappings))
+	for _, mapping := range mappings {
+		parts := strings.SplitN(mapping, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid path mapping %q. It must be in \"source=destination\" format", mapping)
+		}
+		pathMapping[parts[0]] = parts[1]
+	}
+	return pathMapping, nil
+}
+
+// parseTime parses a time specified in RFC3339 format. It returns nil if the time is empty.
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time %q. It must be in RFC3339 format", value)
+	}
+	return &t, nil
+}
@@ -54,6 +54,12 @@ func NewRootCmd() *cobra.Command {
 	rootCmd.AddCommand(NewCmdForget())
 	rootCmd.AddCommand(NewCmdCreateBackupSession())
 	rootCmd.AddCommand(NewCmdRestore())
+	rootCmd.AddCommand(NewCmdVerifyRestore())
+	rootCmd.AddCommand(NewCmdMaintainRepository())
+	rootCmd.AddCommand(NewCmdCopyRepository())
+	rootCmd.AddCommand(NewCmdResolveTask())
+	rootCmd.AddCommand(NewCmdRunStep())
+	rootCmd.AddCommand(NewCmdInstallStepRunner())
 	rootCmd.AddCommand(NewCmdRunBackup())
 
 	rootCmd.AddCommand(NewCmdBackupPVC())
@@ -0,0 +1,272 @@
+package cmds
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core_util "kmodules.xyz/client-go/core/v1"
+	"stash.appscode.dev/stash/apis"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	stash_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1alpha1/util"
+	"stash.appscode.dev/stash/pkg/eventer"
+	stash_rbac "stash.appscode.dev/stash/pkg/rbac"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/status"
+)
+
+// defaultKeyRotationGracePeriod is long enough for the kubelets to propagate the updated storage secret to the pods
+const defaultKeyRotationGracePeriod = 5 * time.Minute
+
+// keyRotator replaces the key of a repository with a newly generated one. The steps are:
+//  1. add a new key with a random password
+//  2. write the new password in the storage secret
+//  3. verify that the password of the storage secret opens the repository with the new key
+//  4. wait for the grace period so that the pods that mount the storage secret get the new password
+//  5. remove the old key
+//
+// If one of the first three steps fails, the changes made by the previous steps are reverted.
+// If the old key has not been removed, the next key rotation removes it first.
+type keyRotator struct {
+	statusOpt     status.UpdateStatusOptions
+	repository    *api_v1alpha1.Repository
+	resticWrapper *restic.ResticWrapper
+}
+
+func newKeyRotator(statusOpt status.UpdateStatusOptions, repository *api_v1alpha1.Repository, resticWrapper *restic.ResticWrapper) *keyRotator {
+	return &keyRotator{
+		statusOpt:     statusOpt,
+		repository:    repository,
+		resticWrapper: resticWrapper,
+	}
+}
+
+func (r *keyRotator) rotate() error {
+	if err := r.ensureSecretNotShared(); err != nil {
+		return r.handleFailure(err)
+	}
+	// remove the old key left by a previous key rotation that has failed to remove it
+	if rotation := r.repository.Status.KeyRotation; rotation != nil && rotation.RetiredKeyID != "" {
+		if err := r.removeRetiredKey(rotation.RetiredKeyID); err != nil {
+			return r.handleFailure(err)
+		}
+	}
+	secret, err := r.statusOpt.KubeClient.CoreV1().Secrets(r.repository.Namespace).Get(r.repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return r.handleFailure(err)
+	}
+	oldPassword := secret.Data[restic.RESTIC_PASSWORD]
+
+	// step 1: add new key
+	startTime := metav1.Now()
+	if err := r.updateStatus(func(in *api_v1alpha1.KeyRotationStatus) {
+		in.Phase = api_v1alpha1.KeyRotationAddingKey
+		in.StartTime = &startTime
+		in.Reason = ""
+	}); err != nil {
+		return err
+	}
+	oldKey, err := r.resticWrapper.CurrentKey()
+	if err != nil {
+		return r.handleFailure(err)
+	}
+	newPassword, err := restic.GeneratePassword()
+	if err != nil {
+		return r.handleFailure(err)
+	}
+	newKeyID, err := r.resticWrapper.AddKey(newPassword)
+	if err != nil {
+		return r.handleFailure(err)
+	}
+
+	// step 2: write new password in the storage secret
+	if err := r.updateStatus(func(in *api_v1alpha1.KeyRotationStatus) {
+		in.Phase = api_v1alpha1.KeyRotationUpdatingSecret
+	}); err != nil {
+		return r.rollback(err, newKeyID, nil)
+	}
+	if err := r.updatePassword(secret, []byte(newPassword)); err != nil {
+		return r.rollback(err, newKeyID, nil)
+	}
+
+	// step 3: verify that the password of the storage secret opens the repository with the new key
+	if err := r.updateStatus(func(in *api_v1alpha1.KeyRotationStatus) {
+		in.Phase = api_v1alpha1.KeyRotationVerifyingKey
+	}); err != nil {
+		return r.rollback(err, newKeyID, oldPassword)
+	}
+	if err := r.verifyKey(newKeyID); err != nil {
+		return r.rollback(err, newKeyID, oldPassword)
+	}
+
+	// step 4: wait for the grace period. the new key has been verified. so, don't rollback from here.
+	if err := r.updateStatus(func(in *api_v1alpha1.KeyRotationStatus) {
+		in.Phase = api_v1alpha1.KeyRotationWaitingGrace
+		in.CurrentKeyID = newKeyID
+		in.RetiredKeyID = oldKey.ID
+	}); err != nil {
+		return err
+	}
+	gracePeriod := defaultKeyRotationGracePeriod
+	if m := r.repository.Spec.Maintenance; m != nil && m.KeyRotation != nil && m.KeyRotation.GracePeriod != nil {
+		gracePeriod = m.KeyRotation.GracePeriod.Duration
+	}
+	log.Infof("Waiting %s before removing the old key %s", gracePeriod, oldKey.ID)
+	time.Sleep(gracePeriod)
+
+	// step 5: remove the old key. the captured key is used so that the removal doesn't depend on the status update above.
+	if err := r.removeRetiredKey(oldKey.ID); err != nil {
+		return r.handleFailure(fmt.Errorf("the key has been replaced with %s but failed to remove the old key %s. Reason: %v", newKeyID, oldKey.ID, err))
+	}
+
+	rotationTime := metav1.Now()
+	if err := r.updateStatus(func(in *api_v1alpha1.KeyRotationStatus) {
+		in.Phase = api_v1alpha1.KeyRotationSucceeded
+		in.LastRotationTime = &rotationTime
+	}); err != nil {
+		return err
+	}
+	eventer.CreateEventWithLog(
+		r.statusOpt.KubeClient,
+		eventer.EventSourceRepositoryMaintainer,
+		r.repository,
+		core.EventTypeNormal,
+		eventer.EventReasonRepositoryKeyRotated,
+		fmt.Sprintf("key %s of the repository has been replaced with key %s. The new password has been written in secret %s/%s.",
+			oldKey.ID, newKeyID, r.repository.Namespace, r.repository.Spec.Backend.StorageSecretName),
+	)
+	return nil
+}
+
+// ensureSecretNotShared ensures that no other Repository uses the storage secret and that the storage secret has not been
+// copied into other namespaces to restore from the Repository. Otherwise, the other Repositories and the copies won't be
+// able to open the repository after the old key is removed.
+func (r *keyRotator) ensureSecretNotShared() error {
+	repositories, err := r.statusOpt.StashClient.StashV1alpha1().Repositories(r.repository.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, repo := range repositories.Items {
+		if repo.Name != r.repository.Name && repo.Spec.Backend.StorageSecretName == r.repository.Spec.Backend.StorageSecretName {
+			return fmt.Errorf("storage secret %s is also used by Repository %s", r.repository.Spec.Backend.StorageSecretName, repo.Name)
+		}
+	}
+	namespaces, err := stash_rbac.ListCrossNamespaceRepoReaders(r.statusOpt.KubeClient, r.repository)
+	if err != nil {
+		return err
+	}
+	if len(namespaces) > 0 {
+		return fmt.Errorf("storage secret %s has been copied into namespace(s) %s to restore from the Repository",
+			r.repository.Spec.Backend.StorageSecretName, strings.Join(namespaces, ", "))
+	}
+	return nil
+}
+
+// removeRetiredKey removes the key that has been replaced by the current key rotation or by a previous one that has failed to remove it
+func (r *keyRotator) removeRetiredKey(retiredKeyID string) error {
+	if err := r.updateStatus(func(in *api_v1alpha1.KeyRotationStatus) {
+		in.Phase = api_v1alpha1.KeyRotationRemovingOldKey
+	}); err != nil {
+		return err
+	}
+	keys, err := r.resticWrapper.ListKeys()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		// the key may have been removed by a previous key rotation that has failed to update the status
+		if key.ID == retiredKeyID && !key.Current {
+			if err := r.resticWrapper.RemoveKey(retiredKeyID); err != nil {
+				return err
+			}
+		}
+	}
+	return r.updateStatus(func(in *api_v1alpha1.KeyRotationStatus) {
+		in.RetiredKeyID = ""
+	})
+}
+
+func (r *keyRotator) updatePassword(secret *core.Secret, password []byte) error {
+	_, _, err := core_util.PatchSecret(r.statusOpt.KubeClient, secret, func(in *core.Secret) *core.Secret {
+		in.Data[restic.RESTIC_PASSWORD] = password
+		return in
+	})
+	return err
+}
+
+func (r *keyRotator) verifyKey(keyID string) error {
+	secret, err := r.statusOpt.KubeClient.CoreV1().Secrets(r.repository.Namespace).Get(r.repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	r.resticWrapper.SetPassword(string(secret.Data[restic.RESTIC_PASSWORD]))
+	key, err := r.resticWrapper.CurrentKey()
+	if err != nil {
+		return err
+	}
+	if key.ID != keyID {
+		return fmt.Errorf("the password of the storage secret opens the repository with key %s instead of %s", key.ID, keyID)
+	}
+	return nil
+}
+
+// rollback restores the old password in the storage secret if it has been changed and removes the new key
+func (r *keyRotator) rollback(rotationErr error, newKeyID string, oldPassword []byte) error {
+	if oldPassword != nil {
+		secret, err := r.statusOpt.KubeClient.CoreV1().Secrets(r.repository.Namespace).Get(r.repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+		if err == nil {
+			err = r.updatePassword(secret, oldPassword)
+		}
+		if err != nil {
+			return r.handleFailure(fmt.Errorf("%v. Failed to restore the old password in the storage secret. Reason: %v", rotationErr, err))
+		}
+		r.resticWrapper.SetPassword(string(oldPassword))
+	}
+	if err := r.resticWrapper.RemoveKey(newKeyID); err != nil {
+		log.Warningf("failed to remove key %s during rollback. Reason: %v", newKeyID, err)
+	}
+	return r.handleFailure(rotationErr)
+}
+
+// handleFailure records the failure in the Repository status, writes an event to the Repository and
+// returns the error so that the job fails.
+func (r *keyRotator) handleFailure(rotationErr error) error {
+	if err := r.updateStatus(func(in *api_v1alpha1.KeyRotationStatus) {
+		in.Phase = api_v1alpha1.KeyRotationFailed
+		in.Reason = rotationErr.Error()
+	}); err != nil {
+		log.Warningf("failed to update key rotation status of Repository %s/%s. Reason: %v", r.repository.Namespace, r.repository.Name, err)
+	}
+	eventer.CreateEventWithLog(
+		r.statusOpt.KubeClient,
+		eventer.EventSourceRepositoryMaintainer,
+		r.repository,
+		core.EventTypeWarning,
+		eventer.EventReasonRepositoryKeyRotationFailed,
+		fmt.Sprintf("key rotation of the repository has failed. Reason: %v", rotationErr),
+	)
+	return rotationErr
+}
+
+func (r *keyRotator) updateStatus(transform func(in *api_v1alpha1.KeyRotationStatus)) error {
+	repository, err := stash_util.UpdateRepositoryStatus(
+		r.statusOpt.StashClient.StashV1alpha1(),
+		r.repository,
+		func(in *api_v1alpha1.RepositoryStatus) *api_v1alpha1.RepositoryStatus {
+			if in.KeyRotation == nil {
+				in.KeyRotation = &api_v1alpha1.KeyRotationStatus{}
+			}
+			transform(in.KeyRotation)
+			return in
+		},
+		apis.EnableStatusSubresource,
+	)
+	if err != nil {
+		return err
+	}
+	r.repository = repository
+	return nil
+}
@@ -0,0 +1,205 @@
+package cmds
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"kmodules.xyz/client-go/meta"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	stepSucceeded = "Succeeded"
+	stepFailed    = "Failed"
+	stepSkipped   = "Skipped"
+
+	stepPollInterval = time.Second
+	// the containers of the previous steps are checked less often as it needs an API call
+	containerCheckInterval = 10 * time.Second
+)
+
+type stepRunner struct {
+	dir               string
+	step              int
+	when              string
+	waitFor           []int
+	waitForContainers []string
+	kubeClient        kubernetes.Interface
+	podName           string
+	namespace         string
+}
+
+func NewCmdRunStep() *cobra.Command {
+	var (
+		masterURL      string
+		kubeconfigPath string
+		opt            = stepRunner{
+			dir:  util.StepsDirMountPath,
+			when: string(api_v1beta1.StepOnSuccess),
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:               "run-step [flags] -- command [args...]",
+		Short:             "Run a step of a Task depending on the result of the previous steps",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("no command has been specified for step %d", opt.step)
+			}
+			if len(opt.waitForContainers) > 0 {
+				if len(opt.waitForContainers) != len(opt.waitFor) {
+					return fmt.Errorf("--wait-for-containers must have a container for each step of --wait-for")
+				}
+				config, err := clientcmd.BuildConfigFromFlags(masterURL, kubeconfigPath)
+				if err != nil {
+					return err
+				}
+				opt.kubeClient = kubernetes.NewForConfigOrDie(config)
+				opt.podName = os.Getenv(util.KeyPodName)
+				opt.namespace = meta.Namespace()
+			}
+			return opt.run(args)
+		},
+	}
+	cmd.Flags().StringVar(&opt.dir, "dir", opt.dir, "Directory shared by the steps to record their results")
+	cmd.Flags().IntVar(&opt.step, "step", opt.step, "Index of the step in the Task")
+	cmd.Flags().StringVar(&opt.when, "when", opt.when, "Condition of the step. One of OnSuccess, OnFailure or Always")
+	cmd.Flags().IntSliceVar(&opt.waitFor, "wait-for", opt.waitFor, "Index of the steps that must complete before this step")
+	cmd.Flags().StringSliceVar(&opt.waitForContainers, "wait-for-containers", opt.waitForContainers, "Name of the containers of the steps of --wait-for in the same order")
+	cmd.Flags().StringVar(&masterURL, "master", masterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
+	cmd.Flags().StringVar(&kubeconfigPath, "kubeconfig", kubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
+
+	return cmd
+}
+
+func (opt stepRunner) run(command []string) error {
+	// wait for the previous steps and find out whether any of them has failed
+	failed := false
+	for i, step := range opt.waitFor {
+		container := ""
+		if i < len(opt.waitForContainers) {
+			container = opt.waitForContainers[i]
+		}
+		result, err := opt.waitForResult(step, container)
+		if err != nil {
+			return err
+		}
+		if result == stepFailed {
+			failed = true
+		}
+	}
+
+	var run bool
+	switch api_v1beta1.StepCondition(opt.when) {
+	case api_v1beta1.StepOnSuccess:
+		run = !failed
+	case api_v1beta1.StepOnFailure:
+		run = failed
+	case api_v1beta1.StepAlways:
+		run = true
+	default:
+		return fmt.Errorf("invalid condition %q for step %d", opt.when, opt.step)
+	}
+	if !run {
+		log.Infof("Skipping step %d as its condition %s does not match", opt.step, opt.when)
+		return opt.writeResult(stepSkipped)
+	}
+
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	runErr := cmd.Run()
+
+	result := stepSucceeded
+	if runErr != nil {
+		result = stepFailed
+	}
+	if err := opt.writeResult(result); err != nil {
+		return err
+	}
+	if runErr != nil {
+		return fmt.Errorf("step %d has failed. Reason: %v", opt.step, runErr)
+	}
+	return nil
+}
+
+// waitForResult waits until the step records its result. A step can't record its result if its container
+// is killed (i.e. OOMKilled). So, the container of the step is watched too and the step is considered failed
+// if the container has terminated without recording the result.
+func (opt stepRunner) waitForResult(step int, container string) (string, error) {
+	lastCheck := time.Now()
+	for {
+		result, found, err := opt.readResult(step)
+		if err != nil || found {
+			return result, err
+		}
+		if container != "" && time.Since(lastCheck) >= containerCheckInterval {
+			lastCheck = time.Now()
+			terminated, err := opt.isContainerTerminated(container)
+			if err != nil {
+				log.Warningf("Failed to get the state of container %s of step %d. Reason: %v", container, step, err)
+			} else if terminated {
+				// the result might have been recorded just before the container has terminated
+				result, found, err := opt.readResult(step)
+				if err != nil || found {
+					return result, err
+				}
+				log.Warningf("Container %s of step %d has terminated without recording its result", container, step)
+				return stepFailed, nil
+			}
+		}
+		time.Sleep(stepPollInterval)
+	}
+}
+
+func (opt stepRunner) readResult(step int) (string, bool, error) {
+	data, err := ioutil.ReadFile(opt.resultFile(step))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return strings.TrimSpace(string(data)), true, nil
+}
+
+// isContainerTerminated checks whether the container of this pod has terminated.
+func (opt stepRunner) isContainerTerminated(container string) (bool, error) {
+	pod, err := opt.kubeClient.CoreV1().Pods(opt.namespace).Get(opt.podName, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return status.State.Terminated != nil, nil
+		}
+	}
+	return false, nil
+}
+
+// writeResult records the result of the step. The result is renamed into place so that
+// the waiting steps never read a partially written result.
+func (opt stepRunner) writeResult(result string) error {
+	tmpFile := opt.resultFile(opt.step) + ".tmp"
+	if err := ioutil.WriteFile(tmpFile, []byte(result), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, opt.resultFile(opt.step))
+}
+
+func (opt stepRunner) resultFile(step int) string {
+	return filepath.Join(opt.dir, fmt.Sprintf("step-%d.result", step))
+}
@@ -3,11 +3,13 @@ package server
 import (
 	"flag"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/spf13/pflag"
 	crd_cs "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/leaderelection"
 	"kmodules.xyz/client-go/discovery"
 	appcatalog_cs "kmodules.xyz/custom-resources/client/clientset/versioned"
 	ocapps "kmodules.xyz/openshift/apis/apps/v1"
@@ -29,6 +31,16 @@ type ExtraOptions struct {
 	ResyncPeriod            time.Duration
 	EnableValidatingWebhook bool
 	EnableMutatingWebhook   bool
+
+	SnapshotIndexRefreshPeriod     time.Duration
+	MaxConcurrentBackupsPerBackend int
+	RepositoryProbeInterval        time.Duration
+
+	EnableLeaderElection        bool
+	LeaderElectionNamespace     string
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
 }
 
 func NewExtraOptions() *ExtraOptions {
@@ -41,6 +53,14 @@ func NewExtraOptions() *ExtraOptions {
 		QPS:            100,
 		Burst:          100,
 		ResyncPeriod:   10 * time.Minute,
+
+		SnapshotIndexRefreshPeriod: 5 * time.Minute,
+		RepositoryProbeInterval:    30 * time.Minute,
+
+		LeaderElectionNamespace:     os.Getenv("MY_POD_NAMESPACE"),
+		LeaderElectionLeaseDuration: 15 * time.Second,
+		LeaderElectionRenewDeadline: 10 * time.Second,
+		LeaderElectionRetryPeriod:   2 * time.Second,
 	}
 }
 
@@ -53,6 +73,18 @@ func (s *ExtraOptions) AddGoFlags(fs *flag.FlagSet) {
 	fs.IntVar(&s.Burst, "burst", s.Burst, "The maximum burst for throttle")
 	fs.DurationVar(&s.ResyncPeriod, "resync-period", s.ResyncPeriod, "If non-zero, will re-list this often. Otherwise, re-list will be delayed aslong as possible (until the upstream source closes the watch or times out.")
 
+	fs.DurationVar(&s.SnapshotIndexRefreshPeriod, "snapshot-index-refresh-period", s.SnapshotIndexRefreshPeriod, "Interval at which the in-memory index of the snapshots is refreshed from the backend. Set it to zero to disable the index.")
+
+	fs.IntVar(&s.MaxConcurrentBackupsPerBackend, "max-concurrent-backups-per-backend", s.MaxConcurrentBackupsPerBackend, "Maximum number of BackupSessions that can run at the same time against a backend. Set it to zero for no limit.")
+
+	fs.DurationVar(&s.RepositoryProbeInterval, "repository-probe-interval", s.RepositoryProbeInterval, "Interval at which the Secret, the backend and the password of each Repository are probed. Set it to zero to disable the probes.")
+
+	fs.BoolVar(&s.EnableLeaderElection, "enable-leader-election", s.EnableLeaderElection, "If true, runs the controller loops only in the replica that holds the stash-operator Lease. Requires permission to get, create and update leases in the coordination.k8s.io group.")
+	fs.StringVar(&s.LeaderElectionNamespace, "leader-election-namespace", s.LeaderElectionNamespace, "Namespace of the Lease used for leader election. Defaults to the namespace of the operator pod.")
+	fs.DurationVar(&s.LeaderElectionLeaseDuration, "leader-election-lease-duration", s.LeaderElectionLeaseDuration, "Duration that the non-leader replicas wait before trying to acquire the leadership after the leader stops renewing the Lease.")
+	fs.DurationVar(&s.LeaderElectionRenewDeadline, "leader-election-renew-deadline", s.LeaderElectionRenewDeadline, "Duration that the leader retries renewing the Lease before giving up the leadership.")
+	fs.DurationVar(&s.LeaderElectionRetryPeriod, "leader-election-retry-period", s.LeaderElectionRetryPeriod, "Duration the replicas wait between tries of acquiring and renewing the Lease.")
+
 	fs.BoolVar(&s.EnableMutatingWebhook, "enable-mutating-webhook", s.EnableMutatingWebhook, "If true, enables mutating webhooks for KubeDB CRDs.")
 	fs.BoolVar(&s.EnableValidatingWebhook, "enable-validating-webhook", s.EnableValidatingWebhook, "If true, enables validating webhooks for KubeDB CRDs.")
 	fs.BoolVar(&apis.EnableStatusSubresource, "enable-status-subresource", apis.EnableStatusSubresource, "If true, uses sub resource for KubeDB crds.")
@@ -76,6 +108,14 @@ func (s *ExtraOptions) ApplyTo(cfg *controller.Config) error {
 	cfg.ClientConfig.Burst = s.Burst
 	cfg.EnableMutatingWebhook = s.EnableMutatingWebhook
 	cfg.EnableValidatingWebhook = s.EnableValidatingWebhook
+	cfg.SnapshotIndexRefreshPeriod = s.SnapshotIndexRefreshPeriod
+	cfg.MaxConcurrentBackupsPerBackend = s.MaxConcurrentBackupsPerBackend
+	cfg.RepositoryProbeInterval = s.RepositoryProbeInterval
+	cfg.EnableLeaderElection = s.EnableLeaderElection
+	cfg.LeaderElectionNamespace = s.LeaderElectionNamespace
+	cfg.LeaderElectionLeaseDuration = s.LeaderElectionLeaseDuration
+	cfg.LeaderElectionRenewDeadline = s.LeaderElectionRenewDeadline
+	cfg.LeaderElectionRetryPeriod = s.LeaderElectionRetryPeriod
 
 	if cfg.KubeClient, err = kubernetes.NewForConfig(cfg.ClientConfig); err != nil {
 		return err
@@ -109,5 +149,16 @@ func (s *ExtraOptions) Validate() []error {
 	if s.StashImageTag == "" {
 		errs = append(errs, fmt.Errorf("--image-tag must be specified"))
 	}
+	if s.EnableLeaderElection {
+		if s.LeaderElectionLeaseDuration <= s.LeaderElectionRenewDeadline {
+			errs = append(errs, fmt.Errorf("--leader-election-lease-duration must be greater than --leader-election-renew-deadline"))
+		}
+		if s.LeaderElectionRetryPeriod <= 0 {
+			errs = append(errs, fmt.Errorf("--leader-election-retry-period must be greater than zero"))
+		}
+		if s.LeaderElectionRenewDeadline <= time.Duration(leaderelection.JitterFactor*float64(s.LeaderElectionRetryPeriod)) {
+			errs = append(errs, fmt.Errorf("--leader-election-renew-deadline must be greater than %v times --leader-election-retry-period", leaderelection.JitterFactor))
+		}
+	}
 	return errs
 }
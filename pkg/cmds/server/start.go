@@ -90,6 +90,10 @@ func (o StashOptions) Config() (*server.StashConfig, error) {
 		"/apis/admission.stash.appscode.com/v1alpha1/replicationcontrollermutators",
 		"/apis/admission.stash.appscode.com/v1alpha1/replicasetmutators",
 		"/apis/admission.stash.appscode.com/v1alpha1/deploymentconfigmutators",
+		"/apis/admission.stash.appscode.com/v1beta1/backupconfigurationvalidators",
+		"/apis/admission.stash.appscode.com/v1beta1/backupblueprintvalidators",
+		"/apis/admission.stash.appscode.com/v1beta1/taskvalidators",
+		"/apis/admission.stash.appscode.com/v1beta1/functionvalidators",
 		"/apis/admission.stash.appscode.com/v1beta1/restoresessionvalidators",
 	}
 
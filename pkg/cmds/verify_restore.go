@@ -0,0 +1,58 @@
+package cmds
+
+import (
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"kmodules.xyz/client-go/meta"
+	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/restore"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+func NewCmdVerifyRestore() *cobra.Command {
+	var (
+		scratchDir = util.VerifyDirMountPath
+		opt        = &restore.Options{
+			Namespace: meta.Namespace(),
+			Host:      restic.DefaultHost,
+			SetupOpt: restic.SetupOptions{
+				ScratchDir:  "/tmp",
+				EnableCache: false,
+			},
+		}
+	)
+
+	cmd := &cobra.Command{
+		Use:               "verify-restore",
+		Short:             "Verify that the backups are restorable",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := clientcmd.BuildConfigFromFlags(opt.MasterURL, opt.KubeconfigPath)
+			if err != nil {
+				return err
+			}
+			opt.Config = config
+			opt.KubeClient = kubernetes.NewForConfigOrDie(config)
+			opt.StashClient = cs.NewForConfigOrDie(config)
+			opt.Metrics.JobName = opt.RestoreSessionName
+
+			return restore.Verify(opt, scratchDir)
+		},
+	}
+	cmd.Flags().StringVar(&opt.MasterURL, "master", opt.MasterURL, "The address of the Kubernetes API server (overrides any value in kubeconfig)")
+	cmd.Flags().StringVar(&opt.KubeconfigPath, "kubeconfig", opt.KubeconfigPath, "Path to kubeconfig file with authorization information (the master location is set by the master flag).")
+	cmd.Flags().StringVar(&opt.RestoreSessionName, "restoresession", opt.RestoreSessionName, "Name of the respective RestoreSession object.")
+	cmd.Flags().StringVar(&opt.Namespace, "namespace", opt.Namespace, "Namespace of the respective RestoreSession object")
+	cmd.Flags().StringVar(&opt.Host, "hostname", opt.Host, "Name of the host whose backups will be verified")
+	cmd.Flags().StringVar(&scratchDir, "scratch-dir", scratchDir, "Directory where the snapshots will be restored for verification")
+	cmd.Flags().BoolVar(&opt.SetupOpt.EnableCache, "enable-cache", opt.SetupOpt.EnableCache, "Specify whether to enable caching for restic")
+	cmd.Flags().IntVar(&opt.SetupOpt.MaxConnections, "max-connections", opt.SetupOpt.MaxConnections, "Specify maximum concurrent connections for GCS, Azure and B2 backend")
+	cmd.Flags().StringVar(&opt.SetupOpt.SecretDir, "secret-dir", opt.SetupOpt.SecretDir, "Directory where storage secret has been mounted")
+
+	cmd.Flags().BoolVar(&opt.Metrics.Enabled, "metrics-enabled", opt.Metrics.Enabled, "Specify whether to export Prometheus metrics")
+	cmd.Flags().StringVar(&opt.Metrics.PushgatewayURL, "pushgateway-url", opt.Metrics.PushgatewayURL, "Pushgateway URL where the metrics will be pushed")
+
+	return cmd
+}
@@ -65,9 +65,18 @@ func (c *StashController) applyBackupAnnotationLogicForAppBinding(ab *appCatalog
 	}
 
 	// if ab has backup annotations then ensure respective Repository and BackupConfiguration
+	var backupBlueprintName string
 	if meta_util.HasKey(ab.Annotations, api_v1beta1.KeyBackupBlueprint) {
-		// backup annotations found. so, we have to ensure Repository and BackupConfiguration from BackupBlueprint
-		verb, err := c.ensureAutoBackupResourcesForAppBinding(ab, targetRef, targetAppGroup, targetAppResource, prefix)
+		backupBlueprintName = ab.Annotations[api_v1beta1.KeyBackupBlueprint]
+	} else {
+		backupBlueprintName, err = c.selectBackupBlueprint(targetRef, ab.Labels, ab.Annotations)
+		if err != nil {
+			return c.handleAutoBackupResourcesCreationFailure(targetRef, err)
+		}
+	}
+	if backupBlueprintName != "" {
+		// backup annotations found or a BackupBlueprint selects it. so, we have to ensure Repository and BackupConfiguration from BackupBlueprint
+		verb, err := c.ensureAutoBackupResourcesForAppBinding(ab, targetRef, backupBlueprintName, targetAppGroup, targetAppResource, prefix)
 		if err != nil {
 			return c.handleAutoBackupResourcesCreationFailure(targetRef, err)
 		}
@@ -75,7 +84,7 @@ func (c *StashController) applyBackupAnnotationLogicForAppBinding(ab *appCatalog
 			return c.handleAutoBackupResourcesCreationSuccess(targetRef)
 		}
 	} else {
-		// app binding does not have backup annotations. it might be removed or was never added.
+		// app binding does not have backup annotations and no BackupBlueprint selects it. it might be removed or was never added.
 		// if respective BackupConfiguration exist then backup annotations has been removed.
 		// in this case, we have to remove the BackupConfiguration too.
 		// however, we will keep Repository crd as it is required for restore.
@@ -93,21 +102,20 @@ func (c *StashController) applyBackupAnnotationLogicForAppBinding(ab *appCatalog
 func (c *StashController) ensureAutoBackupResourcesForAppBinding(
 	ab *appCatalog.AppBinding,
 	targetRef *core.ObjectReference,
+	backupBlueprintName string,
 	targetAppGroup string,
 	targetAppResource string,
 	prefix string,
 ) (kutil.VerbType, error) {
 
-	backupBlueprintName, err := meta_util.GetStringValue(ab.Annotations, api_v1beta1.KeyBackupBlueprint)
-	if err != nil {
-		return kutil.VerbUnchanged, err
-	}
-
 	backupBlueprint, err := c.stashClient.StashV1beta1().BackupBlueprints().Get(backupBlueprintName, metav1.GetOptions{})
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
 
+	// take the hash before the variables are resolved
+	blueprintHash := backupBlueprint.GetSpecHash()
+
 	// resolve BackupBlueprint's variables
 	inputs := make(map[string]string)
 	inputs[apis.TargetAPIVersion] = ab.APIVersion
@@ -125,13 +133,13 @@ func (c *StashController) ensureAutoBackupResourcesForAppBinding(
 	}
 
 	// ensure Repository crd
-	verb1, err := c.ensureRepository(backupBlueprint, targetRef, prefix)
+	verb1, err := c.ensureRepository(backupBlueprint, blueprintHash, targetRef, prefix)
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
 
 	// ensure BackupConfiguration crd
-	verb2, err := c.ensureBackupConfiguration(backupBlueprint, nil, nil, targetRef, prefix)
+	verb2, err := c.ensureBackupConfiguration(backupBlueprint, blueprintHash, nil, nil, targetRef, prefix)
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
@@ -31,12 +31,21 @@ func (c *StashController) applyBackupAnnotationLogic(w *wapi.Workload) error {
 	if err != nil {
 		return fmt.Errorf("failed to create object reference of %s %s/%s. Reason: %v", w.Kind, w.Namespace, w.Namespace, err)
 	}
-	// if workload has backup annotations then ensure respective Repository and BackupConfiguration
+	// if workload has backup annotations or it has been selected by a BackupBlueprint then ensure respective Repository and BackupConfiguration
+	var backupBlueprintName string
 	if meta_util.HasKey(w.Annotations, api_v1beta1.KeyBackupBlueprint) &&
 		meta_util.HasKey(w.Annotations, api_v1beta1.KeyTargetPaths) &&
 		meta_util.HasKey(w.Annotations, api_v1beta1.KeyVolumeMounts) {
-		// backup annotations found. so, we have to ensure Repository and BackupConfiguration from BackupBlueprint
-		verb, err := c.ensureAutoBackupResourcesForWorkload(w, targetRef)
+		backupBlueprintName = w.Annotations[api_v1beta1.KeyBackupBlueprint]
+	} else {
+		backupBlueprintName, err = c.selectBackupBlueprint(targetRef, w.Labels, w.Annotations)
+		if err != nil {
+			return c.handleAutoBackupResourcesCreationFailure(targetRef, err)
+		}
+	}
+	if backupBlueprintName != "" {
+		// we have to ensure Repository and BackupConfiguration from BackupBlueprint
+		verb, err := c.ensureAutoBackupResourcesForWorkload(w, targetRef, backupBlueprintName)
 		if err != nil {
 			return c.handleAutoBackupResourcesCreationFailure(targetRef, err)
 		}
@@ -44,7 +53,7 @@ func (c *StashController) applyBackupAnnotationLogic(w *wapi.Workload) error {
 			return c.handleAutoBackupResourcesCreationSuccess(targetRef)
 		}
 	} else {
-		// workload does not have backup annotations. it might be removed or was never added.
+		// workload does not have backup annotations and no BackupBlueprint selects it. the annotations might be removed or was never added.
 		// if respective BackupConfiguration exist then backup annotations has been removed.
 		// in this case, we have to remove the BackupConfiguration too.
 		// however, we will keep Repository crd as it is required for restore.
@@ -75,6 +84,12 @@ func (c *StashController) applyBackupConfigurationLogic(w *wapi.Workload, caller
 	// in this case, we have to add/update sidecar container accordingly.
 	if newbc != nil && !util.BackupConfigurationEqual(oldbc, newbc) {
 		err := c.ensureBackupSidecar(w, newbc, caller)
+		// don't update the status of the BackupConfiguration when the caller is webhook to make the webhooks side effect free.
+		if caller != util.CallerWebhook {
+			if serr := c.setSidecarInjectedCondition(newbc, w, err); serr != nil {
+				log.Errorf("failed to update status of BackupConfiguration %s/%s. Reason: %v", newbc.Namespace, newbc.Name, serr)
+			}
+		}
 		// write sidecar injection failure/success event
 		ref, rerr := util.GetWorkloadReference(w)
 		if err != nil && rerr != nil {
@@ -102,10 +117,28 @@ func (c *StashController) applyBackupConfigurationLogic(w *wapi.Workload, caller
 			return true, nil
 		}
 		return true, c.handleSidecarDeletionSuccess(ref)
+	} else if newbc != nil && caller != util.CallerWebhook {
+		// the sidecar might have been injected by the webhook. so, make sure that the status reflects it.
+		if cond := newbc.Status.GetCondition(api_v1beta1.SidecarInjected); cond == nil || cond.Status != core.ConditionTrue {
+			return false, c.setSidecarInjectedCondition(newbc, w, nil)
+		}
 	}
 	return false, nil
 }
 
+func (c *StashController) setSidecarInjectedCondition(bc *api_v1beta1.BackupConfiguration, w *wapi.Workload, injectionErr error) error {
+	return c.updateBackupConfigurationStatus(bc, func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus {
+		if injectionErr != nil {
+			in.SetCondition(api_v1beta1.SidecarInjected, core.ConditionFalse, "SidecarInjectionFailed",
+				fmt.Sprintf("failed to inject sidecar into %s %s/%s. Reason: %v", w.Kind, w.Namespace, w.Name, injectionErr))
+		} else {
+			in.SetCondition(api_v1beta1.SidecarInjected, core.ConditionTrue, "SidecarInjected",
+				fmt.Sprintf("sidecar has been injected into %s %s/%s", w.Kind, w.Namespace, w.Name))
+		}
+		return in
+	})
+}
+
 func (c *StashController) applyResticLogic(w *wapi.Workload, caller string) (bool, error) {
 	// detect old Restic from annotations if it does exist
 	oldRestic, err := util.GetAppliedRestic(w.Annotations)
@@ -143,40 +176,55 @@ func (c *StashController) applyResticLogic(w *wapi.Workload, caller string) (boo
 	return false, nil
 }
 
-// ensureAutoBackupResources creates(if does not exist) BackupConfiguration and Repository object for the respective workload
-func (c *StashController) ensureAutoBackupResourcesForWorkload(w *wapi.Workload, targetRef *core.ObjectReference) (kutil.VerbType, error) {
-	backupBlueprintName, err := meta_util.GetStringValue(w.Annotations, api_v1beta1.KeyBackupBlueprint)
-	if err != nil {
-		return kutil.VerbUnchanged, err
-	}
-	paths, err := meta_util.GetStringValue(w.Annotations, api_v1beta1.KeyTargetPaths)
+// ensureAutoBackupResources creates(if does not exist) BackupConfiguration and Repository object for the respective workload.
+// The target-paths and volume-mounts annotations of the workload take precedence over the selector of the BackupBlueprint.
+func (c *StashController) ensureAutoBackupResourcesForWorkload(w *wapi.Workload, targetRef *core.ObjectReference, backupBlueprintName string) (kutil.VerbType, error) {
+	// read respective BackupBlueprint crd
+	backupBlueprint, err := c.stashClient.StashV1beta1().BackupBlueprints().Get(backupBlueprintName, metav1.GetOptions{})
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
 
-	v, err := meta_util.GetStringValue(w.Annotations, api_v1beta1.KeyVolumeMounts)
-	if err != nil {
-		return kutil.VerbUnchanged, err
+	var paths []string
+	var volumeMounts []core.VolumeMount
+	if backupBlueprint.Spec.Selector != nil {
+		paths = backupBlueprint.Spec.Selector.Paths
+		volumeMounts = backupBlueprint.Spec.Selector.VolumeMounts
 	}
-	// extract volume and mount information from volumeMount annotation
-	mounts := strings.Split(v, ",")
-	volumeMounts := []core.VolumeMount{}
-	for _, m := range mounts {
-		vol := strings.Split(m, ":")
-		if len(vol) == 3 {
-			volumeMounts = append(volumeMounts, core.VolumeMount{Name: vol[0], MountPath: vol[1], SubPath: vol[2]})
-		} else if len(vol) == 2 {
-			volumeMounts = append(volumeMounts, core.VolumeMount{Name: vol[0], MountPath: vol[1]})
-		} else {
-			return kutil.VerbUnchanged, fmt.Errorf("invalid volume-mounts annotations. use either 'volName:mountPath' or 'volName:mountPath:subPath' format")
+	if meta_util.HasKey(w.Annotations, api_v1beta1.KeyTargetPaths) {
+		v, err := meta_util.GetStringValue(w.Annotations, api_v1beta1.KeyTargetPaths)
+		if err != nil {
+			return kutil.VerbUnchanged, err
 		}
+		paths = strings.Split(v, ",")
 	}
-	// read respective BackupBlueprint crd
-	backupBlueprint, err := c.stashClient.StashV1beta1().BackupBlueprints().Get(backupBlueprintName, metav1.GetOptions{})
-	if err != nil {
-		return kutil.VerbUnchanged, err
+	if meta_util.HasKey(w.Annotations, api_v1beta1.KeyVolumeMounts) {
+		v, err := meta_util.GetStringValue(w.Annotations, api_v1beta1.KeyVolumeMounts)
+		if err != nil {
+			return kutil.VerbUnchanged, err
+		}
+		// extract volume and mount information from volumeMount annotation
+		mounts := strings.Split(v, ",")
+		volumeMounts = []core.VolumeMount{}
+		for _, m := range mounts {
+			vol := strings.Split(m, ":")
+			if len(vol) == 3 {
+				volumeMounts = append(volumeMounts, core.VolumeMount{Name: vol[0], MountPath: vol[1], SubPath: vol[2]})
+			} else if len(vol) == 2 {
+				volumeMounts = append(volumeMounts, core.VolumeMount{Name: vol[0], MountPath: vol[1]})
+			} else {
+				return kutil.VerbUnchanged, fmt.Errorf("invalid volume-mounts annotations. use either 'volName:mountPath' or 'volName:mountPath:subPath' format")
+			}
+		}
+	}
+	if len(paths) == 0 || len(volumeMounts) == 0 {
+		return kutil.VerbUnchanged, fmt.Errorf("target paths or volume mounts are specified neither by the annotations of the workload nor by the selector of BackupBlueprint %s", backupBlueprint.Name)
 	}
 
+	// hash of the BackupBlueprint before resolving its variables.
+	// it is used to find the generated resources that are not in sync with the BackupBlueprint.
+	blueprintHash := backupBlueprint.GetSpecHash()
+
 	// resolve BackupBlueprint's variables
 	inputs := make(map[string]string)
 	inputs[apis.TargetAPIVersion] = w.APIVersion
@@ -196,13 +244,13 @@ func (c *StashController) ensureAutoBackupResourcesForWorkload(w *wapi.Workload,
 	}
 
 	// ensure Repository crd
-	verb1, err := c.ensureRepository(backupBlueprint, targetRef, targetRef.Kind)
+	verb1, err := c.ensureRepository(backupBlueprint, blueprintHash, targetRef, targetRef.Kind)
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
 
 	// ensure BackupConfiguration crd
-	verb2, err := c.ensureBackupConfiguration(backupBlueprint, strings.Split(paths, ","), volumeMounts, targetRef, targetRef.Kind)
+	verb2, err := c.ensureBackupConfiguration(backupBlueprint, blueprintHash, paths, volumeMounts, targetRef, targetRef.Kind)
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
@@ -234,13 +282,16 @@ func (c *StashController) ensureAutoBackupResourcesDeleted(targetRef *core.Objec
 	return kutil.VerbDeleted, nil
 }
 
-func (c *StashController) ensureRepository(backupBlueprint *api_v1beta1.BackupBlueprint, target *core.ObjectReference, prefix string) (kutil.VerbType, error) {
+func (c *StashController) ensureRepository(backupBlueprint *api_v1beta1.BackupBlueprint, blueprintHash string, target *core.ObjectReference, prefix string) (kutil.VerbType, error) {
 	meta := metav1.ObjectMeta{
 		Name:      getRepositoryName(target, prefix),
 		Namespace: target.Namespace,
 	}
 	_, verb, err := v1alpha1_util.CreateOrPatchRepository(c.stashClient.StashV1alpha1(), meta, func(in *api_v1alpha1.Repository) *api_v1alpha1.Repository {
+		setBackupBlueprintReference(&in.ObjectMeta, backupBlueprint, blueprintHash)
 		in.Spec.Backend = backupBlueprint.Spec.Backend
+		in.Spec.Maintenance = backupBlueprint.Spec.Maintenance
+		in.Spec.CopyTo = backupBlueprint.Spec.CopyTo
 		return in
 	})
 	if err != nil {
@@ -249,7 +300,7 @@ func (c *StashController) ensureRepository(backupBlueprint *api_v1beta1.BackupBl
 	return verb, nil
 }
 
-func (c *StashController) ensureBackupConfiguration(backupBlueprint *api_v1beta1.BackupBlueprint, paths []string, volumeMounts []core.VolumeMount, target *core.ObjectReference, prefix string) (kutil.VerbType, error) {
+func (c *StashController) ensureBackupConfiguration(backupBlueprint *api_v1beta1.BackupBlueprint, blueprintHash string, paths []string, volumeMounts []core.VolumeMount, target *core.ObjectReference, prefix string) (kutil.VerbType, error) {
 	meta := metav1.ObjectMeta{
 		Name:      getBackupConfigurationName(target, prefix),
 		Namespace: target.Namespace,
@@ -257,6 +308,7 @@ func (c *StashController) ensureBackupConfiguration(backupBlueprint *api_v1beta1
 	_, verb, err := v1beta1_util.CreateOrPatchBackupConfiguration(c.stashClient.StashV1beta1(), meta, func(in *api_v1beta1.BackupConfiguration) *api_v1beta1.BackupConfiguration {
 		// set workload as owner of this backupConfiguration object
 		core_util.EnsureOwnerReference(&in.ObjectMeta, target)
+		setBackupBlueprintReference(&in.ObjectMeta, backupBlueprint, blueprintHash)
 		in.Spec.Repository.Name = getRepositoryName(target, prefix)
 		in.Spec.Target = &api_v1beta1.BackupTarget{
 			Ref: api_v1beta1.TargetRef{
@@ -271,6 +323,8 @@ func (c *StashController) ensureBackupConfiguration(backupBlueprint *api_v1beta1
 		in.Spec.Schedule = backupBlueprint.Spec.Schedule
 		in.Spec.Task = backupBlueprint.Spec.Task
 		in.Spec.RetentionPolicy = backupBlueprint.Spec.RetentionPolicy
+		in.Spec.Tags = backupBlueprint.Spec.Tags
+		in.Spec.BandwidthLimit = backupBlueprint.Spec.BandwidthLimit
 		in.Spec.RuntimeSettings = backupBlueprint.Spec.RuntimeSettings
 		in.Spec.TempDir = backupBlueprint.Spec.TempDir
 		return in
@@ -0,0 +1,382 @@
+package controller
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/golang/glog"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/cache"
+	core_util "kmodules.xyz/client-go/core/v1"
+	meta_util "kmodules.xyz/client-go/meta"
+	"kmodules.xyz/client-go/tools/queue"
+	"kmodules.xyz/webhook-runtime/admission"
+	hooks "kmodules.xyz/webhook-runtime/admission/v1beta1"
+	webhook "kmodules.xyz/webhook-runtime/admission/v1beta1/generic"
+	"stash.appscode.dev/stash/apis"
+	"stash.appscode.dev/stash/apis/stash"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	v1beta1_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
+	"stash.appscode.dev/stash/pkg/resolve"
+)
+
+func (c *StashController) NewBackupBlueprintWebhook() hooks.AdmissionHook {
+	return webhook.NewGenericWebhook(
+		schema.GroupVersionResource{
+			Group:    "admission.stash.appscode.com",
+			Version:  "v1beta1",
+			Resource: "backupblueprintvalidators",
+		},
+		"backupblueprintvalidator",
+		[]string{stash.GroupName},
+		api_v1beta1.SchemeGroupVersion.WithKind(api_v1beta1.ResourceKindBackupBlueprint),
+		nil,
+		&admission.ResourceHandlerFuncs{
+			CreateFunc: func(obj runtime.Object) (runtime.Object, error) {
+				return nil, c.validateBackupBlueprint(obj.(*api_v1beta1.BackupBlueprint))
+			},
+			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				oldBB := oldObj.(*api_v1beta1.BackupBlueprint)
+				newBB := newObj.(*api_v1beta1.BackupBlueprint)
+				// don't block status updates because of a Task that has gone missing
+				if reflect.DeepEqual(oldBB.Spec, newBB.Spec) {
+					return nil, nil
+				}
+				return nil, c.validateBackupBlueprint(newBB)
+			},
+		},
+	)
+}
+
+// validateBackupBlueprint ensures that the Task referred by the BackupBlueprint exists and is valid.
+func (c *StashController) validateBackupBlueprint(backupBlueprint *api_v1beta1.BackupBlueprint) error {
+	if err := backupBlueprint.IsValid(); err != nil {
+		return err
+	}
+	if backupBlueprint.Spec.Task.Name == "" {
+		return nil
+	}
+	task, err := c.stashClient.StashV1beta1().Tasks().Get(backupBlueprint.Spec.Task.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("can't get Task %s for BackupBlueprint %s, reason: %s", backupBlueprint.Spec.Task.Name, backupBlueprint.Name, err)
+	}
+	return resolve.ValidateTask(c.stashClient, task)
+}
+
+func (c *StashController) initBackupBlueprintWatcher() {
+	c.bbInformer = c.stashInformerFactory.Stash().V1beta1().BackupBlueprints().Informer()
+	c.bbQueue = queue.New(api_v1beta1.ResourceKindBackupBlueprint, c.MaxNumRequeues, c.NumThreads, c.runBackupBlueprintProcessor)
+	// status updates of the BackupBlueprint don't need to be processed
+	c.bbInformer.AddEventHandler(queue.NewEventHandler(c.bbQueue.GetQueue(), func(oldObj, newObj interface{}) bool {
+		return oldObj.(*api_v1beta1.BackupBlueprint).GetSpecHash() != newObj.(*api_v1beta1.BackupBlueprint).GetSpecHash()
+	}))
+	c.bbLister = c.stashInformerFactory.Stash().V1beta1().BackupBlueprints().Lister()
+
+	// recount the targets of the BackupBlueprint when any of its generated resources changes
+	blueprintOfGeneratedResource := &cache.ResourceEventHandlerFuncs{
+		AddFunc: c.enqueueBackupBlueprintOf,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueBackupBlueprintOf(newObj)
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			c.enqueueBackupBlueprintOf(obj)
+		},
+	}
+	c.bcInformer.AddEventHandler(blueprintOfGeneratedResource)
+	c.repoInformer.AddEventHandler(blueprintOfGeneratedResource)
+}
+
+func (c *StashController) enqueueBackupBlueprintOf(obj interface{}) {
+	o, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+	// the label holds the full name only for the BackupBlueprints whose name is a valid label value
+	name := o.GetAnnotations()[api_v1beta1.KeyBackupBlueprint]
+	if name == "" {
+		name = o.GetLabels()[api_v1beta1.KeyBackupBlueprint]
+	}
+	if name != "" {
+		c.bbQueue.GetQueue().Add(name)
+	}
+}
+
+// runBackupBlueprintProcessor triggers the targets whose Repository or BackupConfiguration has been generated from
+// an older spec of the BackupBlueprint so that they are generated again. Then, it updates the number of the targets
+// that are in sync with the BackupBlueprint in its status.
+func (c *StashController) runBackupBlueprintProcessor(key string) error {
+	obj, exists, err := c.bbInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		glog.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+	if !exists {
+		glog.Warningf("BackupBlueprint %s does not exist anymore\n", key)
+		// the targets remove the resources generated from the BackupBlueprint when they are not selected anymore
+		return c.enqueueBackupBlueprintTargets(key)
+	}
+
+	backupBlueprint := obj.(*api_v1beta1.BackupBlueprint).DeepCopy()
+	glog.Infof("Sync/Add/Update for BackupBlueprint %s", backupBlueprint.Name)
+
+	specHash := backupBlueprint.GetSpecHash()
+	backupConfigs, err := c.bcLister.List(labels.SelectorFromSet(map[string]string{
+		api_v1beta1.KeyBackupBlueprint: backupBlueprintLabelValue(backupBlueprint.Name),
+	}))
+	if err != nil {
+		return err
+	}
+
+	// the targets that are newly selected by the BackupBlueprint don't have any generated resource yet.
+	// so, check the selector again whenever the spec changes.
+	if backupBlueprint.Spec.Selector != nil && backupBlueprint.Status.SpecHash != specHash {
+		selector := backupBlueprint.Spec.Selector
+		err = c.enqueueAutoBackupTargets(selector.TargetKinds(), "", func(kind string, target metav1.Object) bool {
+			selected, err := selector.Selects(kind, target.GetNamespace(), c.getNamespaceLabels(target.GetNamespace()), target.GetLabels())
+			return err == nil && selected
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	totalTargets, inSyncTargets := c.syncBackupBlueprintTargets(backupBlueprint.Name, backupConfigs, specHash)
+
+	status := backupBlueprint.Status
+	if status.ObservedGeneration == backupBlueprint.Generation &&
+		status.SpecHash == specHash &&
+		status.TotalTargets == totalTargets &&
+		status.InSyncTargets == inSyncTargets {
+		return nil
+	}
+	_, err = v1beta1_util.UpdateBackupBlueprintStatus(
+		c.stashClient.StashV1beta1(),
+		backupBlueprint,
+		func(in *api_v1beta1.BackupBlueprintStatus) *api_v1beta1.BackupBlueprintStatus {
+			syncTime := metav1.Now()
+			in.ObservedGeneration = backupBlueprint.Generation
+			in.SpecHash = specHash
+			in.TotalTargets = totalTargets
+			in.InSyncTargets = inSyncTargets
+			in.LastSyncTime = &syncTime
+			return in
+		},
+		apis.EnableStatusSubresource,
+	)
+	return err
+}
+
+// syncBackupBlueprintTargets counts the targets of the BackupConfigurations generated from the BackupBlueprint and the
+// ones that are in sync with its current spec. The targets that are not in sync are added to the queue of their kind.
+func (c *StashController) syncBackupBlueprintTargets(name string, backupConfigs []*api_v1beta1.BackupConfiguration, specHash string) (totalTargets, inSyncTargets int32) {
+	for _, bc := range backupConfigs {
+		if bc.DeletionTimestamp != nil {
+			continue
+		}
+		totalTargets++
+		if c.isInSyncWithBackupBlueprint(bc, specHash) {
+			inSyncTargets++
+			continue
+		}
+		// the target generates its Repository and BackupConfiguration again from the current BackupBlueprint
+		if err := c.enqueueBackupBlueprintTarget(bc); err != nil {
+			glog.Errorf("failed to resync BackupConfiguration %s/%s with BackupBlueprint %s. Reason: %v", bc.Namespace, bc.Name, name, err)
+		}
+	}
+	return totalTargets, inSyncTargets
+}
+
+// isInSyncWithBackupBlueprint returns true if both the BackupConfiguration and its Repository
+// have been generated from the current spec of the BackupBlueprint.
+func (c *StashController) isInSyncWithBackupBlueprint(bc *api_v1beta1.BackupConfiguration, specHash string) bool {
+	if bc.Annotations[api_v1beta1.AppliedBackupBlueprintSpecHash] != specHash {
+		return false
+	}
+	repository, err := c.repoLister.Repositories(bc.Namespace).Get(bc.Spec.Repository.Name)
+	if err != nil {
+		return false
+	}
+	return repository.Annotations[api_v1beta1.AppliedBackupBlueprintSpecHash] == specHash
+}
+
+// enqueueBackupBlueprintTargets adds the targets of the BackupConfigurations generated from the BackupBlueprint to the queue of their kind
+func (c *StashController) enqueueBackupBlueprintTargets(name string) error {
+	backupConfigs, err := c.bcLister.List(labels.SelectorFromSet(map[string]string{
+		api_v1beta1.KeyBackupBlueprint: backupBlueprintLabelValue(name),
+	}))
+	if err != nil {
+		return err
+	}
+	for _, bc := range backupConfigs {
+		if err := c.enqueueBackupBlueprintTarget(bc); err != nil {
+			glog.Errorf("failed to enqueue the target of BackupConfiguration %s/%s of BackupBlueprint %s. Reason: %v", bc.Namespace, bc.Name, name, err)
+		}
+	}
+	return nil
+}
+
+// enqueueBackupBlueprintTarget adds the target of a BackupConfiguration generated from a BackupBlueprint
+// to the queue of its kind. The target then resolves the BackupBlueprint again and patches the generated resources.
+func (c *StashController) enqueueBackupBlueprintTarget(bc *api_v1beta1.BackupConfiguration) error {
+	if bc.Spec.Target == nil {
+		return fmt.Errorf("BackupConfiguration %s/%s does not have any target", bc.Namespace, bc.Name)
+	}
+	worker, _, err := c.workerForTargetKind(bc.Spec.Target.Ref.Kind)
+	if err != nil {
+		return err
+	}
+	worker.GetQueue().Add(fmt.Sprintf("%s/%s", bc.Namespace, bc.Spec.Target.Ref.Name))
+	return nil
+}
+
+// enqueueAutoBackupTargets adds the targets of the given kinds in the namespace that pass the filter to the queue
+// of their kind. The targets of every namespace are checked if the namespace is empty.
+func (c *StashController) enqueueAutoBackupTargets(kinds []string, namespace string, filter func(kind string, target metav1.Object) bool) error {
+	for _, kind := range kinds {
+		worker, informer, err := c.workerForTargetKind(kind)
+		if err != nil {
+			glog.Warningf("skipping %s targets. Reason: %v", kind, err)
+			continue
+		}
+		var objects []interface{}
+		if namespace == "" {
+			objects = informer.GetStore().List()
+		} else if objects, err = informer.GetIndexer().ByIndex(cache.NamespaceIndex, namespace); err != nil {
+			return err
+		}
+		for _, obj := range objects {
+			target, ok := obj.(metav1.Object)
+			if ok && filter(kind, target) {
+				queue.Enqueue(worker.GetQueue(), obj)
+			}
+		}
+	}
+	return nil
+}
+
+// enqueueTargetsOfNamespace adds the targets of the namespace that may be selected or unselected by the namespace selector
+// of a BackupBlueprint to their queues. It is called when the namespace is added or its labels change.
+func (c *StashController) enqueueTargetsOfNamespace(namespace string) error {
+	backupBlueprints, err := c.bbLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	for _, backupBlueprint := range backupBlueprints {
+		selector := backupBlueprint.Spec.Selector
+		if selector == nil || selector.NamespaceSelector == nil {
+			continue
+		}
+		targetSelector, err := metav1.LabelSelectorAsSelector(selector.LabelSelector)
+		if err != nil {
+			glog.Errorf("invalid label selector of BackupBlueprint %s. Reason: %v", backupBlueprint.Name, err)
+			continue
+		}
+		err = c.enqueueAutoBackupTargets(selector.TargetKinds(), namespace, func(kind string, target metav1.Object) bool {
+			return targetSelector.Matches(labels.Set(target.GetLabels()))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *StashController) workerForTargetKind(kind string) (*queue.Worker, cache.SharedIndexInformer, error) {
+	switch kind {
+	case apis.KindDeployment:
+		return c.dpQueue, c.dpInformer, nil
+	case apis.KindDaemonSet:
+		return c.dsQueue, c.dsInformer, nil
+	case apis.KindStatefulSet:
+		return c.ssQueue, c.ssInformer, nil
+	case apis.KindReplicationController:
+		return c.rcQueue, c.rcInformer, nil
+	case apis.KindReplicaSet:
+		return c.rsQueue, c.rsInformer, nil
+	case apis.KindDeploymentConfig:
+		if c.dcInformer == nil {
+			return nil, nil, fmt.Errorf("DeploymentConfig is not supported by the cluster")
+		}
+		return c.dcQueue, c.dcInformer, nil
+	case apis.KindPersistentVolumeClaim:
+		return c.pvcQueue, c.pvcInformer, nil
+	case apis.KindAppBinding:
+		return c.abQueue, c.abInformer, nil
+	}
+	return nil, nil, fmt.Errorf("unknown target kind %s", kind)
+}
+
+// selectBackupBlueprint returns the name of the BackupBlueprint whose selector selects the target. If more than one
+// BackupBlueprint selects the target, the first one in the order of their names is used. It returns an empty string if
+// no BackupBlueprint selects the target or the target has opted out of auto backup.
+func (c *StashController) selectBackupBlueprint(target *core.ObjectReference, targetLabels, targetAnnotations map[string]string) (string, error) {
+	if excluded, _ := meta_util.GetBoolValue(targetAnnotations, api_v1beta1.KeyExcludeFromAutoBackup); excluded {
+		return "", nil
+	}
+	backupBlueprints, err := c.bbLister.List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(backupBlueprints, func(i, j int) bool {
+		return backupBlueprints[i].Name < backupBlueprints[j].Name
+	})
+
+	namespaceLabels := c.getNamespaceLabels(target.Namespace)
+	for _, backupBlueprint := range backupBlueprints {
+		if backupBlueprint.Spec.Selector == nil {
+			continue
+		}
+		selected, err := backupBlueprint.Spec.Selector.Selects(target.Kind, target.Namespace, namespaceLabels, targetLabels)
+		if err != nil {
+			// invalid selector of a BackupBlueprint should not stop the other BackupBlueprints from selecting the target
+			glog.Errorf("failed to match selector of BackupBlueprint %s with %s %s/%s. Reason: %v", backupBlueprint.Name, target.Kind, target.Namespace, target.Name, err)
+			continue
+		}
+		if selected {
+			return backupBlueprint.Name, nil
+		}
+	}
+	return "", nil
+}
+
+func (c *StashController) getNamespaceLabels(namespace string) map[string]string {
+	obj, exists, err := c.nsInformer.GetIndexer().GetByKey(namespace)
+	if err != nil || !exists {
+		return nil
+	}
+	return obj.(*core.Namespace).Labels
+}
+
+// setBackupBlueprintReference records the name of the BackupBlueprint and the hash of the spec a resource has been
+// generated from. The resource is also labeled with the name of the BackupBlueprint so that it can be listed by it.
+func setBackupBlueprintReference(meta *metav1.ObjectMeta, backupBlueprint *api_v1beta1.BackupBlueprint, specHash string) {
+	meta.Labels = core_util.UpsertMap(meta.Labels, map[string]string{
+		api_v1beta1.KeyBackupBlueprint: backupBlueprintLabelValue(backupBlueprint.Name),
+	})
+	meta.Annotations = core_util.UpsertMap(meta.Annotations, map[string]string{
+		api_v1beta1.KeyBackupBlueprint:             backupBlueprint.Name,
+		api_v1beta1.AppliedBackupBlueprintSpecHash: specHash,
+	})
+}
+
+// backupBlueprintLabelValue returns the name of the BackupBlueprint if it fits in a label value. Otherwise, it returns
+// a prefix of the name followed by the hash of the full name.
+func backupBlueprintLabelValue(name string) string {
+	if len(name) <= validation.LabelValueMaxLength {
+		return name
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+	return fmt.Sprintf("%s-%s", name[:40], strconv.FormatUint(hash.Sum64(), 10))
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+	"stash.appscode.dev/stash/apis"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_listers "stash.appscode.dev/stash/client/listers/stash/v1alpha1"
+	v1beta1_listers "stash.appscode.dev/stash/client/listers/stash/v1beta1"
+)
+
+func newGeneratedRepository(name, specHash string) *api_v1alpha1.Repository {
+	return &api_v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "demo",
+			Annotations: map[string]string{api_v1beta1.AppliedBackupBlueprintSpecHash: specHash},
+		},
+	}
+}
+
+func newGeneratedBackupConfiguration(name, kind, repository, specHash string) *api_v1beta1.BackupConfiguration {
+	return &api_v1beta1.BackupConfiguration{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "demo",
+			Annotations: map[string]string{api_v1beta1.AppliedBackupBlueprintSpecHash: specHash},
+		},
+		Spec: api_v1beta1.BackupConfigurationSpec{
+			Repository: core.LocalObjectReference{Name: repository},
+			Target: &api_v1beta1.BackupTarget{
+				Ref: api_v1beta1.TargetRef{Kind: kind, Name: name},
+			},
+		},
+	}
+}
+
+func TestSyncBackupBlueprintTargets(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, repository := range []*api_v1alpha1.Repository{
+		newGeneratedRepository("synced", "new"),
+		newGeneratedRepository("stale-bc", "new"),
+		newGeneratedRepository("stale-repo", "old"),
+	} {
+		if err := indexer.Add(repository); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &StashController{
+		repoLister: stash_listers.NewRepositoryLister(indexer),
+		dpQueue:    queue.New(apis.KindDeployment, 0, 1, nil),
+		pvcQueue:   queue.New(apis.KindPersistentVolumeClaim, 0, 1, nil),
+	}
+	defer c.dpQueue.GetQueue().ShutDown()
+	defer c.pvcQueue.GetQueue().ShutDown()
+
+	deleted := newGeneratedBackupConfiguration("deleted", apis.KindDeployment, "synced", "old")
+	deleted.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	backupConfigs := []*api_v1beta1.BackupConfiguration{
+		newGeneratedBackupConfiguration("synced", apis.KindDeployment, "synced", "new"),
+		newGeneratedBackupConfiguration("stale-bc", apis.KindDeployment, "stale-bc", "old"),
+		newGeneratedBackupConfiguration("stale-repo", apis.KindPersistentVolumeClaim, "stale-repo", "new"),
+		newGeneratedBackupConfiguration("missing-repo", apis.KindPersistentVolumeClaim, "missing", "new"),
+		newGeneratedBackupConfiguration("unknown-kind", "Unknown", "synced", "old"),
+		deleted,
+	}
+
+	total, inSync := c.syncBackupBlueprintTargets("blueprint", backupConfigs, "new")
+	if total != 5 || inSync != 1 {
+		t.Errorf("expected 1 of 5 targets in sync, found %d of %d", inSync, total)
+	}
+
+	// only the targets that are out of sync are requeued
+	expected := map[*queue.Worker][]string{
+		c.dpQueue:  {"demo/stale-bc"},
+		c.pvcQueue: {"demo/stale-repo", "demo/missing-repo"},
+	}
+	for worker, keys := range expected {
+		var found []string
+		for worker.GetQueue().Len() > 0 {
+			key, _ := worker.GetQueue().Get()
+			found = append(found, key.(string))
+			worker.GetQueue().Done(key)
+		}
+		if strings.Join(found, ",") != strings.Join(keys, ",") {
+			t.Errorf("expected %v to be requeued, found %v", keys, found)
+		}
+	}
+}
+
+func TestBackupBlueprintLabelValue(t *testing.T) {
+	short := "mysql-blueprint"
+	if got := backupBlueprintLabelValue(short); got != short {
+		t.Errorf("expected %s, found %s", short, got)
+	}
+
+	long := strings.Repeat("blueprint-", 10)
+	got := backupBlueprintLabelValue(long)
+	if errs := validation.IsValidLabelValue(got); len(errs) != 0 {
+		t.Errorf("%s is not a valid label value: %v", got, errs)
+	}
+	if got != backupBlueprintLabelValue(long) {
+		t.Errorf("label value of %s is not stable", long)
+	}
+	if other := backupBlueprintLabelValue(long + "x"); other == got {
+		t.Errorf("expected different label values for different names, found %s", got)
+	}
+}
+
+func newSelectorBackupBlueprint(name string, selector *api_v1beta1.BackupBlueprintSelector) *api_v1beta1.BackupBlueprint {
+	return &api_v1beta1.BackupBlueprint{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       api_v1beta1.BackupBlueprintSpec{Selector: selector},
+	}
+}
+
+func TestSelectBackupBlueprint(t *testing.T) {
+	bbIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, backupBlueprint := range []*api_v1beta1.BackupBlueprint{
+		newSelectorBackupBlueprint("annotation-only", nil),
+		newSelectorBackupBlueprint("z-database", &api_v1beta1.BackupBlueprintSelector{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		}),
+		newSelectorBackupBlueprint("b-database", &api_v1beta1.BackupBlueprintSelector{
+			Namespaces:    []string{"demo"},
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		}),
+		newSelectorBackupBlueprint("a-invalid", &api_v1beta1.BackupBlueprintSelector{
+			LabelSelector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "Unknown"}},
+			},
+		}),
+		newSelectorBackupBlueprint("volume", &api_v1beta1.BackupBlueprintSelector{
+			Kinds:             []string{apis.KindPersistentVolumeClaim},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"backup": "enabled"}},
+			LabelSelector:     &metav1.LabelSelector{},
+		}),
+	} {
+		if err := bbIndexer.Add(backupBlueprint); err != nil {
+			t.Fatal(err)
+		}
+	}
+	nsInformer := cache.NewSharedIndexInformer(nil, &core.Namespace{}, 0, cache.Indexers{})
+	for _, namespace := range []*core.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "demo", Labels: map[string]string{"backup": "enabled"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+	} {
+		if err := nsInformer.GetIndexer().Add(namespace); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c := &StashController{
+		bbLister:   v1beta1_listers.NewBackupBlueprintLister(bbIndexer),
+		nsInformer: nsInformer,
+	}
+
+	testCases := []struct {
+		name        string
+		kind        string
+		namespace   string
+		labels      map[string]string
+		annotations map[string]string
+		want        string
+	}{
+		{
+			name:      "the first BackupBlueprint by name is used",
+			kind:      apis.KindDeployment,
+			namespace: "demo",
+			labels:    map[string]string{"app": "db"},
+			want:      "b-database",
+		},
+		{
+			name:      "BackupBlueprints of other namespaces are skipped",
+			kind:      apis.KindStatefulSet,
+			namespace: "prod",
+			labels:    map[string]string{"app": "db"},
+			want:      "z-database",
+		},
+		{
+			name:      "namespace selector selects the PersistentVolumeClaims",
+			kind:      apis.KindPersistentVolumeClaim,
+			namespace: "demo",
+			want:      "volume",
+		},
+		{
+			name:      "no BackupBlueprint selects the target",
+			kind:      apis.KindPersistentVolumeClaim,
+			namespace: "prod",
+			want:      "",
+		},
+		{
+			name:        "target has opted out of auto backup",
+			kind:        apis.KindDeployment,
+			namespace:   "demo",
+			labels:      map[string]string{"app": "db"},
+			annotations: map[string]string{api_v1beta1.KeyExcludeFromAutoBackup: "true"},
+			want:        "",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target := &core.ObjectReference{Kind: tc.kind, Namespace: tc.namespace, Name: "target"}
+			got, err := c.selectBackupBlueprint(target, tc.labels, tc.annotations)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("expected BackupBlueprint %q, found %q", tc.want, got)
+			}
+		})
+	}
+}
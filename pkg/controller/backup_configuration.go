@@ -2,32 +2,121 @@ package controller
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/appscode/go/log"
 	"github.com/golang/glog"
+	"github.com/robfig/cron/v3"
 	batch_v1beta1 "k8s.io/api/batch/v1beta1"
 	core "k8s.io/api/core/v1"
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/reference"
 	batch_util "kmodules.xyz/client-go/batch/v1beta1"
 	core_util "kmodules.xyz/client-go/core/v1"
 	"kmodules.xyz/client-go/tools/queue"
+	"kmodules.xyz/webhook-runtime/admission"
+	hooks "kmodules.xyz/webhook-runtime/admission/v1beta1"
+	webhook "kmodules.xyz/webhook-runtime/admission/v1beta1/generic"
 	workload_api "kmodules.xyz/webhook-runtime/apis/workload/v1"
 	"stash.appscode.dev/stash/apis"
+	"stash.appscode.dev/stash/apis/stash"
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
 	v1beta1_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
 	"stash.appscode.dev/stash/pkg/docker"
 	"stash.appscode.dev/stash/pkg/eventer"
 	stash_rbac "stash.appscode.dev/stash/pkg/rbac"
+	"stash.appscode.dev/stash/pkg/resolve"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
 // TODO: Add validator that will reject to create BackupConfiguration if any Restic exist for target workload
 
+func (c *StashController) NewBackupConfigurationWebhook() hooks.AdmissionHook {
+	return webhook.NewGenericWebhook(
+		schema.GroupVersionResource{
+			Group:    "admission.stash.appscode.com",
+			Version:  "v1beta1",
+			Resource: "backupconfigurationvalidators",
+		},
+		"backupconfigurationvalidator",
+		[]string{stash.GroupName},
+		api_v1beta1.SchemeGroupVersion.WithKind(api_v1beta1.ResourceKindBackupConfiguration),
+		nil,
+		&admission.ResourceHandlerFuncs{
+			CreateFunc: func(obj runtime.Object) (runtime.Object, error) {
+				return nil, c.validateBackupConfiguration(obj.(*api_v1beta1.BackupConfiguration))
+			},
+			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				oldBC := oldObj.(*api_v1beta1.BackupConfiguration)
+				newBC := newObj.(*api_v1beta1.BackupConfiguration)
+				// don't block status, finalizer or deletion updates because of a reference that has gone missing
+				if newBC.DeletionTimestamp != nil || reflect.DeepEqual(oldBC.Spec, newBC.Spec) {
+					return nil, nil
+				}
+				return nil, c.validateBackupConfiguration(newBC)
+			},
+		},
+	)
+}
+
+// validateBackupConfiguration ensures that the Task referred by the BackupConfiguration exists
+// and that the Task can be resolved with the inputs of the BackupConfiguration once its Repository exists.
+func (c *StashController) validateBackupConfiguration(backupConfig *api_v1beta1.BackupConfiguration) error {
+	if err := backupConfig.IsValid(); err != nil {
+		return err
+	}
+	// VolumeSnapshotter driver does not use any Repository or Task
+	if backupConfig.Spec.Driver == api_v1beta1.VolumeSnapshotter {
+		return nil
+	}
+
+	repository, err := c.stashClient.StashV1alpha1().Repositories(backupConfig.Namespace).Get(backupConfig.Spec.Repository.Name, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		// the Repository may be created after the BackupConfiguration (i.e. both are applied from one manifest).
+		// RepositoryReady condition of the BackupConfiguration reports it. The Task can't be resolved without
+		// the Repository. So, only ensure that the Task exists.
+		log.Warningf("Repository %s/%s for BackupConfiguration %s does not exist yet", backupConfig.Namespace, backupConfig.Spec.Repository.Name, backupConfig.Name)
+		if backupConfig.Spec.Task.Name == "" {
+			return nil
+		}
+		if _, err = c.stashClient.StashV1beta1().Tasks().Get(backupConfig.Spec.Task.Name, metav1.GetOptions{}); err != nil {
+			return fmt.Errorf("can't get Task %s for BackupConfiguration %s/%s, reason: %s", backupConfig.Spec.Task.Name, backupConfig.Namespace, backupConfig.Name, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get Repository %s/%s for BackupConfiguration %s, reason: %s", backupConfig.Namespace, backupConfig.Spec.Repository.Name, backupConfig.Name, err)
+	}
+	if backupConfig.Spec.Task.Name == "" {
+		return nil
+	}
+
+	// BackupSession does not exist yet. so, use the name that it would get now to resolve the Task.
+	inputs, err := c.inputsForBackupJob(backupConfig, repository, getBackupSessionName(backupConfig))
+	if err != nil {
+		return err
+	}
+	taskResolver := resolve.TaskResolver{
+		StashClient:     c.stashClient,
+		TaskName:        backupConfig.Spec.Task.Name,
+		Inputs:          inputs,
+		RuntimeSettings: backupConfig.Spec.RuntimeSettings,
+		TempDir:         backupConfig.Spec.TempDir,
+		StepRunnerImage: c.stepRunnerImage(),
+	}
+	if err = taskResolver.IsValid(); err != nil {
+		return fmt.Errorf("can't resolve Task %s for BackupConfiguration %s/%s, reason: %s", backupConfig.Spec.Task.Name, backupConfig.Namespace, backupConfig.Name, err)
+	}
+	return nil
+}
+
 func (c *StashController) initBackupConfigurationWatcher() {
 	c.bcInformer = c.stashInformerFactory.Stash().V1beta1().BackupConfigurations().Informer()
 	c.bcQueue = queue.New(api_v1beta1.ResourceKindBackupConfiguration, c.MaxNumRequeues, c.NumThreads, c.runBackupConfigurationProcessor)
@@ -86,12 +175,14 @@ func (c *StashController) runBackupConfigurationProcessor(key string) error {
 			// skip if BackupConfiguration paused
 			if backupConfiguration.Spec.Paused {
 				log.Infof("Skipping processing BackupConfiguration %s/%s. Reason: Backup Configuration is paused.", backupConfiguration.Namespace, backupConfiguration.Name)
-				return nil
+				return c.updateBackupConfigurationStatus(backupConfiguration, func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus {
+					in.ObservedGeneration = backupConfiguration.Generation
+					in.NextScheduledTime = nil
+					return in
+				})
 			}
 
-			if backupConfiguration.Spec.Target != nil &&
-				backupConfiguration.Spec.Driver != api_v1beta1.VolumeSnapshotter &&
-				util.BackupModel(backupConfiguration.Spec.Target.Ref.Kind) == util.ModelSidecar {
+			if isSidecarBackupConfiguration(backupConfiguration) {
 				if err := c.EnsureV1beta1Sidecar(backupConfiguration); err != nil {
 					ref, rerr := reference.GetReference(stash_scheme.Scheme, backupConfiguration)
 					if rerr != nil {
@@ -101,15 +192,85 @@ func (c *StashController) runBackupConfigurationProcessor(key string) error {
 				}
 			}
 			// create a CronJob that will create BackupSession on each schedule
-			err = c.EnsureCronJob(backupConfiguration)
+			cronErr := c.EnsureCronJob(backupConfiguration)
+			nextScheduledTime := getNextScheduledTime(backupConfiguration.Spec.Schedule)
+			repoErr := c.checkBackupConfigurationRepository(backupConfiguration)
+
+			err = c.updateBackupConfigurationStatus(backupConfiguration, func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus {
+				in.ObservedGeneration = backupConfiguration.Generation
+				in.NextScheduledTime = nextScheduledTime
+
+				if cronErr != nil {
+					in.SetCondition(api_v1beta1.CronJobCreated, core.ConditionFalse, "CronJobCreationFailed", cronErr.Error())
+				} else {
+					in.SetCondition(api_v1beta1.CronJobCreated, core.ConditionTrue, "CronJobCreated",
+						fmt.Sprintf("CronJob %s has been created", getBackupCronJobName(backupConfiguration)))
+				}
+
+				if backupConfiguration.Spec.Driver == api_v1beta1.VolumeSnapshotter {
+					in.RemoveCondition(api_v1beta1.RepositoryReady)
+				} else if repoErr != nil {
+					in.SetCondition(api_v1beta1.RepositoryReady, core.ConditionFalse, "RepositoryNotFound", repoErr.Error())
+				} else {
+					in.SetCondition(api_v1beta1.RepositoryReady, core.ConditionTrue, "RepositoryFound",
+						fmt.Sprintf("Repository %s exists", backupConfiguration.Spec.Repository.Name))
+				}
+
+				// sidecar injection status is maintained by the workload controllers
+				if !isSidecarBackupConfiguration(backupConfiguration) {
+					in.RemoveCondition(api_v1beta1.SidecarInjected)
+				}
+				return in
+			})
+			if cronErr != nil {
+				return c.handleCronJobCreationFailure(backupConfiguration, cronErr)
+			}
 			if err != nil {
-				return c.handleCronJobCreationFailure(backupConfiguration, err)
+				return err
+			}
+			// requeue the BackupConfiguration at the next schedule so that its NextScheduledTime does not go stale
+			if nextScheduledTime != nil {
+				c.bcQueue.GetQueue().AddAfter(key, time.Until(nextScheduledTime.Time))
 			}
 		}
 	}
 	return nil
 }
 
+func (c *StashController) updateBackupConfigurationStatus(
+	backupConfiguration *api_v1beta1.BackupConfiguration,
+	transform func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus,
+) error {
+	_, err := v1beta1_util.UpdateBackupConfigurationStatus(c.stashClient.StashV1beta1(), backupConfiguration, transform, apis.EnableStatusSubresource)
+	return err
+}
+
+// checkBackupConfigurationRepository returns an error if the Repository referred by the BackupConfiguration does not exist
+func (c *StashController) checkBackupConfigurationRepository(backupConfiguration *api_v1beta1.BackupConfiguration) error {
+	_, err := c.stashClient.StashV1alpha1().Repositories(backupConfiguration.Namespace).Get(backupConfiguration.Spec.Repository.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("can't get Repository %s/%s. Reason: %v", backupConfiguration.Namespace, backupConfiguration.Spec.Repository.Name, err)
+	}
+	return nil
+}
+
+// getNextScheduledTime returns the time when the CronJob will create the next BackupSession.
+// it returns nil if the schedule is invalid.
+func getNextScheduledTime(schedule string) *metav1.Time {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return nil
+	}
+	next := metav1.NewTime(sched.Next(time.Now()))
+	return &next
+}
+
+func isSidecarBackupConfiguration(backupConfiguration *api_v1beta1.BackupConfiguration) bool {
+	return backupConfiguration.Spec.Target != nil &&
+		backupConfiguration.Spec.Driver != api_v1beta1.VolumeSnapshotter &&
+		util.BackupModel(backupConfiguration.Spec.Target.Ref.Kind) == util.ModelSidecar
+}
+
 // EnsureV1beta1SidecarDeleted send an event to workload respective controller
 // the workload controller will take care of removing respective sidecar
 func (c *StashController) EnsureV1beta1SidecarDeleted(backupConfiguration *api_v1beta1.BackupConfiguration) error {
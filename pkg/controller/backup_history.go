@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"sort"
+	"time"
+
+	"github.com/appscode/go/log"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"kmodules.xyz/client-go/meta"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+// cleanupBackupHistory removes the old BackupSessions of a BackupConfiguration along with their jobs and events
+// according to the backupHistoryLimit of the BackupConfiguration. The most recent succeeded BackupSession is never removed.
+func (c *StashController) cleanupBackupHistory(namespace, backupConfigName string) error {
+	backupConfig, err := c.stashClient.StashV1beta1().BackupConfigurations(namespace).Get(backupConfigName, metav1.GetOptions{})
+	if err != nil {
+		if kerr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if backupConfig.Spec.BackupHistoryLimit == nil {
+		return nil
+	}
+
+	backupSessions, err := c.backupSessionLister.BackupSessions(namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, backupSession := range backupSessionsToPrune(backupSessions, backupConfigName, *backupConfig.Spec.BackupHistoryLimit, time.Now()) {
+		log.Infof("Removing BackupSession %s/%s according to the backupHistoryLimit of BackupConfiguration %s", backupSession.Namespace, backupSession.Name, backupConfigName)
+		if err := c.deleteBackupSession(backupSession); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.NewAggregate(errs)
+}
+
+// backupSessionsToPrune returns the completed BackupSessions of the BackupConfiguration that exceed the history limit.
+// Skipped BackupSessions are counted as failed. Running and pending BackupSessions are never pruned.
+func backupSessionsToPrune(backupSessions []*api_v1beta1.BackupSession, backupConfigName string, limit api_v1beta1.BackupHistoryLimit, now time.Time) []*api_v1beta1.BackupSession {
+	var succeeded, failed []*api_v1beta1.BackupSession
+	for _, backupSession := range backupSessions {
+		if backupSession.Spec.BackupConfiguration.Name != backupConfigName {
+			continue
+		}
+		switch backupSession.Status.Phase {
+		case api_v1beta1.BackupSessionSucceeded:
+			succeeded = append(succeeded, backupSession)
+		case api_v1beta1.BackupSessionFailed, api_v1beta1.BackupSessionSkipped:
+			failed = append(failed, backupSession)
+		}
+	}
+
+	// sort the BackupSessions so that the newest one comes first
+	newestFirst := func(list []*api_v1beta1.BackupSession) {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[j].CreationTimestamp.Before(&list[i].CreationTimestamp)
+		})
+	}
+	newestFirst(succeeded)
+	newestFirst(failed)
+
+	prune := make([]*api_v1beta1.BackupSession, 0)
+	expired := func(backupSession *api_v1beta1.BackupSession) bool {
+		return limit.TTL != nil && now.Sub(backupSession.CreationTimestamp.Time) > limit.TTL.Duration
+	}
+
+	for i, backupSession := range succeeded {
+		// always keep the most recent succeeded BackupSession
+		if i == 0 {
+			continue
+		}
+		if (limit.Succeeded != nil && i >= int(*limit.Succeeded)) || expired(backupSession) {
+			prune = append(prune, backupSession)
+		}
+	}
+	for i, backupSession := range failed {
+		if (limit.Failed != nil && i >= int(*limit.Failed)) || expired(backupSession) {
+			prune = append(prune, backupSession)
+		}
+	}
+	return prune
+}
+
+// deleteBackupSession deletes the BackupSession, the jobs that has been created for it and its events.
+func (c *StashController) deleteBackupSession(backupSession *api_v1beta1.BackupSession) error {
+	// delete the jobs first so that they don't outlive the BackupSession if anything goes wrong afterward
+	err := c.deleteBackupSessionJobs(backupSession)
+	if err != nil {
+		return err
+	}
+
+	events, err := c.kubeClient.CoreV1().Events(backupSession.Namespace).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.uid", string(backupSession.UID)).String(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, event := range events.Items {
+		err = c.kubeClient.CoreV1().Events(event.Namespace).Delete(event.Name, &metav1.DeleteOptions{})
+		if err != nil && !kerr.IsNotFound(err) {
+			return err
+		}
+	}
+
+	err = c.stashClient.StashV1beta1().BackupSessions(backupSession.Namespace).Delete(backupSession.Name, meta.DeleteInBackground())
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteBackupSessionJobs deletes all the jobs that has been created for the BackupSession.
+// The running pods of the jobs are killed too.
+func (c *StashController) deleteBackupSessionJobs(backupSession *api_v1beta1.BackupSession) error {
+	for _, jobName := range getBackupSessionJobNames(backupSession) {
+		err := c.kubeClient.BatchV1().Jobs(backupSession.Namespace).Delete(jobName, meta.DeleteInBackground())
+		if err != nil && !kerr.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/appscode/go/types"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+func newBackupSession(name, backupConfigName string, phase api_v1beta1.BackupSessionPhase, created time.Time) *api_v1beta1.BackupSession {
+	return &api_v1beta1.BackupSession{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "demo",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+		Spec: api_v1beta1.BackupSessionSpec{
+			BackupConfiguration: core.LocalObjectReference{Name: backupConfigName},
+		},
+		Status: api_v1beta1.BackupSessionStatus{Phase: phase},
+	}
+}
+
+func TestBackupSessionsToPrune(t *testing.T) {
+	now := time.Date(2019, 10, 15, 12, 0, 0, 0, time.UTC)
+	at := func(hoursAgo int) time.Time {
+		return now.Add(-time.Duration(hoursAgo) * time.Hour)
+	}
+	// the BackupSessions are listed in random order to ensure that the selection does not depend on the lister order
+	backupSessions := []*api_v1beta1.BackupSession{
+		newBackupSession("s3", "bc", api_v1beta1.BackupSessionSucceeded, at(3)),
+		newBackupSession("s1", "bc", api_v1beta1.BackupSessionSucceeded, at(1)),
+		newBackupSession("f2", "bc", api_v1beta1.BackupSessionFailed, at(2)),
+		newBackupSession("s5", "bc", api_v1beta1.BackupSessionSucceeded, at(5)),
+		newBackupSession("k4", "bc", api_v1beta1.BackupSessionSkipped, at(4)),
+		newBackupSession("f6", "bc", api_v1beta1.BackupSessionFailed, at(6)),
+		newBackupSession("r7", "bc", api_v1beta1.BackupSessionRunning, at(7)),
+		newBackupSession("p8", "bc", api_v1beta1.BackupSessionPending, at(8)),
+		newBackupSession("u9", "bc", api_v1beta1.BackupSessionUnknown, at(9)),
+		newBackupSession("other", "other-bc", api_v1beta1.BackupSessionSucceeded, at(10)),
+	}
+
+	testCases := []struct {
+		name  string
+		limit api_v1beta1.BackupHistoryLimit
+		want  []string
+	}{
+		{
+			name:  "no limit prunes nothing",
+			limit: api_v1beta1.BackupHistoryLimit{},
+			want:  []string{},
+		},
+		{
+			name:  "succeeded limit keeps the newest ones",
+			limit: api_v1beta1.BackupHistoryLimit{Succeeded: types.Int32P(2)},
+			want:  []string{"s5"},
+		},
+		{
+			name:  "failed limit counts skipped as failed",
+			limit: api_v1beta1.BackupHistoryLimit{Failed: types.Int32P(1)},
+			want:  []string{"k4", "f6"},
+		},
+		{
+			name:  "zero limit keeps the most recent succeeded one",
+			limit: api_v1beta1.BackupHistoryLimit{Succeeded: types.Int32P(0), Failed: types.Int32P(0)},
+			want:  []string{"s3", "s5", "f2", "k4", "f6"},
+		},
+		{
+			name:  "ttl prunes the old completed ones",
+			limit: api_v1beta1.BackupHistoryLimit{TTL: &metav1.Duration{Duration: 150 * time.Minute}},
+			want:  []string{"s3", "s5", "k4", "f6"},
+		},
+		{
+			name:  "ttl never prunes the most recent succeeded one",
+			limit: api_v1beta1.BackupHistoryLimit{TTL: &metav1.Duration{Duration: time.Minute}},
+			want:  []string{"s3", "s5", "f2", "k4", "f6"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, backupSession := range backupSessionsToPrune(backupSessions, "bc", tc.limit, now) {
+				got = append(got, backupSession.Name)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expected %v, found %v", tc.want, got)
+			}
+		})
+	}
+}
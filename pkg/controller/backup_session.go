@@ -23,6 +23,7 @@ import (
 	webhook "kmodules.xyz/webhook-runtime/admission/v1beta1/generic"
 	"stash.appscode.dev/stash/apis"
 	"stash.appscode.dev/stash/apis/stash"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
 	stash_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
@@ -99,16 +100,41 @@ func (c *StashController) runBackupSessionProcessor(key string) error {
 
 	if phase == api_v1beta1.BackupSessionFailed {
 		// one or more hosts has failed to complete their backup process.
-		// mark entire backup session as failure.
+		// if a host has failed because the repository was locked by a process that does not exist anymore,
+		// enqueue the Repository so that the stale lock is removed before the retry or the next BackupSession.
+		if backupSession.Status.NextRetryTime == nil && hasLockFailure(backupSession) {
+			c.recoverStaleLocks(backupSession)
+		}
+		// retry the backup of the failed hosts if backoffLimit allows.
+		retrying, rerr := c.retryFailedHosts(backupSession, err)
+		if rerr != nil || retrying {
+			return rerr
+		}
+		// no more retry left. mark entire backup session as failure.
 		// individual hosts has updated their respective stats and has sent respective metrics.
 		// now, just set BackupSession phase "Failed" and create an event.
-		return c.setBackupSessionFailed(backupSession, err)
+		if err := c.setBackupSessionFailed(backupSession, err); err != nil {
+			return err
+		}
+		// BackupSession has been completed. cleanup old BackupSessions according to backupHistoryLimit.
+		return c.cleanupBackupHistory(backupSession.Namespace, backupSession.Spec.BackupConfiguration.Name)
 	} else if phase == api_v1beta1.BackupSessionSucceeded {
 		// all hosts has completed their backup process successfully.
 		// individual hosts has updated their respective stats and has sent respective metrics.
 		// now, just set BackupSession phase "Succeeded" and create an event.
-		return c.setBackupSessionSucceeded(backupSession)
+		if err := c.setBackupSessionSucceeded(backupSession); err != nil {
+			return err
+		}
+		// copy the new snapshots to the replicas of the Repository that are not copied on their own schedule
+		c.ensureRepositoryCopyJobs(backupSession)
+		// BackupSession has been completed. cleanup old BackupSessions according to backupHistoryLimit.
+		return c.cleanupBackupHistory(backupSession.Namespace, backupSession.Spec.BackupConfiguration.Name)
 	} else if phase == api_v1beta1.BackupSessionRunning {
+		// fail the BackupSession if it has exceeded the timeout
+		timedOut, err := c.handleBackupSessionTimeout(backupSession)
+		if err != nil || timedOut {
+			return err
+		}
 		log.Infof("Skipping processing BackupSession %s/%s. Reason: phase is %q.", backupSession.Namespace, backupSession.Name, backupSession.Status.Phase)
 		return nil
 	} else if phase == api_v1beta1.BackupSessionSkipped {
@@ -129,11 +155,33 @@ func (c *StashController) runBackupSessionProcessor(key string) error {
 	// skip if BackupConfiguration paused
 	if backupConfig.Spec.Paused {
 		log.Infof("Skipping processing BackupSession %s/%s. Reason: Backup Configuration is paused.", backupSession.Namespace, backupSession.Name)
-		return c.setBackupSessionSkipped(backupSession, fmt.Sprintf("BackupConfiguration %s/%s is paused", backupConfig.Namespace, backupConfig.Name))
+		if err := c.setBackupSessionSkipped(backupSession, fmt.Sprintf("BackupConfiguration %s/%s is paused", backupConfig.Namespace, backupConfig.Name)); err != nil {
+			return err
+		}
+		return c.cleanupBackupHistory(backupSession.Namespace, backupConfig.Name)
+	}
+
+	// the BackupSession might have been held "Pending" by the limits below. fail it if it has exceeded the timeout.
+	timedOut, err := c.applyBackupSessionTimeout(backupConfig, backupSession)
+	if err != nil || timedOut {
+		return err
+	}
+
+	// check whether the new BackupSession can run while the previous BackupSession is still running
+	proceed, err := c.applyConcurrencyPolicy(backupConfig, backupSession)
+	if err != nil || !proceed {
+		return err
+	}
+
+	// wait if too many BackupSessions are already running against the same backend
+	proceed, err = c.applyBackendConcurrencyLimit(backupConfig, backupSession)
+	if err != nil || !proceed {
+		return err
 	}
 
 	// skip if backup model is sidecar.
-	// for sidecar model controller inside sidecar will take care of it.
+	// for sidecar model controller inside sidecar will take care of it. the sidecar starts backup only
+	// after the BackupSession is set "Running" here, so the limits above apply to it too.
 	if backupConfig.Spec.Target != nil && util.BackupModel(backupConfig.Spec.Target.Ref.Kind) == util.ModelSidecar {
 		log.Infof("Skipping processing BackupSession %s/%s. Reason: Backup model is sidecar. Controller inside sidecar will take care of it.", backupSession.Namespace, backupSession.Name)
 		return c.setBackupSessionRunning(backupSession)
@@ -213,40 +261,9 @@ func (c *StashController) ensureBackupJob(backupSession *api_v1beta1.BackupSessi
 	}
 
 	// resolve task template
-
-	explicitInputs := make(map[string]string)
-	for _, param := range backupConfig.Spec.Task.Params {
-		explicitInputs[param.Name] = param.Value
-	}
-
-	repoInputs, err := c.inputsForRepository(repository)
-	if err != nil {
-		return fmt.Errorf("cannot resolve implicit inputs for Repository %s/%s, reason: %s", repository.Namespace, repository.Name, err)
-	}
-	bcInputs, err := c.inputsForBackupConfig(*backupConfig)
+	podSpec, _, err := c.resolveBackupTask(backupConfig, repository, backupSession.Name, false)
 	if err != nil {
-		return fmt.Errorf("cannot resolve implicit inputs for BackupConfiguration %s/%s, reason: %s", backupConfig.Namespace, backupConfig.Name, err)
-	}
-
-	implicitInputs := core_util.UpsertMap(repoInputs, bcInputs)
-	implicitInputs[apis.Namespace] = backupSession.Namespace
-	implicitInputs[apis.BackupSession] = backupSession.Name
-	implicitInputs[apis.StatusSubresourceEnabled] = fmt.Sprint(apis.EnableStatusSubresource)
-
-	taskResolver := resolve.TaskResolver{
-		StashClient:     c.stashClient,
-		TaskName:        backupConfig.Spec.Task.Name,
-		Inputs:          core_util.UpsertMap(explicitInputs, implicitInputs), // TODO: reverse priority ???
-		RuntimeSettings: backupConfig.Spec.RuntimeSettings,
-		TempDir:         backupConfig.Spec.TempDir,
-	}
-	podSpec, err := taskResolver.GetPodSpec()
-	if err != nil {
-		return fmt.Errorf("can't get PodSpec for BackupConfiguration %s/%s, reason: %s", backupConfig.Namespace, backupConfig.Name, err)
-	}
-	// for local backend, attach volume to all containers
-	if repository.Spec.Backend.Local != nil {
-		podSpec = util.AttachLocalBackend(podSpec, *repository.Spec.Backend.Local)
+		return err
 	}
 
 	// create Backup Job
@@ -262,6 +279,8 @@ func (c *StashController) ensureBackupJob(backupSession *api_v1beta1.BackupSessi
 
 		in.Spec.Template.Spec = podSpec
 		in.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+		// kill the job if the BackupSession exceeds the timeout
+		in.Spec.ActiveDeadlineSeconds = getBackupJobActiveDeadlineSeconds(backupConfig, backupSession)
 
 		return in
 	})
@@ -269,6 +288,35 @@ func (c *StashController) ensureBackupJob(backupSession *api_v1beta1.BackupSessi
 	return err
 }
 
+// resolveBackupTask resolves the Task and Functions of a BackupConfiguration then returns the PodSpec of the backup job.
+// In dry run mode, the variables that can't be resolved are left as it is and returned instead of failing.
+func (c *StashController) resolveBackupTask(backupConfig *api_v1beta1.BackupConfiguration,
+	repository *api_v1alpha1.Repository, backupSessionName string, dryRun bool) (core.PodSpec, []string, error) {
+
+	inputs, err := c.inputsForBackupJob(backupConfig, repository, backupSessionName)
+	if err != nil {
+		return core.PodSpec{}, nil, err
+	}
+
+	taskResolver := resolve.TaskResolver{
+		StashClient:     c.stashClient,
+		TaskName:        backupConfig.Spec.Task.Name,
+		Inputs:          inputs,
+		RuntimeSettings: backupConfig.Spec.RuntimeSettings,
+		TempDir:         backupConfig.Spec.TempDir,
+		StepRunnerImage: c.stepRunnerImage(),
+	}
+	podSpec, unresolved, err := getPodSpec(taskResolver, dryRun)
+	if err != nil {
+		return core.PodSpec{}, nil, fmt.Errorf("can't get PodSpec for BackupConfiguration %s/%s, reason: %s", backupConfig.Namespace, backupConfig.Name, err)
+	}
+	// for local backend, attach volume to all containers
+	if repository.Spec.Backend.Local != nil {
+		podSpec = util.AttachLocalBackend(podSpec, *repository.Spec.Backend.Local)
+	}
+	return podSpec, unresolved, nil
+}
+
 func (c *StashController) ensureVolumeSnapshotterJob(backupConfig *api_v1beta1.BackupConfiguration, backupSession *api_v1beta1.BackupSession) error {
 	offshootLabels := backupConfig.OffshootLabels()
 
@@ -326,6 +374,8 @@ func (c *StashController) ensureVolumeSnapshotterJob(backupConfig *api_v1beta1.B
 
 		in.Spec.Template = *jobTemplate
 		in.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+		// kill the job if the BackupSession exceeds the timeout
+		in.Spec.ActiveDeadlineSeconds = getBackupJobActiveDeadlineSeconds(backupConfig, backupSession)
 		return in
 	})
 
@@ -353,11 +403,19 @@ func (c *StashController) setBackupSessionFailed(backupSession *api_v1beta1.Back
 		fmt.Sprintf("Backup session failed to complete. Reason: %v", backupErr),
 	)
 
-	// send backup session specific metrics
 	backupConfig, err2 := c.stashClient.StashV1beta1().BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
 	if err2 != nil {
 		return err2
 	}
+	err2 = c.updateBackupConfigurationStatus(backupConfig, func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus {
+		in.LastBackupSession = backupSession.Name
+		return in
+	})
+	if err2 != nil {
+		return err2
+	}
+
+	// send backup session specific metrics
 	metricsOpt := &restic.MetricsOptions{
 		Enabled:        true,
 		PushgatewayURL: util.PushgatewayLocalURL,
@@ -451,11 +509,21 @@ func (c *StashController) setBackupSessionSucceeded(backupSession *api_v1beta1.B
 		fmt.Sprintf("Backup session completed successfully"),
 	)
 
-	// send backup session specific metrics
 	backupConfig, err := c.stashClient.StashV1beta1().BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
+	err = c.updateBackupConfigurationStatus(backupConfig, func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus {
+		currentTime := metav1.Now()
+		in.LastBackupSession = backupSession.Name
+		in.LastSuccessfulBackupTime = &currentTime
+		return in
+	})
+	if err != nil {
+		return err
+	}
+
+	// send backup session specific metrics
 	metricsOpt := &restic.MetricsOptions{
 		Enabled:        true,
 		PushgatewayURL: util.PushgatewayLocalURL,
@@ -506,7 +574,7 @@ func (c *StashController) handleBackupJobCreationFailure(backupSession *api_v1be
 }
 
 func getBackupJobName(backupSession *api_v1beta1.BackupSession) string {
-	return BackupJobPrefix + strings.ReplaceAll(backupSession.Name, ".", "-")
+	return BackupJobPrefix + jobNameSuffix(backupSession.Name, backupSession.Status.Retried)
 }
 
 func getBackupJobServiceAccountName(backupConfiguration *api_v1beta1.BackupConfiguration) string {
@@ -514,5 +582,39 @@ func getBackupJobServiceAccountName(backupConfiguration *api_v1beta1.BackupConfi
 }
 
 func getVolumeSnapshotterJobName(backupSession *api_v1beta1.BackupSession) string {
-	return VolumeSnapshotPrefix + strings.ReplaceAll(backupSession.Name, ".", "-")
+	return VolumeSnapshotPrefix + jobNameSuffix(backupSession.Name, backupSession.Status.Retried)
+}
+
+// getBackupJobActiveDeadlineSeconds returns the remaining time before the BackupSession exceeds the timeout.
+func getBackupJobActiveDeadlineSeconds(backupConfig *api_v1beta1.BackupConfiguration, backupSession *api_v1beta1.BackupSession) *int64 {
+	if backupConfig.Spec.Timeout == nil {
+		return nil
+	}
+	remaining := int64(time.Until(backupSession.CreationTimestamp.Add(backupConfig.Spec.Timeout.Duration)).Seconds())
+	if remaining < 1 {
+		remaining = 1
+	}
+	return &remaining
+}
+
+// getBackupSessionJobNames returns the name of all the jobs that might have been created for the BackupSession including the retries.
+func getBackupSessionJobNames(backupSession *api_v1beta1.BackupSession) []string {
+	names := make([]string, 0)
+	for attempt := int32(0); attempt <= backupSession.Status.Retried; attempt++ {
+		names = append(names,
+			BackupJobPrefix+jobNameSuffix(backupSession.Name, attempt),
+			VolumeSnapshotPrefix+jobNameSuffix(backupSession.Name, attempt),
+		)
+	}
+	return names
+}
+
+// jobNameSuffix generates the suffix of a job name for the BackupSession.
+// A new job is created for each retry. So, the retry attempt is appended to the name.
+func jobNameSuffix(backupSessionName string, attempt int32) string {
+	suffix := strings.ReplaceAll(backupSessionName, ".", "-")
+	if attempt > 0 {
+		suffix = fmt.Sprintf("%s-retry-%d", suffix, attempt)
+	}
+	return suffix
 }
@@ -0,0 +1,367 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/appscode/go/log"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"stash.appscode.dev/stash/apis"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
+	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	// backup of the failed hosts are retried with exponential backoff starting from backupRetryBaseDelay
+	// and capped at backupRetryMaxDelay
+	backupRetryBaseDelay = 10 * time.Second
+	backupRetryMaxDelay  = 5 * time.Minute
+
+	// a BackupSession waiting for the backend concurrency limit is re-checked after backendLimitRecheckDelay
+	backendLimitRecheckDelay = 30 * time.Second
+	// an admitted BackupSession holds a slot of the backend for at most backendReservationTTL before the lister
+	// observes it running
+	backendReservationTTL = 2 * time.Minute
+	// a running BackupSession without timeout stops holding a slot of the backend after staleBackupSessionAge
+	staleBackupSessionAge = 24 * time.Hour
+)
+
+// handleBackupSessionTimeout marks the BackupSession as "Failed" and kills its jobs if the BackupSession has exceeded
+// the timeout of the BackupConfiguration. Otherwise, it re-enqueues the BackupSession so that it is checked again when
+// the timeout expires. It returns true if the BackupSession has timed out.
+func (c *StashController) handleBackupSessionTimeout(backupSession *api_v1beta1.BackupSession) (bool, error) {
+	backupConfig, err := c.stashClient.StashV1beta1().BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return c.applyBackupSessionTimeout(backupConfig, backupSession)
+}
+
+// applyBackupSessionTimeout applies the timeout of the BackupConfiguration to the BackupSession. The timeout is counted
+// from the creation of the BackupSession. So, it also applies to the BackupSessions that are held "Pending" by the
+// concurrencyPolicy or the backend concurrency limit.
+func (c *StashController) applyBackupSessionTimeout(backupConfig *api_v1beta1.BackupConfiguration, backupSession *api_v1beta1.BackupSession) (bool, error) {
+	if backupConfig.Spec.Timeout == nil {
+		return false, nil
+	}
+
+	remaining := time.Until(backupSession.CreationTimestamp.Add(backupConfig.Spec.Timeout.Duration))
+	if remaining > 0 {
+		c.enqueueBackupSessionAfter(backupSession, remaining)
+		return false, nil
+	}
+
+	timeoutErr := fmt.Errorf("BackupSession has exceeded the timeout %v", backupConfig.Spec.Timeout.Duration)
+	_, _ = eventer.CreateEvent(
+		c.kubeClient,
+		eventer.EventSourceBackupSessionController,
+		backupSession,
+		core.EventTypeWarning,
+		eventer.EventReasonBackupSessionTimeout,
+		fmt.Sprintf("%v. Killing the backup job if there is any.", timeoutErr),
+	)
+	// kill the running backup job. for sidecar model, there is no job to kill.
+	// the sidecar enforces the timeout on its own.
+	if err := c.deleteBackupSessionJobs(backupSession); err != nil {
+		return true, err
+	}
+	if err := c.setBackupSessionFailed(backupSession, timeoutErr); err != nil {
+		return true, err
+	}
+	return true, c.cleanupBackupHistory(backupSession.Namespace, backupConfig.Name)
+}
+
+// retryFailedHosts retries the backup of the failed hosts according to the backoffLimit of the BackupConfiguration.
+// The first time a failure is observed, it schedules the retry after an exponential backoff delay. When the delay
+// expires, it removes the failed hosts from the BackupSession status so that they can take backup again. It returns
+// true if the backup of the failed hosts will be retried.
+func (c *StashController) retryFailedHosts(backupSession *api_v1beta1.BackupSession, backupErr error) (bool, error) {
+	backupConfig, err := c.stashClient.StashV1beta1().BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
+	if err != nil {
+		if kerr.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if backupConfig.Spec.BackoffLimit == nil || backupSession.Status.Retried >= *backupConfig.Spec.BackoffLimit {
+		return false, nil
+	}
+	// don't retry if the BackupSession has already exceeded the timeout
+	if backupConfig.Spec.Timeout != nil &&
+		time.Since(backupSession.CreationTimestamp.Time) >= backupConfig.Spec.Timeout.Duration {
+		return false, nil
+	}
+
+	now := time.Now()
+	// schedule the retry
+	if backupSession.Status.NextRetryTime == nil {
+		delay := backupRetryDelay(backupSession.Status.Retried)
+		_, err = stash_util.UpdateBackupSessionStatus(c.stashClient.StashV1beta1(), backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
+			in.NextRetryTime = &metav1.Time{Time: now.Add(delay)}
+			return in
+		}, apis.EnableStatusSubresource)
+		if err != nil {
+			return true, err
+		}
+		_, _ = eventer.CreateEvent(
+			c.kubeClient,
+			eventer.EventSourceBackupSessionController,
+			backupSession,
+			core.EventTypeWarning,
+			eventer.EventReasonBackupSessionRetrying,
+			fmt.Sprintf("%v. Retrying in %v (retry %d of %d).", backupErr, delay, backupSession.Status.Retried+1, *backupConfig.Spec.BackoffLimit),
+		)
+		c.enqueueBackupSessionAfter(backupSession, delay)
+		return true, nil
+	}
+
+	// retry has been scheduled but the backoff delay hasn't expired yet
+	if now.Before(backupSession.Status.NextRetryTime.Time) {
+		c.enqueueBackupSessionAfter(backupSession, backupSession.Status.NextRetryTime.Sub(now))
+		return true, nil
+	}
+
+	// backoff delay has expired. remove the failed hosts from the status so that they can take backup again.
+	log.Infof("Retrying backup of the failed hosts of BackupSession %s/%s", backupSession.Namespace, backupSession.Name)
+	updatedBackupSession, err := stash_util.UpdateBackupSessionStatus(c.stashClient.StashV1beta1(), backupSession, func(in *api_v1beta1.BackupSessionStatus) *api_v1beta1.BackupSessionStatus {
+		stats := make([]api_v1beta1.HostBackupStats, 0, len(in.Stats))
+		for _, hostStats := range in.Stats {
+			if hostStats.Phase != api_v1beta1.HostBackupFailed {
+				stats = append(stats, hostStats)
+			}
+		}
+		in.Stats = stats
+		in.Retried++
+		in.NextRetryTime = nil
+		in.Phase = api_v1beta1.BackupSessionRunning
+		return in
+	}, apis.EnableStatusSubresource)
+	if err != nil {
+		return true, err
+	}
+
+	// for sidecar model, the sidecar will take backup again as the entry for its host has been removed.
+	// for other models, create a new backup job.
+	if backupConfig.Spec.Target != nil && util.BackupModel(backupConfig.Spec.Target.Ref.Kind) == util.ModelSidecar {
+		return true, nil
+	}
+	if backupConfig.Spec.Target != nil && backupConfig.Spec.Driver == api_v1beta1.VolumeSnapshotter {
+		err = c.ensureVolumeSnapshotterJob(backupConfig, updatedBackupSession)
+	} else {
+		err = c.ensureBackupJob(updatedBackupSession, backupConfig)
+	}
+	if err != nil {
+		return true, c.handleBackupJobCreationFailure(updatedBackupSession, err)
+	}
+	return true, nil
+}
+
+// applyConcurrencyPolicy decides whether the new BackupSession should proceed when a previous BackupSession of the
+// same BackupConfiguration is still running. For "Forbid" policy, the new BackupSession is skipped. For "Replace"
+// policy, the running BackupSessions are marked as "Failed" and their jobs are killed.
+func (c *StashController) applyConcurrencyPolicy(backupConfig *api_v1beta1.BackupConfiguration, backupSession *api_v1beta1.BackupSession) (bool, error) {
+	if backupConfig.Spec.ConcurrencyPolicy == "" || backupConfig.Spec.ConcurrencyPolicy == api_v1beta1.AllowConcurrent {
+		return true, nil
+	}
+
+	backupSessions, err := c.backupSessionLister.BackupSessions(backupSession.Namespace).List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	running := make([]*api_v1beta1.BackupSession, 0)
+	for _, bs := range backupSessions {
+		if bs.Name != backupSession.Name &&
+			bs.Spec.BackupConfiguration.Name == backupConfig.Name &&
+			bs.Status.Phase == api_v1beta1.BackupSessionRunning &&
+			!backupSession.CreationTimestamp.Before(&bs.CreationTimestamp) {
+			running = append(running, bs)
+		}
+	}
+	if len(running) == 0 {
+		return true, nil
+	}
+
+	switch backupConfig.Spec.ConcurrencyPolicy {
+	case api_v1beta1.ForbidConcurrent:
+		log.Infof("Skipping processing BackupSession %s/%s. Reason: previous BackupSession %s is still running.", backupSession.Namespace, backupSession.Name, running[0].Name)
+		err = c.setBackupSessionSkipped(backupSession, fmt.Sprintf("previous BackupSession %s is still running and concurrencyPolicy is %q", running[0].Name, api_v1beta1.ForbidConcurrent))
+		if err != nil {
+			return false, err
+		}
+		return false, c.cleanupBackupHistory(backupSession.Namespace, backupConfig.Name)
+	case api_v1beta1.ReplaceConcurrent:
+		for _, bs := range running {
+			log.Infof("Replacing running BackupSession %s/%s with BackupSession %s", bs.Namespace, bs.Name, backupSession.Name)
+			_, _ = eventer.CreateEvent(
+				c.kubeClient,
+				eventer.EventSourceBackupSessionController,
+				bs,
+				core.EventTypeWarning,
+				eventer.EventReasonBackupSessionReplaced,
+				fmt.Sprintf("BackupSession has been replaced by BackupSession %s as concurrencyPolicy is %q", backupSession.Name, api_v1beta1.ReplaceConcurrent),
+			)
+			if err := c.deleteBackupSessionJobs(bs); err != nil {
+				return false, err
+			}
+			if err := c.setBackupSessionFailed(bs, fmt.Errorf("replaced by BackupSession %s", backupSession.Name)); err != nil {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// applyBackendConcurrencyLimit checks whether the number of running BackupSessions that use the same backend has
+// reached the operator wide limit. If so, the BackupSession is kept pending and re-enqueued so that it starts once
+// one of the running BackupSessions has completed. It returns true if the BackupSession can proceed.
+//
+// The admission is serialized and the admitted BackupSession reserves a slot of the backend until the lister observes
+// it running. Otherwise, the BackupSessions processed at the same time would all be admitted as none of them is
+// running yet. The running BackupSessions that have exceeded their timeout or staleBackupSessionAge don't hold a slot.
+func (c *StashController) applyBackendConcurrencyLimit(backupConfig *api_v1beta1.BackupConfiguration, backupSession *api_v1beta1.BackupSession) (bool, error) {
+	if c.MaxConcurrentBackupsPerBackend <= 0 || backupConfig.Spec.Driver == api_v1beta1.VolumeSnapshotter {
+		return true, nil
+	}
+	backend, err := c.getBackendKey(backupConfig)
+	if err != nil {
+		return false, err
+	}
+	sessionKey, err := cache.MetaNamespaceKeyFunc(backupSession)
+	if err != nil {
+		return false, err
+	}
+
+	c.backendLock.Lock()
+	defer c.backendLock.Unlock()
+
+	if err := c.pruneBackendReservations(); err != nil {
+		return false, err
+	}
+
+	backupSessions, err := c.backupSessionLister.List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+	running := 0
+	for _, bs := range backupSessions {
+		if bs.Status.Phase != api_v1beta1.BackupSessionRunning ||
+			(bs.Namespace == backupSession.Namespace && bs.Name == backupSession.Name) {
+			continue
+		}
+		bc, err := c.bcLister.BackupConfigurations(bs.Namespace).Get(bs.Spec.BackupConfiguration.Name)
+		if err != nil || bc.Spec.Driver == api_v1beta1.VolumeSnapshotter || isStaleBackupSession(bc, bs) {
+			continue
+		}
+		if key, err := c.getBackendKey(bc); err == nil && key == backend {
+			running++
+		}
+	}
+	for key, reservation := range c.backendReservations {
+		if key != sessionKey && reservation.backend == backend {
+			running++
+		}
+	}
+	if running < c.MaxConcurrentBackupsPerBackend {
+		if c.backendReservations == nil {
+			c.backendReservations = make(map[string]backendReservation)
+		}
+		c.backendReservations[sessionKey] = backendReservation{backend: backend, admitted: time.Now()}
+		return true, nil
+	}
+
+	log.Infof("Delaying BackupSession %s/%s. Reason: %d BackupSessions are already running against backend %s.", backupSession.Namespace, backupSession.Name, running, backend)
+	c.enqueueBackupSessionAfter(backupSession, backendLimitRecheckDelay)
+	return false, nil
+}
+
+// backendReservation is a slot of a backend held by an admitted BackupSession that is not observed running yet
+type backendReservation struct {
+	backend  string
+	admitted time.Time
+}
+
+// pruneBackendReservations releases the slots of the BackupSessions that have been deleted, that are observed running
+// or completed by the lister, or that have held the slot for longer than backendReservationTTL.
+// The caller must hold backendLock.
+func (c *StashController) pruneBackendReservations() error {
+	for key, reservation := range c.backendReservations {
+		if time.Since(reservation.admitted) > backendReservationTTL {
+			delete(c.backendReservations, key)
+			continue
+		}
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			return err
+		}
+		bs, err := c.backupSessionLister.BackupSessions(namespace).Get(name)
+		if err != nil {
+			if kerr.IsNotFound(err) {
+				delete(c.backendReservations, key)
+				continue
+			}
+			return err
+		}
+		if bs.Status.Phase != "" && bs.Status.Phase != api_v1beta1.BackupSessionPending {
+			delete(c.backendReservations, key)
+		}
+	}
+	return nil
+}
+
+// isStaleBackupSession returns true if a running BackupSession has exceeded the timeout of its BackupConfiguration or,
+// if there is no timeout, staleBackupSessionAge. Such a BackupSession is most probably stuck.
+func isStaleBackupSession(backupConfig *api_v1beta1.BackupConfiguration, backupSession *api_v1beta1.BackupSession) bool {
+	maxAge := staleBackupSessionAge
+	if backupConfig.Spec.Timeout != nil {
+		maxAge = backupConfig.Spec.Timeout.Duration
+	}
+	return time.Since(backupSession.CreationTimestamp.Time) > maxAge
+}
+
+// getBackendKey returns a key that identifies the backend of the Repository used by a BackupConfiguration
+func (c *StashController) getBackendKey(backupConfig *api_v1beta1.BackupConfiguration) (string, error) {
+	repository, err := c.repoLister.Repositories(backupConfig.Namespace).Get(backupConfig.Spec.Repository.Name)
+	if err != nil {
+		return "", err
+	}
+	provider, err := repository.Spec.Backend.Provider()
+	if err != nil {
+		return "", err
+	}
+	bucket, err := repository.Spec.Backend.Container()
+	if err != nil {
+		return "", err
+	}
+	endpoint, _ := repository.Spec.Backend.Endpoint()
+	return fmt.Sprintf("%s:%s/%s", provider, endpoint, bucket), nil
+}
+
+func (c *StashController) enqueueBackupSessionAfter(backupSession *api_v1beta1.BackupSession, delay time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(backupSession)
+	if err != nil {
+		log.Errorf("Failed to get key for BackupSession %s/%s. Reason: %v", backupSession.Namespace, backupSession.Name, err)
+		return
+	}
+	c.backupSessionQueue.GetQueue().AddAfter(key, delay)
+}
+
+// backupRetryDelay returns the delay before the next retry. The delay is doubled on each retry.
+func backupRetryDelay(retried int32) time.Duration {
+	delay := backupRetryBaseDelay
+	for i := int32(0); i < retried; i++ {
+		delay *= 2
+		if delay >= backupRetryMaxDelay {
+			return backupRetryMaxDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+	store "kmodules.xyz/objectstore-api/api/v1"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_listers "stash.appscode.dev/stash/client/listers/stash/v1alpha1"
+	v1beta1_listers "stash.appscode.dev/stash/client/listers/stash/v1beta1"
+)
+
+func TestApplyBackendConcurrencyLimit(t *testing.T) {
+	repoIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, bucket := range []string{"shared", "other"} {
+		repository := &api_v1alpha1.Repository{
+			ObjectMeta: metav1.ObjectMeta{Name: bucket, Namespace: "demo"},
+			Spec: api_v1alpha1.RepositorySpec{
+				Backend: store.Backend{GCS: &store.GCSSpec{Bucket: bucket}},
+			},
+		}
+		if err := repoIndexer.Add(repository); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bcIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	backupConfigs := map[string]*api_v1beta1.BackupConfiguration{}
+	for _, name := range []string{"shared", "other"} {
+		backupConfigs[name] = &api_v1beta1.BackupConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "demo"},
+			Spec:       api_v1beta1.BackupConfigurationSpec{Repository: core.LocalObjectReference{Name: name}},
+		}
+		if err := bcIndexer.Add(backupConfigs[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := time.Now()
+	bsIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	pending := map[string]*api_v1beta1.BackupSession{}
+	for _, bs := range []*api_v1beta1.BackupSession{
+		newBackupSession("running", "shared", api_v1beta1.BackupSessionRunning, now),
+		newBackupSession("stuck", "shared", api_v1beta1.BackupSessionRunning, now.Add(-staleBackupSessionAge-time.Hour)),
+		newBackupSession("other-running", "other", api_v1beta1.BackupSessionRunning, now),
+		newBackupSession("p1", "shared", api_v1beta1.BackupSessionPending, now),
+		newBackupSession("p2", "shared", api_v1beta1.BackupSessionPending, now),
+		newBackupSession("p3", "other", api_v1beta1.BackupSessionPending, now),
+	} {
+		if err := bsIndexer.Add(bs); err != nil {
+			t.Fatal(err)
+		}
+		pending[bs.Name] = bs
+	}
+
+	c := &StashController{
+		repoLister:          stash_listers.NewRepositoryLister(repoIndexer),
+		bcLister:            v1beta1_listers.NewBackupConfigurationLister(bcIndexer),
+		backupSessionLister: v1beta1_listers.NewBackupSessionLister(bsIndexer),
+		backupSessionQueue:  queue.New(api_v1beta1.ResourceKindBackupSession, 0, 1, nil),
+	}
+	c.MaxConcurrentBackupsPerBackend = 2
+	defer c.backupSessionQueue.GetQueue().ShutDown()
+
+	admit := func(name string, want bool) {
+		bs := pending[name]
+		got, err := c.applyBackendConcurrencyLimit(backupConfigs[bs.Spec.BackupConfiguration.Name], bs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("expected BackupSession %s to be admitted: %v, found: %v", name, want, got)
+		}
+	}
+
+	// the stuck BackupSession does not hold a slot. so, the first one is admitted.
+	admit("p1", true)
+	// p1 is not observed running yet but it has reserved the last slot of the backend
+	admit("p2", false)
+	// the other backend has its own slots
+	admit("p3", true)
+	// re-processing an admitted BackupSession keeps its own slot
+	admit("p1", true)
+
+	// p1 has failed before it was observed running. so, its slot is released.
+	failed := pending["p1"].DeepCopy()
+	failed.Status.Phase = api_v1beta1.BackupSessionFailed
+	if err := bsIndexer.Update(failed); err != nil {
+		t.Fatal(err)
+	}
+	admit("p2", true)
+
+	// p2 is observed running. so, it holds the slot through the lister instead of the reservation.
+	running := pending["p2"].DeepCopy()
+	running.Status.Phase = api_v1beta1.BackupSessionRunning
+	if err := bsIndexer.Update(running); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.pruneBackendReservations(); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := c.backendReservations["demo/p2"]; found {
+		t.Errorf("expected the reservation of the running BackupSession p2 to be released")
+	}
+	if err := bsIndexer.Delete(pending["p3"]); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.pruneBackendReservations(); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.backendReservations) != 0 {
+		t.Errorf("expected no reservation, found %v", c.backendReservations)
+	}
+}
@@ -35,6 +35,22 @@ type config struct {
 	ResyncPeriod            time.Duration
 	EnableValidatingWebhook bool
 	EnableMutatingWebhook   bool
+	// SnapshotIndexRefreshPeriod is the interval at which the in-memory snapshot index is refreshed.
+	// The index is disabled if it is zero.
+	SnapshotIndexRefreshPeriod time.Duration
+	// MaxConcurrentBackupsPerBackend is the maximum number of BackupSessions that can run at the same time
+	// against a backend. There is no limit if it is zero.
+	MaxConcurrentBackupsPerBackend int
+	// RepositoryProbeInterval is the interval at which the health of each Repository is probed.
+	// The Repositories are not probed if it is zero.
+	RepositoryProbeInterval time.Duration
+	// EnableLeaderElection runs the controller loops only in the replica that holds the Lease.
+	// The webhooks and the Snapshot API are served by every replica.
+	EnableLeaderElection        bool
+	LeaderElectionNamespace     string
+	LeaderElectionLeaseDuration time.Duration
+	LeaderElectionRenewDeadline time.Duration
+	LeaderElectionRetryPeriod   time.Duration
 }
 
 type Config struct {
@@ -133,6 +149,8 @@ func (c *Config) New() (*StashController, error) {
 
 	// init v1beta1 resources watcher
 	ctrl.initBackupConfigurationWatcher()
+	// BackupBlueprint watcher watches the generated Repositories and BackupConfigurations too. so, it must be initialized after them.
+	ctrl.initBackupBlueprintWatcher()
 	ctrl.initBackupSessionWatcher()
 	ctrl.initRestoreSessionWatcher()
 
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/appscode/go/log"
 	"github.com/golang/glog"
@@ -12,6 +13,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	apps_listers "k8s.io/client-go/listers/apps/v1"
 	batch_listers "k8s.io/client-go/listers/batch/v1"
+	batch_v1beta1_listers "k8s.io/client-go/listers/batch/v1beta1"
 	core_listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
@@ -44,6 +46,8 @@ type StashController struct {
 	crdClient        crd_cs.ApiextensionsV1beta1Interface
 	appCatalogClient appcatalog_cs.Interface
 	recorder         record.EventRecorder
+	// leading is 1 while this replica holds the leader election Lease
+	leading int32
 
 	kubeInformerFactory       informers.SharedInformerFactory
 	ocInformerFactory         oc_informers.SharedInformerFactory
@@ -67,6 +71,10 @@ type StashController struct {
 	repoQueue    *queue.Worker
 	repoInformer cache.SharedIndexInformer
 	repoLister   stash_listers.RepositoryLister
+	// removes the locks held by dead processes from the Repositories
+	repoLockQueue *queue.Worker
+	// probes the health of the Repositories
+	repoProbeQueue *queue.Worker
 
 	// Deployment
 	dpQueue    *queue.Worker
@@ -108,6 +116,15 @@ type StashController struct {
 	jobInformer cache.SharedIndexInformer
 	jobLister   batch_listers.JobLister
 
+	// CronJob
+	cronJobInformer cache.SharedIndexInformer
+	cronJobLister   batch_v1beta1_listers.CronJobLister
+
+	// BackupBlueprint
+	bbQueue    *queue.Worker
+	bbInformer cache.SharedIndexInformer
+	bbLister   stash_listers_v1beta1.BackupBlueprintLister
+
 	// BackupConfiguration
 	bcQueue    *queue.Worker
 	bcInformer cache.SharedIndexInformer
@@ -116,6 +133,10 @@ type StashController struct {
 	backupSessionQueue    *queue.Worker
 	backupSessionInformer cache.SharedIndexInformer
 	backupSessionLister   stash_listers_v1beta1.BackupSessionLister
+	// serializes the admission of the BackupSessions against the backend concurrency limit
+	backendLock sync.Mutex
+	// backends of the admitted BackupSessions that are not observed running yet, keyed by BackupSession
+	backendReservations map[string]backendReservation
 
 	// RestoreSession
 	restoreSessionQueue    *queue.Worker
@@ -207,6 +228,19 @@ func (c *StashController) RunInformers(stopCh <-chan struct{}) {
 		}
 	}
 
+	// the informers keep running in every replica as the webhooks and the Snapshot API read from the listers.
+	// only the leader processes the queues.
+	if c.EnableLeaderElection {
+		c.runWithLeaderElection(stopCh, c.runQueues)
+	} else {
+		c.runQueues(stopCh)
+	}
+
+	<-stopCh
+	log.Infoln("Stopping Stash controller")
+}
+
+func (c *StashController) runQueues(stopCh <-chan struct{}) {
 	// start workload queue
 	c.dpQueue.Run(stopCh)
 	c.dsQueue.Run(stopCh)
@@ -225,14 +259,14 @@ func (c *StashController) RunInformers(stopCh <-chan struct{}) {
 
 	// start v1alpha1 resources queue
 	c.repoQueue.Run(stopCh)
+	c.repoLockQueue.Run(stopCh)
+	c.repoProbeQueue.Run(stopCh)
 	c.rstQueue.Run(stopCh)
 	c.recQueue.Run(stopCh)
 
 	// start v1beta1 resources queue
+	c.bbQueue.Run(stopCh)
 	c.bcQueue.Run(stopCh)
 	c.backupSessionQueue.Run(stopCh)
 	c.restoreSessionQueue.Run(stopCh)
-
-	<-stopCh
-	log.Infoln("Stopping Stash controller")
 }
@@ -30,11 +30,19 @@ func (c *StashController) ensureRestoreInitContainer(w *wapi.Workload, rs *api_v
 		if err != nil {
 			return err
 		}
+		var hooks []*api_v1beta1.Hook
+		if rs.Spec.Hooks != nil {
+			hooks = []*api_v1beta1.Hook{rs.Spec.Hooks.PreRestore, rs.Spec.Hooks.PostRestore}
+		}
+		err = stash_rbac.EnsureHookExecutorRBAC(c.kubeClient, ref, stash_rbac.StashRestoreInitContainer, sa, rs.OffshootLabels(), hooks...)
+		if err != nil {
+			return err
+		}
 	}
 
-	repository, err := c.stashClient.StashV1alpha1().Repositories(rs.Namespace).Get(rs.Spec.Repository.Name, metav1.GetOptions{})
+	repository, err := c.getRestoreRepository(rs, caller)
 	if err != nil {
-		log.Errorf("unable to get repository %s/%s: Reason: %v", rs.Namespace, rs.Spec.Repository.Name, err)
+		log.Errorf("unable to get repository %s/%s: Reason: %v", rs.RepositoryNamespace(), rs.Spec.Repository.Name, err)
 		return err
 	}
 
@@ -43,10 +51,19 @@ func (c *StashController) ensureRestoreInitContainer(w *wapi.Workload, rs *api_v
 		return err
 	}
 
-	// check if secret exist
-	_, err = c.kubeClient.CoreV1().Secrets(w.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
-	if err != nil {
-		return err
+	// check if secret exist. for the Repository of a different namespace, getRestoreRepository has checked the
+	// source secret and the controller copies it into the namespace of the workload.
+	if repository.Namespace == w.Namespace {
+		_, err = c.kubeClient.CoreV1().Secrets(w.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+	}
+	if caller != util.CallerWebhook {
+		err = c.ensureRestoreRepositoryReader(rs, repository, sa)
+		if err != nil {
+			return err
+		}
 	}
 
 	if w.Spec.Template.Annotations == nil {
@@ -1,10 +1,16 @@
 package controller
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/appscode/go/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	core_util "kmodules.xyz/client-go/core/v1"
 	"stash.appscode.dev/stash/apis"
 	apiAlpha "stash.appscode.dev/stash/apis/stash/v1alpha1"
@@ -28,6 +34,9 @@ func (c *StashController) inputsForBackupConfig(backupConfig api.BackupConfigura
 	// always enable cache if nothing specified
 	inputs[apis.EnableCache] = strconv.FormatBool(!backupConfig.Spec.TempDir.DisableCaching)
 
+	// append inputs for bandwidth limit
+	inputs = core_util.UpsertMap(inputs, c.inputsForBandwidthLimit(backupConfig.Spec.BandwidthLimit))
+
 	// add PushgatewayURL as input
 	metricInputs := c.inputForMetrics(backupConfig.Name)
 	inputs = core_util.UpsertMap(inputs, metricInputs)
@@ -35,6 +44,57 @@ func (c *StashController) inputsForBackupConfig(backupConfig api.BackupConfigura
 	return inputs, nil
 }
 
+// inputsForBackupJob returns the inputs used to resolve the Task of a BackupConfiguration for a BackupSession.
+// The params of the Task in the BackupConfiguration take precedence over the implicit inputs.
+func (c *StashController) inputsForBackupJob(backupConfig *api.BackupConfiguration, repository *apiAlpha.Repository, backupSessionName string) (map[string]string, error) {
+	explicitInputs := make(map[string]string)
+	for _, param := range backupConfig.Spec.Task.Params {
+		explicitInputs[param.Name] = param.Value
+	}
+
+	repoInputs, err := c.inputsForRepository(repository)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve implicit inputs for Repository %s/%s, reason: %s", repository.Namespace, repository.Name, err)
+	}
+	bcInputs, err := c.inputsForBackupConfig(*backupConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve implicit inputs for BackupConfiguration %s/%s, reason: %s", backupConfig.Namespace, backupConfig.Name, err)
+	}
+
+	implicitInputs := core_util.UpsertMap(repoInputs, bcInputs)
+	implicitInputs[apis.Namespace] = backupConfig.Namespace
+	implicitInputs[apis.BackupSession] = backupSessionName
+	implicitInputs[apis.BackupTags] = strings.Join(util.SnapshotTags(*backupConfig, backupSessionName, c.getTargetAppVersion(backupConfig)), ",")
+	implicitInputs[apis.StatusSubresourceEnabled] = fmt.Sprint(apis.EnableStatusSubresource)
+
+	// the maintenance jobs of the Repository check and prune the repository. so, don't do it after backup.
+	if repository != nil && repository.Spec.Maintenance != nil {
+		maintenance := repository.Spec.Maintenance
+		implicitInputs[apis.SkipRepositoryCheck] = strconv.FormatBool(maintenance.Check != nil)
+		implicitInputs[apis.SkipRepositoryStats] = "true"
+		if maintenance.Prune != nil {
+			implicitInputs[apis.RetentionPrune] = "false"
+		}
+	}
+
+	return core_util.UpsertMap(explicitInputs, implicitInputs), nil // TODO: reverse priority ???
+}
+
+// getTargetAppVersion returns the version of the application if the target of the BackupConfiguration is an AppBinding.
+// The version is only used to tag the snapshots. So, an empty string is returned if the AppBinding can't be read.
+func (c *StashController) getTargetAppVersion(backupConfig *api.BackupConfiguration) string {
+	if c.appCatalogClient == nil || backupConfig.Spec.Target == nil ||
+		backupConfig.Spec.Target.Ref.Kind != apis.KindAppBinding {
+		return ""
+	}
+	ab, err := c.appCatalogClient.AppcatalogV1alpha1().AppBindings(backupConfig.Namespace).Get(backupConfig.Spec.Target.Ref.Name, metav1.GetOptions{})
+	if err != nil {
+		log.Warningf("failed to read AppBinding %s/%s to tag the snapshots with the application version. Reason: %v", backupConfig.Namespace, backupConfig.Spec.Target.Ref.Name, err)
+		return ""
+	}
+	return ab.Spec.Version
+}
+
 func (c *StashController) inputsForRestoreSession(restoreSession api.RestoreSession, host string) (map[string]string, error) {
 	// get inputs for target
 	inputs := c.inputsForRestoreTarget(restoreSession.Spec.Target)
@@ -45,10 +105,39 @@ func (c *StashController) inputsForRestoreSession(restoreSession api.RestoreSess
 	inputs[apis.SourceHostname] = restoreOptions.SourceHost
 	inputs[apis.RestorePaths] = strings.Join(restoreOptions.RestorePaths, ",")
 	inputs[apis.RestoreSnapshots] = strings.Join(restoreOptions.Snapshots, ",")
+	if restoreOptions.Destination != "" {
+		inputs[apis.RestoreDestination] = restoreOptions.Destination
+	}
+	if len(restoreOptions.PathMapping) > 0 {
+		mappings := make([]string, 0, len(restoreOptions.PathMapping))
+		for src, dst := range restoreOptions.PathMapping {
+			mappings = append(mappings, src+"="+dst)
+		}
+		sort.Strings(mappings)
+		inputs[apis.RestorePathMapping] = strings.Join(mappings, ",")
+	}
+	if len(restoreOptions.Includes) > 0 {
+		inputs[apis.RestoreIncludePatterns] = joinAsCSV(restoreOptions.Includes)
+	}
+	if len(restoreOptions.Excludes) > 0 {
+		inputs[apis.RestoreExcludePatterns] = joinAsCSV(restoreOptions.Excludes)
+	}
+	if restoreOptions.Before != nil {
+		inputs[apis.RestoreBefore] = restoreOptions.Before.UTC().Format(time.RFC3339)
+	}
+	if restoreOptions.After != nil {
+		inputs[apis.RestoreAfter] = restoreOptions.After.UTC().Format(time.RFC3339)
+	}
+	if len(restoreOptions.Tags) > 0 {
+		inputs[apis.RestoreTags] = strings.Join(restoreOptions.Tags, ",")
+	}
 
 	// always enable cache if nothing specified
 	inputs[apis.EnableCache] = strconv.FormatBool(!restoreSession.Spec.TempDir.DisableCaching)
 
+	// append inputs for bandwidth limit
+	inputs = core_util.UpsertMap(inputs, c.inputsForBandwidthLimit(restoreSession.Spec.BandwidthLimit))
+
 	// pass replicas field to function. if not set pass default 1.
 	replicas := int32(1)
 	if restoreSession.Spec.Target != nil && restoreSession.Spec.Target.Replicas != nil {
@@ -63,6 +152,20 @@ func (c *StashController) inputsForRestoreSession(restoreSession api.RestoreSess
 	return inputs, nil
 }
 
+func (c *StashController) inputsForBandwidthLimit(limit *api.BandwidthLimit) map[string]string {
+	inputs := make(map[string]string)
+	if limit == nil {
+		return inputs
+	}
+	if limit.LimitUpload > 0 {
+		inputs[apis.LimitUpload] = fmt.Sprintf("%d", limit.LimitUpload)
+	}
+	if limit.LimitDownload > 0 {
+		inputs[apis.LimitDownload] = fmt.Sprintf("%d", limit.LimitDownload)
+	}
+	return inputs
+}
+
 func (c *StashController) inputsForRepository(repository *apiAlpha.Repository) (inputs map[string]string, err error) {
 	inputs = make(map[string]string)
 	if repository == nil {
@@ -112,6 +215,36 @@ func (c *StashController) inputsForBackupTarget(target *api.BackupTarget) map[st
 		} else {
 			inputs[apis.TargetMountPath] = apis.StashDefaultMountPath
 		}
+
+		// append inputs for file filter
+		inputs = core_util.UpsertMap(inputs, c.inputsForFileFilter(target.FileFilter))
+	}
+	return inputs
+}
+
+func (c *StashController) inputsForFileFilter(filter apiAlpha.FileFilter) map[string]string {
+	inputs := make(map[string]string)
+
+	if len(filter.Includes) > 0 {
+		inputs[apis.TargetIncludePatterns] = joinAsCSV(filter.Includes)
+	}
+	if len(filter.Excludes) > 0 {
+		inputs[apis.TargetExcludePatterns] = joinAsCSV(filter.Excludes)
+	}
+	if len(filter.IExcludes) > 0 {
+		inputs[apis.TargetIExcludePatterns] = joinAsCSV(filter.IExcludes)
+	}
+	if len(filter.ExcludeFiles) > 0 {
+		inputs[apis.TargetExcludeFiles] = joinAsCSV(filter.ExcludeFiles)
+	}
+	if filter.ExcludeCaches {
+		inputs[apis.TargetExcludeCaches] = "true"
+	}
+	if len(filter.ExcludeIfPresent) > 0 {
+		inputs[apis.TargetExcludeIfPresent] = joinAsCSV(filter.ExcludeIfPresent)
+	}
+	if filter.OneFileSystem {
+		inputs[apis.TargetOneFileSystem] = "true"
 	}
 	return inputs
 }
@@ -171,3 +304,16 @@ func (c *StashController) inputForMetrics(jobName string) map[string]string {
 		apis.PrometheusJobName: jobName,
 	}
 }
+
+// joinAsCSV joins the values as a CSV record. The StringSlice flags of the Functions parse their values
+// as CSV. So, a value containing comma or quote is kept intact.
+func joinAsCSV(values []string) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(values); err != nil {
+		// writing into a bytes.Buffer does not fail
+		return strings.Join(values, ",")
+	}
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
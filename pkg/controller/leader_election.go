@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"github.com/appscode/go/log"
+	"github.com/prometheus/client_golang/prometheus"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"stash.appscode.dev/stash/pkg/eventer"
+)
+
+const (
+	// name of the Lease that is used to elect the leader among the replicas of the operator
+	leaderElectionLeaseName = "stash-operator"
+)
+
+var (
+	leaderGauge = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "stash_operator_leader",
+		Help: "Indicates whether this replica of the operator is the leader. 1 means it is the leader and 0 means it is not.",
+	})
+	leadershipTransitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "stash_operator_leadership_transitions_total",
+		Help: "Total number of times this replica of the operator has acquired or lost the leadership",
+	}, []string{"transition"})
+)
+
+func init() {
+	// the metrics are served by the /metrics endpoint of the operator from the default registry
+	prometheus.MustRegister(leaderGauge, leadershipTransitions)
+}
+
+// isLeader returns true if this replica of the operator runs the controller loops. It is always true if the
+// leader election is disabled.
+func (c *StashController) isLeader() bool {
+	return !c.EnableLeaderElection || atomic.LoadInt32(&c.leading) == 1
+}
+
+// runWithLeaderElection runs the controller loops only while this replica holds the Lease.
+// The queues can't be restarted once they have been shut down. So, the operator exits when
+// it loses the leadership and a new replica picks up the Lease after restart.
+func (c *StashController) runWithLeaderElection(stopCh <-chan struct{}, run func(stopCh <-chan struct{})) {
+	identity := os.Getenv("MY_POD_NAME")
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.Fatalf("failed to get the identity for leader election. Reason: %v", err)
+		}
+		identity = hostname
+	}
+	namespace := c.LeaderElectionNamespace
+	if namespace == "" {
+		namespace = os.Getenv("MY_POD_NAMESPACE")
+	}
+	if namespace == "" {
+		namespace = metav1.NamespaceSystem
+	}
+
+	lock, err := resourcelock.New(
+		resourcelock.LeasesResourceLock,
+		namespace,
+		leaderElectionLeaseName,
+		c.kubeClient.CoreV1(),
+		c.kubeClient.CoordinationV1(),
+		resourcelock.ResourceLockConfig{
+			Identity:      identity,
+			EventRecorder: c.recorder,
+		},
+	)
+	if err != nil {
+		log.Fatalf("failed to create the resource lock for leader election. Reason: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	leaderGauge.Set(0)
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:          lock,
+		LeaseDuration: c.LeaderElectionLeaseDuration,
+		RenewDeadline: c.LeaderElectionRenewDeadline,
+		RetryPeriod:   c.LeaderElectionRetryPeriod,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				atomic.StoreInt32(&c.leading, 1)
+				c.handleLeadershipChange(namespace, identity, true)
+				log.Infof("%s has acquired the leadership. Starting the controller loops.", identity)
+				run(ctx.Done())
+			},
+			OnStoppedLeading: func() {
+				wasLeading := atomic.SwapInt32(&c.leading, 0) == 1
+				if wasLeading {
+					c.handleLeadershipChange(namespace, identity, false)
+				}
+				select {
+				case <-stopCh:
+					log.Infof("%s has released the leadership", identity)
+				default:
+					log.Fatalf("%s has lost the leadership. Exiting so that the controller loops are restarted.", identity)
+				}
+			},
+			OnNewLeader: func(leader string) {
+				if leader != identity {
+					log.Infof("%s is the leader of the operator", leader)
+				}
+			},
+		},
+	})
+}
+
+// handleLeadershipChange writes an event to the pod of the operator and updates the leader metrics
+// when this replica acquires or loses the leadership.
+func (c *StashController) handleLeadershipChange(namespace, identity string, leading bool) {
+	transition := "lost"
+	if leading {
+		transition = "acquired"
+		leaderGauge.Set(1)
+	} else {
+		leaderGauge.Set(0)
+	}
+	leadershipTransitions.WithLabelValues(transition).Inc()
+
+	pod, err := c.kubeClient.CoreV1().Pods(namespace).Get(identity, metav1.GetOptions{})
+	if err != nil {
+		// the operator is not running inside a pod of this namespace
+		return
+	}
+	if leading {
+		_, _ = eventer.CreateEvent(
+			c.kubeClient,
+			eventer.EventSourceLeaderElection,
+			pod,
+			core.EventTypeNormal,
+			eventer.EventReasonLeadershipAcquired,
+			fmt.Sprintf("%s has acquired the Lease %s/%s", identity, namespace, leaderElectionLeaseName),
+		)
+	} else {
+		_, _ = eventer.CreateEvent(
+			c.kubeClient,
+			eventer.EventSourceLeaderElection,
+			pod,
+			core.EventTypeWarning,
+			eventer.EventReasonLeadershipLost,
+			fmt.Sprintf("%s has lost the Lease %s/%s", identity, namespace, leaderElectionLeaseName),
+		)
+	}
+}
@@ -1,8 +1,10 @@
 package controller
 
 import (
+	"reflect"
 	"time"
 
+	"github.com/golang/glog"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -21,7 +23,30 @@ func (c *StashController) initNamespaceWatcher() {
 		)
 	})
 	c.nsInformer.AddEventHandler(&cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			// the targets of a new namespace may have been processed before the namespace was observed. so, they have
+			// not been matched with the namespace selector of the BackupBlueprints yet.
+			if ns, ok := obj.(*core.Namespace); ok {
+				if err := c.enqueueTargetsOfNamespace(ns.Name); err != nil {
+					glog.Errorf("failed to enqueue the targets of namespace %s. Reason: %v", ns.Name, err)
+				}
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNs, ok1 := oldObj.(*core.Namespace)
+			newNs, ok2 := newObj.(*core.Namespace)
+			// the targets of the namespace may be selected or unselected by the namespace selector of a BackupBlueprint
+			if ok1 && ok2 && !reflect.DeepEqual(oldNs.Labels, newNs.Labels) {
+				if err := c.enqueueTargetsOfNamespace(newNs.Name); err != nil {
+					glog.Errorf("failed to enqueue the targets of namespace %s. Reason: %v", newNs.Name, err)
+				}
+			}
+		},
 		DeleteFunc: func(obj interface{}) {
+			// only the leader deletes the Restics of a deleted namespace
+			if !c.isLeader() {
+				return
+			}
 			if ns, ok := obj.(*core.Namespace); ok {
 				items, err := c.rstLister.Restics(ns.Name).List(labels.Everything())
 				if err == nil {
@@ -59,9 +59,18 @@ func (c *StashController) applyBackupAnnotationLogicForPVC(pvc *core.PersistentV
 	}
 
 	// if pvc has backup annotations then ensure respective Repository and BackupConfiguration
+	var backupBlueprintName string
 	if meta_util.HasKey(pvc.Annotations, api_v1beta1.KeyBackupBlueprint) {
-		// backup annotations found. so, we have to ensure Repository and BackupConfiguration from BackupBlueprint
-		verb, err := c.ensureAutoBackupResourcesForPVC(pvc, targetRef)
+		backupBlueprintName = pvc.Annotations[api_v1beta1.KeyBackupBlueprint]
+	} else {
+		backupBlueprintName, err = c.selectBackupBlueprint(targetRef, pvc.Labels, pvc.Annotations)
+		if err != nil {
+			return c.handleAutoBackupResourcesCreationFailure(targetRef, err)
+		}
+	}
+	if backupBlueprintName != "" {
+		// backup annotations found or a BackupBlueprint selects it. so, we have to ensure Repository and BackupConfiguration from BackupBlueprint
+		verb, err := c.ensureAutoBackupResourcesForPVC(pvc, targetRef, backupBlueprintName)
 		if err != nil {
 			return c.handleAutoBackupResourcesCreationFailure(targetRef, err)
 		}
@@ -69,7 +78,7 @@ func (c *StashController) applyBackupAnnotationLogicForPVC(pvc *core.PersistentV
 			return c.handleAutoBackupResourcesCreationSuccess(targetRef)
 		}
 	} else {
-		// pvc does not have backup annotations. it might be removed or was never added.
+		// pvc does not have backup annotations and no BackupBlueprint selects it. it might be removed or was never added.
 		// if respective BackupConfiguration exist then backup annotations has been removed.
 		// in this case, we have to remove the BackupConfiguration too.
 		// however, we will keep Repository crd as it is required for restore.
@@ -84,18 +93,16 @@ func (c *StashController) applyBackupAnnotationLogicForPVC(pvc *core.PersistentV
 	return nil
 }
 
-func (c *StashController) ensureAutoBackupResourcesForPVC(pvc *core.PersistentVolumeClaim, targetRef *core.ObjectReference) (kutil.VerbType, error) {
-
-	backupBlueprintName, err := meta_util.GetStringValue(pvc.Annotations, api_v1beta1.KeyBackupBlueprint)
-	if err != nil {
-		return kutil.VerbUnchanged, err
-	}
+func (c *StashController) ensureAutoBackupResourcesForPVC(pvc *core.PersistentVolumeClaim, targetRef *core.ObjectReference, backupBlueprintName string) (kutil.VerbType, error) {
 
 	backupBlueprint, err := c.stashClient.StashV1beta1().BackupBlueprints().Get(backupBlueprintName, metav1.GetOptions{})
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
 
+	// take the hash before the variables are resolved
+	blueprintHash := backupBlueprint.GetSpecHash()
+
 	// resolve BackupBlueprint's variables
 	inputs := make(map[string]string)
 	inputs[apis.TargetAPIVersion] = pvc.APIVersion
@@ -109,14 +116,14 @@ func (c *StashController) ensureAutoBackupResourcesForPVC(pvc *core.PersistentVo
 	}
 
 	// ensure Repository crd
-	verb1, err := c.ensureRepository(backupBlueprint, targetRef, targetRef.Kind)
+	verb1, err := c.ensureRepository(backupBlueprint, blueprintHash, targetRef, targetRef.Kind)
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
 
 	// ensure BackupConfiguration crd. For stand-alone PVC backup, we don't need to specify target paths and volumeMounts.
 	// Stash will use default target path and mount path.
-	verb2, err := c.ensureBackupConfiguration(backupBlueprint, nil, nil, targetRef, targetRef.Kind)
+	verb2, err := c.ensureBackupConfiguration(backupBlueprint, blueprintHash, nil, nil, targetRef, targetRef.Kind)
 	if err != nil {
 		return kutil.VerbUnchanged, err
 	}
@@ -60,7 +60,7 @@ func (c *StashController) initRecoveryWatcher() {
 			if r, ok := obj.(*api.Recovery); ok {
 				if err := r.IsValid(); err != nil {
 					ref, rerr := reference.GetReference(scheme.Scheme, r)
-					if rerr == nil {
+					if rerr == nil && c.isLeader() {
 						c.recorder.Eventf(
 							ref,
 							core.EventTypeWarning,
@@ -87,7 +87,7 @@ func (c *StashController) initRecoveryWatcher() {
 			}
 			if err := newRes.IsValid(); err != nil {
 				ref, rerr := reference.GetReference(scheme.Scheme, newRes)
-				if rerr == nil {
+				if rerr == nil && c.isLeader() {
 					c.recorder.Eventf(
 						ref,
 						core.EventTypeWarning,
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"reflect"
+
 	"github.com/golang/glog"
 	"gomodules.xyz/stow"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -41,8 +43,21 @@ func (c *StashController) NewRepositoryWebhook() hooks.AdmissionHook {
 func (c *StashController) initRepositoryWatcher() {
 	c.repoInformer = c.stashInformerFactory.Stash().V1alpha1().Repositories().Informer()
 	c.repoQueue = queue.New("Repository", c.MaxNumRequeues, c.NumThreads, c.runRepositoryReconciler)
-	c.repoInformer.AddEventHandler(queue.DefaultEventHandler(c.repoQueue.GetQueue()))
+	// the status updates of the Repository (i.e. by the probes) don't need to be reconciled. the periodic resyncs are still reconciled.
+	c.repoInformer.AddEventHandler(queue.NewEventHandler(c.repoQueue.GetQueue(), func(oldObj, newObj interface{}) bool {
+		oldRepo := oldObj.(*api.Repository)
+		newRepo := newObj.(*api.Repository)
+		return oldRepo.ResourceVersion == newRepo.ResourceVersion ||
+			newRepo.DeletionTimestamp != nil ||
+			!reflect.DeepEqual(oldRepo.Spec, newRepo.Spec) ||
+			!reflect.DeepEqual(oldRepo.Labels, newRepo.Labels) ||
+			!reflect.DeepEqual(oldRepo.Annotations, newRepo.Annotations) ||
+			!reflect.DeepEqual(oldRepo.Finalizers, newRepo.Finalizers)
+	}))
 	c.repoLister = c.stashInformerFactory.Stash().V1alpha1().Repositories().Lister()
+	c.initRepositoryCronJobLister()
+	c.initRepositoryLockQueue()
+	c.initRepositoryProbeQueue()
 }
 
 func (c *StashController) runRepositoryReconciler(key string) error {
@@ -79,7 +94,19 @@ func (c *StashController) runRepositoryReconciler(key string) error {
 				in.ObjectMeta = core_util.AddFinalizer(in.ObjectMeta, util.RepositoryFinalizer)
 				return in
 			})
-			return err
+			if err != nil {
+				return err
+			}
+			// ignore invalid repository objects (eg: created by xray).
+			if repo.IsValid() == nil {
+				if err := c.ensureRepositoryMaintenanceCronJobs(repo); err != nil {
+					return c.handleRepositoryMaintenanceFailure(repo, err)
+				}
+				if err := c.ensureRepositoryCopyCronJobs(repo); err != nil {
+					return c.handleRepositoryMaintenanceFailure(repo, err)
+				}
+				c.enqueueRepositoryProbe(repo, key)
+			}
 		}
 	}
 	return nil
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"syscall"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/golang/glog"
+	"gomodules.xyz/stow"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/cache"
+	"kmodules.xyz/client-go/tools/queue"
+	"kmodules.xyz/objectstore-api/osm"
+	"stash.appscode.dev/stash/apis"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1alpha1/util"
+	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+// hasLockFailure returns true if any host of the BackupSession has failed because the repository was locked
+func hasLockFailure(backupSession *api_v1beta1.BackupSession) bool {
+	for _, host := range backupSession.Status.Stats {
+		if host.Phase == api_v1beta1.HostBackupFailed && restic.IsLockErrorMessage(host.Error) {
+			return true
+		}
+	}
+	return false
+}
+
+// staleLockMinAge is the minimum age of a lock before its holder is checked. restic refreshes the locks of
+// a running process every 5 minutes. So, a lock older than two refresh intervals has not been refreshed.
+const staleLockMinAge = 10 * time.Minute
+
+func (c *StashController) initRepositoryLockQueue() {
+	c.repoLockQueue = queue.New("RepositoryLock", c.MaxNumRequeues, c.NumThreads, c.runStaleLockRecovery)
+}
+
+// recoverStaleLocks enqueues the Repository used by the BackupSession so that the locks held by dead processes
+// (i.e. a backup pod killed by OOM killer) are removed outside of the BackupSession worker.
+func (c *StashController) recoverStaleLocks(backupSession *api_v1beta1.BackupSession) {
+	backupConfig, err := c.bcLister.BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name)
+	if err != nil {
+		log.Warningf("failed to recover stale locks for BackupSession %s/%s. Reason: %v", backupSession.Namespace, backupSession.Name, err)
+		return
+	}
+	queue.Enqueue(c.repoLockQueue.GetQueue(), &api_v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      backupConfig.Spec.Repository.Name,
+			Namespace: backupConfig.Namespace,
+		},
+	})
+}
+
+// runStaleLockRecovery removes the locks of the Repository that are held by dead processes. Then, it records the
+// remaining locks in the Repository status. It only writes an event on failure as the BackupSession will be retried anyway.
+func (c *StashController) runStaleLockRecovery(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	repository, err := c.repoLister.Repositories(namespace).Get(name)
+	if err != nil {
+		if kerr.IsNotFound(err) {
+			glog.Warningf("Repository %s does not exist anymore\n", key)
+			return nil
+		}
+		return err
+	}
+
+	if err := c.removeStaleLocks(repository); err != nil {
+		log.Warningf("failed to recover stale locks of Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err)
+		_, _ = eventer.CreateEvent(
+			c.kubeClient,
+			eventer.EventSourceRepositoryController,
+			repository,
+			core.EventTypeWarning,
+			eventer.EventReasonRepositoryLockRecoveryFailed,
+			fmt.Sprintf("failed to recover stale locks. Reason: %v", err),
+		)
+	}
+	return nil
+}
+
+func (c *StashController) removeStaleLocks(repository *api_v1alpha1.Repository) error {
+	tempDir, err := ioutil.TempDir("", "stash")
+	if err != nil {
+		return err
+	}
+	// cleanup whole tempDir dir at the end
+	defer os.RemoveAll(tempDir)
+
+	resticWrapper, err := c.newResticWrapperForRepository(repository, tempDir)
+	if err != nil {
+		return err
+	}
+	locks, err := resticWrapper.ListLocks()
+	if err != nil {
+		return err
+	}
+
+	removed, err := resticWrapper.RemoveDeadLocks(locks, c.isLockHolderDead, c.lockRemover(repository))
+	for _, lock := range removed {
+		_, _ = eventer.CreateEvent(
+			c.kubeClient,
+			eventer.EventSourceRepositoryController,
+			repository,
+			core.EventTypeWarning,
+			eventer.EventReasonRepositoryLockRemoved,
+			fmt.Sprintf("removed stale lock %s held by PID %d on host %s since %s. Reason: the process that holds the lock does not exist anymore.",
+				lock.ID, lock.PID, lock.Hostname, lock.Time.Format(metav1.RFC3339Micro)),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	// record the remaining locks in the Repository status
+	if len(removed) > 0 {
+		locks, err = resticWrapper.ListLocks()
+		if err != nil {
+			return err
+		}
+	}
+	now := metav1.Now()
+	_, err = stash_util.UpdateRepositoryStatus(c.stashClient.StashV1alpha1(), repository, func(in *api_v1alpha1.RepositoryStatus) *api_v1alpha1.RepositoryStatus {
+		in.Locks = repositoryLocks(locks)
+		in.LastLockCheckTime = &now
+		return in
+	}, apis.EnableStatusSubresource)
+	return err
+}
+
+// isLockHolderDead returns true if the process that holds the lock is known to be dead. restic uses the hostname
+// of the process as the hostname of the lock and the hostname of a pod is the name of the pod. So, the holder of
+// a lock that has not been refreshed is dead if its PID does not exist on this host, or if no running pod with
+// that name exists in any namespace.
+func (c *StashController) isLockHolderDead(lock restic.Lock) (bool, error) {
+	if time.Since(lock.Time) < staleLockMinAge {
+		return false, nil
+	}
+
+	if hostname, err := os.Hostname(); err == nil && hostname == lock.Hostname {
+		// the lock has been created by a restic process of the operator itself
+		process, err := os.FindProcess(lock.PID)
+		if err != nil {
+			return true, nil
+		}
+		return process.Signal(syscall.Signal(0)) != nil, nil
+	}
+
+	pods, err := c.kubeClient.CoreV1().Pods(core.NamespaceAll).List(metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("metadata.name", lock.Hostname).String(),
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != core.PodSucceeded && pod.Status.Phase != core.PodFailed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// lockRemover returns a function that removes a lock file from the backend of the Repository. It returns nil
+// if the lock files can't be removed directly (i.e. local and rest backends). Then, only the locks that restic
+// considers stale are removed.
+func (c *StashController) lockRemover(repository *api_v1alpha1.Repository) func(lock restic.Lock) error {
+	if repository.Spec.Backend.Local != nil || repository.Spec.Backend.Rest != nil {
+		return nil
+	}
+	cfg, err := osm.NewOSMContext(c.kubeClient, repository.Spec.Backend, repository.Namespace)
+	if err != nil {
+		log.Warningf("failed to connect to the backend of Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err)
+		return nil
+	}
+	loc, err := stow.Dial(cfg.Provider, cfg.Config)
+	if err != nil {
+		log.Warningf("failed to connect to the backend of Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err)
+		return nil
+	}
+	bucket, prefix, err := util.GetBucketAndPrefix(&repository.Spec.Backend)
+	if err != nil {
+		log.Warningf("failed to connect to the backend of Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err)
+		return nil
+	}
+	container, err := loc.Container(bucket)
+	if err != nil {
+		log.Warningf("failed to connect to the backend of Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err)
+		return nil
+	}
+	return func(lock restic.Lock) error {
+		return container.RemoveItem(path.Join(prefix, "locks", lock.ID))
+	}
+}
+
+// newResticWrapperForRepository configures a restic wrapper for the Repository in the given directory
+func (c *StashController) newResticWrapperForRepository(repository *api_v1alpha1.Repository, dir string) (*restic.ResticWrapper, error) {
+	return util.NewResticWrapperForRepository(c.kubeClient, *repository, dir)
+}
+
+func repositoryLocks(locks []restic.Lock) []api_v1alpha1.RepositoryLock {
+	var result []api_v1alpha1.RepositoryLock
+	for _, lock := range locks {
+		result = append(result, api_v1alpha1.RepositoryLock{
+			ID:           lock.ID,
+			Hostname:     lock.Hostname,
+			PID:          lock.PID,
+			Exclusive:    lock.Exclusive,
+			CreationTime: metav1.NewTime(lock.Time),
+		})
+	}
+	return result
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/appscode/go/types"
+	batch_v1beta1 "k8s.io/api/batch/v1beta1"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	batch_v1beta1_informers "k8s.io/client-go/informers/batch/v1beta1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/reference"
+	batch_util "kmodules.xyz/client-go/batch/v1beta1"
+	core_util "kmodules.xyz/client-go/core/v1"
+	"stash.appscode.dev/stash/apis"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
+	"stash.appscode.dev/stash/pkg/docker"
+	"stash.appscode.dev/stash/pkg/eventer"
+	stash_rbac "stash.appscode.dev/stash/pkg/rbac"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	RepositoryMaintainerPrefix = "stash-maintainer-"
+)
+
+// initRepositoryCronJobLister watches the CronJobs created by Stash so that the Repository reconciler can find
+// the maintenance and copy CronJobs to remove without calling the API server on every reconcile.
+func (c *StashController) initRepositoryCronJobLister() {
+	c.cronJobInformer = c.kubeInformerFactory.InformerFor(&batch_v1beta1.CronJob{}, func(client kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+		return batch_v1beta1_informers.NewFilteredCronJobInformer(
+			client,
+			core.NamespaceAll,
+			resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+			func(options *metav1.ListOptions) {
+				options.LabelSelector = labels.SelectorFromSet(map[string]string{
+					util.LabelApp: util.AppLabelStash,
+				}).String()
+			},
+		)
+	})
+	c.cronJobLister = c.kubeInformerFactory.Batch().V1beta1().CronJobs().Lister()
+}
+
+// ensureRepositoryMaintenanceCronJobs creates a CronJob for each maintenance operation scheduled in the Repository and
+// removes the CronJobs of the operations that are not scheduled anymore. The CronJobs are owned by the Repository.
+// So, they are garbage collected when the Repository is deleted.
+func (c *StashController) ensureRepositoryMaintenanceCronJobs(repository *api_v1alpha1.Repository) error {
+	schedules := map[restic.MaintenanceOperation]string{}
+	if m := repository.Spec.Maintenance; m != nil {
+		if m.Check != nil {
+			schedules[restic.MaintenanceCheck] = m.Check.Schedule
+		}
+		if m.Prune != nil {
+			schedules[restic.MaintenancePrune] = m.Prune.Schedule
+		}
+		if m.KeyRotation != nil {
+			schedules[restic.MaintenanceRotateKey] = m.KeyRotation.Schedule
+		}
+	}
+
+	// remove the CronJobs of the operations that are not scheduled anymore. the lister is checked first so that
+	// the reconciles of the Repositories without maintenance don't call the API server.
+	for _, operation := range []restic.MaintenanceOperation{restic.MaintenanceCheck, restic.MaintenancePrune, restic.MaintenanceRotateKey} {
+		if _, scheduled := schedules[operation]; scheduled {
+			continue
+		}
+		name := getRepositoryMaintainerName(repository, operation)
+		if _, err := c.cronJobLister.CronJobs(repository.Namespace).Get(name); err != nil {
+			if kerr.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		err := c.kubeClient.BatchV1beta1().CronJobs(repository.Namespace).Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !kerr.IsNotFound(err) {
+			return err
+		}
+		// the RBAC of the key rotator is created along with its CronJob
+		if operation == restic.MaintenanceRotateKey {
+			if err := stash_rbac.EnsureRepositoryKeyRotatorRBACDeleted(c.kubeClient, repository.Namespace, repository.Name); err != nil {
+				return err
+			}
+		}
+	}
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	image := docker.Docker{
+		Registry: c.DockerRegistry,
+		Image:    docker.ImageStash,
+		Tag:      c.StashImageTag,
+	}
+
+	ref, err := reference.GetReference(stash_scheme.Scheme, repository)
+	if err != nil {
+		return err
+	}
+	offshootLabels := map[string]string{
+		util.LabelApp: util.AppLabelStash,
+	}
+	serviceAccountName, err := c.ensureRepositoryMaintainerServiceAccount(repository, ref, offshootLabels)
+	if err != nil {
+		return err
+	}
+	if _, scheduled := schedules[restic.MaintenanceRotateKey]; scheduled {
+		// key rotation job has to write the new password in the storage secret
+		err = stash_rbac.EnsureRepositoryKeyRotatorRBAC(c.kubeClient, ref, serviceAccountName, repository.Spec.Backend.StorageSecretName, offshootLabels)
+		if err != nil {
+			return err
+		}
+	}
+
+	for operation, schedule := range schedules {
+		meta := metav1.ObjectMeta{
+			Name:      getRepositoryMaintainerName(repository, operation),
+			Namespace: repository.Namespace,
+			Labels:    offshootLabels,
+		}
+		jobTemplate := util.NewRepositoryMaintenanceJob(repository, operation, image)
+		jobTemplate.Spec.ServiceAccountName = serviceAccountName
+
+		_, _, err = batch_util.CreateOrPatchCronJob(c.kubeClient, meta, func(in *batch_v1beta1.CronJob) *batch_v1beta1.CronJob {
+			// set Repository as owner of this CronJob
+			core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+
+			in.Spec.Schedule = schedule
+			in.Spec.Suspend = types.BoolP(repository.Spec.Maintenance.Paused)
+			// maintenance operations hold an exclusive lock. so, don't start a new one while the previous one is running.
+			in.Spec.ConcurrencyPolicy = batch_v1beta1.ForbidConcurrent
+			in.Spec.FailedJobsHistoryLimit = types.Int32P(1)
+
+			in.Spec.JobTemplate.Labels = core_util.UpsertMap(in.Spec.JobTemplate.Labels, offshootLabels)
+			// ensure that job gets deleted on completion
+			in.Spec.JobTemplate.Labels[apis.KeyDeleteJobOnCompletion] = "true"
+			// the result of a failed maintenance is recorded in the Repository. so, don't retry.
+			in.Spec.JobTemplate.Spec.BackoffLimit = types.Int32P(0)
+			in.Spec.JobTemplate.Spec.Template.Spec = jobTemplate.Spec
+			return in
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleRepositoryMaintenanceFailure writes an event to the Repository and returns the error so that
+// the Repository is requeued.
+func (c *StashController) handleRepositoryMaintenanceFailure(repository *api_v1alpha1.Repository, err error) error {
+	log.Warningf("failed to ensure maintenance or copy CronJobs for Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err)
+
+	// write event to Repository
+	_, _ = eventer.CreateEvent(
+		c.kubeClient,
+		eventer.EventSourceRepositoryController,
+		repository,
+		core.EventTypeWarning,
+		eventer.EventReasonCronJobCreationFailed,
+		fmt.Sprintf("failed to ensure maintenance or copy CronJobs for Repository %s/%s. Reason: %v", repository.Namespace, repository.Name, err),
+	)
+	return err
+}
+
+// ensureRepositoryMaintainerServiceAccount ensures the ServiceAccount and the RBAC resources shared by
+// the maintenance and the copy jobs of a Repository. It returns the name of the ServiceAccount.
+func (c *StashController) ensureRepositoryMaintainerServiceAccount(repository *api_v1alpha1.Repository, ref *core.ObjectReference, labels map[string]string) (string, error) {
+	serviceAccountName := RepositoryMaintainerPrefix + strings.ReplaceAll(repository.Name, ".", "-")
+	saMeta := metav1.ObjectMeta{
+		Name:      serviceAccountName,
+		Namespace: repository.Namespace,
+	}
+	_, _, err := core_util.CreateOrPatchServiceAccount(c.kubeClient, saMeta, func(in *core.ServiceAccount) *core.ServiceAccount {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+		in.Labels = labels
+		return in
+	})
+	if err != nil {
+		return "", err
+	}
+	err = stash_rbac.EnsureRepositoryMaintainerRBAC(c.kubeClient, ref, serviceAccountName, []string{DefaultBackupJobPSPName}, labels)
+	if err != nil {
+		return "", err
+	}
+	return serviceAccountName, nil
+}
+
+func getRepositoryMaintainerName(repository *api_v1alpha1.Repository, operation restic.MaintenanceOperation) string {
+	return fmt.Sprintf("%s%s-%s", RepositoryMaintainerPrefix, operation, strings.ReplaceAll(repository.Name, ".", "-"))
+}
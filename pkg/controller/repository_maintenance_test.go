@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	batch_v1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	batch_v1beta1_listers "k8s.io/client-go/listers/batch/v1beta1"
+	"k8s.io/client-go/tools/cache"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	"stash.appscode.dev/stash/pkg/restic"
+)
+
+func TestEnsureRepositoryMaintenanceCronJobs(t *testing.T) {
+	repository := &api_v1alpha1.Repository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "minio-repo",
+			Namespace: "demo",
+			SelfLink:  "/apis/stash.appscode.com/v1alpha1/namespaces/demo/repositories/minio-repo",
+		},
+	}
+	cronJob := func(operation restic.MaintenanceOperation) *batch_v1beta1.CronJob {
+		return &batch_v1beta1.CronJob{
+			ObjectMeta: metav1.ObjectMeta{Name: getRepositoryMaintainerName(repository, operation), Namespace: "demo"},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		maintenance *api_v1alpha1.RepositoryMaintenance
+		// CronJobs known to the API server and to the lister
+		existing []restic.MaintenanceOperation
+		// CronJobs known to the API server only
+		unobserved []restic.MaintenanceOperation
+		want       []restic.MaintenanceOperation
+	}{
+		{
+			name: "CronJobs are created for the scheduled operations",
+			maintenance: &api_v1alpha1.RepositoryMaintenance{
+				Check: &api_v1alpha1.RepositoryCheck{Schedule: "@daily"},
+				Prune: &api_v1alpha1.RepositoryPrune{Schedule: "@weekly"},
+			},
+			want: []restic.MaintenanceOperation{restic.MaintenanceCheck, restic.MaintenancePrune},
+		},
+		{
+			name: "CronJobs of the operations that are not scheduled anymore are deleted",
+			maintenance: &api_v1alpha1.RepositoryMaintenance{
+				Check: &api_v1alpha1.RepositoryCheck{Schedule: "@daily"},
+			},
+			existing: []restic.MaintenanceOperation{restic.MaintenancePrune, restic.MaintenanceRotateKey},
+			want:     []restic.MaintenanceOperation{restic.MaintenanceCheck},
+		},
+		{
+			name:     "CronJobs are deleted when the maintenance is removed",
+			existing: []restic.MaintenanceOperation{restic.MaintenanceCheck, restic.MaintenancePrune},
+		},
+		{
+			name:       "CronJobs not observed by the lister are not deleted",
+			unobserved: []restic.MaintenanceOperation{restic.MaintenancePrune},
+			want:       []restic.MaintenanceOperation{restic.MaintenancePrune},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset()
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, operation := range append(tc.existing, tc.unobserved...) {
+				if _, err := kubeClient.BatchV1beta1().CronJobs("demo").Create(cronJob(operation)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, operation := range tc.existing {
+				if err := indexer.Add(cronJob(operation)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			c := &StashController{
+				kubeClient:    kubeClient,
+				cronJobLister: batch_v1beta1_listers.NewCronJobLister(indexer),
+			}
+
+			repo := repository.DeepCopy()
+			repo.Spec.Maintenance = tc.maintenance
+			if err := c.ensureRepositoryMaintenanceCronJobs(repo); err != nil {
+				t.Fatal(err)
+			}
+
+			cronJobs, err := kubeClient.BatchV1beta1().CronJobs("demo").List(metav1.ListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var found, want []string
+			for _, cj := range cronJobs.Items {
+				found = append(found, cj.Name)
+			}
+			for _, operation := range tc.want {
+				want = append(want, getRepositoryMaintainerName(repository, operation))
+			}
+			sort.Strings(found)
+			sort.Strings(want)
+			if strings.Join(found, ",") != strings.Join(want, ",") {
+				t.Errorf("expected CronJobs %v, found %v", want, found)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package controller
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/golang/glog"
+	"gomodules.xyz/stow"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"kmodules.xyz/client-go/tools/queue"
+	store "kmodules.xyz/objectstore-api/api/v1"
+	"kmodules.xyz/objectstore-api/osm"
+	"stash.appscode.dev/stash/apis"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	stash_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1alpha1/util"
+	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	PromJobRepositoryController = "stash-repository-controller"
+	// maximum time to wait for restic to open the repository during a probe
+	repositoryProbeTimeout = 2 * time.Minute
+)
+
+func (c *StashController) initRepositoryProbeQueue() {
+	// the probes run restic with a timeout in a separate queue so that an unreachable backend does not block the reconciliation of the Repositories
+	c.repoProbeQueue = queue.New("RepositoryProbe", c.MaxNumRequeues, c.NumThreads, c.runRepositoryProbe)
+}
+
+// enqueueRepositoryProbe enqueues the Repository into the probe queue when it is due to be probed
+func (c *StashController) enqueueRepositoryProbe(repository *api_v1alpha1.Repository, key string) {
+	if c.RepositoryProbeInterval <= 0 {
+		return
+	}
+	if lastProbe := repository.Status.LastProbeTime; lastProbe != nil && time.Since(lastProbe.Time) < c.RepositoryProbeInterval {
+		c.repoProbeQueue.GetQueue().AddAfter(key, c.RepositoryProbeInterval-time.Since(lastProbe.Time))
+		return
+	}
+	c.repoProbeQueue.GetQueue().Add(key)
+}
+
+// runRepositoryProbe probes the health of the Repository if it has not been probed within the probe interval.
+// Then, it requeues the Repository so that it is probed again on the next interval.
+func (c *StashController) runRepositoryProbe(key string) error {
+	obj, exist, err := c.repoInformer.GetIndexer().GetByKey(key)
+	if err != nil {
+		glog.Errorf("Fetching object with key %s from store failed with %v", key, err)
+		return err
+	}
+	if !exist {
+		return nil
+	}
+	repository := obj.(*api_v1alpha1.Repository)
+	if repository.DeletionTimestamp != nil || repository.IsValid() != nil {
+		return nil
+	}
+	if lastProbe := repository.Status.LastProbeTime; lastProbe != nil && time.Since(lastProbe.Time) < c.RepositoryProbeInterval {
+		c.repoProbeQueue.GetQueue().AddAfter(key, c.RepositoryProbeInterval-time.Since(lastProbe.Time))
+		return nil
+	}
+
+	conditions := c.probeRepository(repository)
+	updatedRepository, err := stash_util.UpdateRepositoryStatus(
+		c.stashClient.StashV1alpha1(),
+		repository,
+		func(in *api_v1alpha1.RepositoryStatus) *api_v1alpha1.RepositoryStatus {
+			currentTime := metav1.Now()
+			in.LastProbeTime = &currentTime
+			for _, cond := range conditions {
+				in.SetCondition(cond.Type, cond.Status, cond.Reason, cond.Message)
+			}
+			return in
+		},
+		apis.EnableStatusSubresource,
+	)
+	if err != nil {
+		return err
+	}
+	c.handleRepositoryReadinessChange(repository, updatedRepository)
+	c.repoProbeQueue.GetQueue().AddAfter(key, c.RepositoryProbeInterval)
+	return nil
+}
+
+// probeRepository checks that the storage secret has the keys required by the backend, that the bucket is reachable
+// and that the repository can be opened with the password. It returns the result as the conditions of the Repository.
+func (c *StashController) probeRepository(repository *api_v1alpha1.Repository) []api_v1alpha1.RepositoryCondition {
+	status := api_v1alpha1.RepositoryStatus{}
+	notProbed := func(reason, message string) []api_v1alpha1.RepositoryCondition {
+		status.SetCondition(api_v1alpha1.BackendReachable, core.ConditionUnknown, reason, message)
+		status.SetCondition(api_v1alpha1.RepositoryAccessible, core.ConditionUnknown, reason, message)
+		return status.Conditions
+	}
+
+	// check the storage secret
+	secretErr := c.validateRepositorySecret(repository)
+	if secretErr != nil {
+		status.SetCondition(api_v1alpha1.SecretValid, core.ConditionFalse, "SecretInvalid", secretErr.Error())
+		status.SetCondition(api_v1alpha1.RepositoryReady, core.ConditionFalse, "SecretInvalid", secretErr.Error())
+		return notProbed("SecretInvalid", "the backend has not been probed as the storage secret is invalid")
+	}
+	status.SetCondition(api_v1alpha1.SecretValid, core.ConditionTrue, "SecretValid", "storage secret has the keys required by the backend")
+
+	// the operator does not have access to the volume of a local backend
+	if repository.Spec.Backend.Local != nil {
+		status.SetCondition(api_v1alpha1.RepositoryReady, core.ConditionUnknown, "LocalBackend", "local backend can't be probed by the operator")
+		return notProbed("LocalBackend", "local backend can't be probed by the operator")
+	}
+
+	// the operator does not use the credential providers of the storage secret
+	if key := c.repositoryCredentialProvider(repository); key != "" {
+		msg := fmt.Sprintf("the credentials provided by %s are used only by the backup and restore processes", key)
+		status.SetCondition(api_v1alpha1.RepositoryReady, core.ConditionUnknown, "CredentialProvider", msg)
+		return notProbed("CredentialProvider", msg)
+	}
+
+	// check the bucket. the backends that are not supported by osm are checked by restic only.
+	bucketChecked, bucketErr := c.checkRepositoryBucket(repository)
+	if bucketErr != nil {
+		status.SetCondition(api_v1alpha1.BackendReachable, core.ConditionFalse, "BackendUnreachable", bucketErr.Error())
+		status.SetCondition(api_v1alpha1.RepositoryAccessible, core.ConditionUnknown, "BackendUnreachable", "the repository has not been opened as the backend is unreachable")
+		status.SetCondition(api_v1alpha1.RepositoryReady, core.ConditionFalse, "BackendUnreachable", bucketErr.Error())
+		return status.Conditions
+	}
+	if bucketChecked {
+		status.SetCondition(api_v1alpha1.BackendReachable, core.ConditionTrue, "BucketFound", "bucket is reachable")
+	}
+
+	// check that the repository opens with the password
+	openErr := c.openRepository(repository)
+	switch {
+	case openErr == nil:
+		if !bucketChecked {
+			status.SetCondition(api_v1alpha1.BackendReachable, core.ConditionTrue, "RepositoryOpened", "backend is reachable")
+		}
+		status.SetCondition(api_v1alpha1.RepositoryAccessible, core.ConditionTrue, "RepositoryOpened", "repository has been opened with the password")
+	case restic.IsRepositoryNotInitializedMessage(openErr.Error()):
+		if !bucketChecked {
+			status.SetCondition(api_v1alpha1.BackendReachable, core.ConditionTrue, "RepositoryNotInitialized", "backend is reachable")
+		}
+		status.SetCondition(api_v1alpha1.RepositoryAccessible, core.ConditionUnknown, "RepositoryNotInitialized", "repository will be initialized by the first backup")
+	case restic.IsWrongPasswordMessage(openErr.Error()):
+		// restic has reached the backend as it has read the keys of the repository
+		if !bucketChecked {
+			status.SetCondition(api_v1alpha1.BackendReachable, core.ConditionTrue, "WrongPassword", "backend is reachable")
+		}
+		status.SetCondition(api_v1alpha1.RepositoryAccessible, core.ConditionFalse, "WrongPassword", openErr.Error())
+	case restic.IsBackendErrorMessage(openErr.Error()):
+		status.SetCondition(api_v1alpha1.BackendReachable, core.ConditionFalse, "BackendUnreachable", openErr.Error())
+		status.SetCondition(api_v1alpha1.RepositoryAccessible, core.ConditionUnknown, "BackendUnreachable", "the repository has not been opened as the backend is unreachable")
+	default:
+		if !bucketChecked {
+			status.SetCondition(api_v1alpha1.BackendReachable, core.ConditionUnknown, "RepositoryOpenFailed", openErr.Error())
+		}
+		status.SetCondition(api_v1alpha1.RepositoryAccessible, core.ConditionFalse, "RepositoryOpenFailed", openErr.Error())
+	}
+
+	backend := status.GetCondition(api_v1alpha1.BackendReachable)
+	access := status.GetCondition(api_v1alpha1.RepositoryAccessible)
+	switch {
+	case access.Status == core.ConditionFalse:
+		status.SetCondition(api_v1alpha1.RepositoryReady, core.ConditionFalse, access.Reason, access.Message)
+	case backend.Status != core.ConditionTrue:
+		status.SetCondition(api_v1alpha1.RepositoryReady, core.ConditionFalse, backend.Reason, backend.Message)
+	default:
+		status.SetCondition(api_v1alpha1.RepositoryReady, core.ConditionTrue, "ProbeSucceeded", "repository has passed the health probe")
+	}
+	return status.Conditions
+}
+
+func (c *StashController) validateRepositorySecret(repository *api_v1alpha1.Repository) error {
+	if repository.Spec.Backend.StorageSecretName == "" {
+		return fmt.Errorf("storage secret name is not specified")
+	}
+	secret, err := c.kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	provider, err := repository.Spec.Backend.Provider()
+	if err != nil {
+		return err
+	}
+	return restic.ValidateSecretKeys(provider, secret.Data)
+}
+
+// checkRepositoryBucket checks that the bucket of the backend is reachable using osm. It returns false if the backend
+// or the credentials are not supported by osm.
+func (c *StashController) checkRepositoryBucket(repository *api_v1alpha1.Repository) (bool, error) {
+	provider, err := repository.Spec.Backend.Provider()
+	if err != nil {
+		return false, err
+	}
+	if provider == store.ProviderB2 || provider == store.ProviderRest {
+		return false, nil
+	}
+	secret, err := c.kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return false, err
+	}
+	if _, found := secret.Data[restic.AZURE_ACCOUNT_SAS]; found {
+		return false, nil
+	}
+
+	cfg, err := osm.NewOSMContext(c.kubeClient, repository.Spec.Backend, repository.Namespace)
+	if err != nil {
+		return true, err
+	}
+	loc, err := stow.Dial(cfg.Provider, cfg.Config)
+	if err != nil {
+		return true, err
+	}
+	bucket, _, err := util.GetBucketAndPrefix(&repository.Spec.Backend)
+	if err != nil {
+		return true, err
+	}
+	_, err = loc.Container(bucket)
+	return true, err
+}
+
+// repositoryCredentialProvider returns the key of the storage secret that specifies a credential provider
+func (c *StashController) repositoryCredentialProvider(repository *api_v1alpha1.Repository) string {
+	secret, err := c.kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return ""
+	}
+	for _, key := range []string{restic.CREDENTIAL_PROVIDER_COMMAND, restic.CREDENTIAL_PROVIDER_FILE, restic.AWS_WEB_IDENTITY_TOKEN_FILE} {
+		if _, found := secret.Data[key]; found {
+			return key
+		}
+	}
+	return ""
+}
+
+func (c *StashController) openRepository(repository *api_v1alpha1.Repository) error {
+	tempDir, err := ioutil.TempDir("", "stash")
+	if err != nil {
+		return err
+	}
+	// cleanup whole tempDir dir at the end
+	defer os.RemoveAll(tempDir)
+
+	resticWrapper, err := c.newResticWrapperForRepository(repository, tempDir)
+	if err != nil {
+		return err
+	}
+	resticWrapper.SetDeadline(time.Now().Add(repositoryProbeTimeout))
+	return resticWrapper.VerifyRepository()
+}
+
+// handleRepositoryReadinessChange writes an event when the readiness of the Repository changes
+// and sends the readiness metric to the Pushgateway
+func (c *StashController) handleRepositoryReadinessChange(old, cur *api_v1alpha1.Repository) {
+	cond := cur.Status.GetCondition(api_v1alpha1.RepositoryReady)
+	if cond == nil || cond.Status == core.ConditionUnknown {
+		return
+	}
+
+	if prev := old.Status.GetCondition(api_v1alpha1.RepositoryReady); prev == nil || prev.Status != cond.Status {
+		if cond.Status == core.ConditionTrue {
+			_, _ = eventer.CreateEvent(
+				c.kubeClient,
+				eventer.EventSourceRepositoryController,
+				cur,
+				core.EventTypeNormal,
+				eventer.EventReasonRepositoryReady,
+				cond.Message,
+			)
+		} else {
+			_, _ = eventer.CreateEvent(
+				c.kubeClient,
+				eventer.EventSourceRepositoryController,
+				cur,
+				core.EventTypeWarning,
+				eventer.EventReasonRepositoryNotReady,
+				fmt.Sprintf("repository has failed the health probe. Reason: %s", cond.Message),
+			)
+		}
+	}
+
+	metricsOpt := &restic.MetricsOptions{
+		Enabled:        true,
+		PushgatewayURL: util.PushgatewayLocalURL,
+		JobName:        fmt.Sprintf("%s-%s-%s", PromJobRepositoryController, cur.Namespace, cur.Name),
+	}
+	if err := metricsOpt.SendRepositoryHealthMetrics(cur, cond.Status == core.ConditionTrue); err != nil {
+		log.Errorf("failed to send health metrics of Repository %s/%s. Reason: %v", cur.Namespace, cur.Name, err)
+	}
+}
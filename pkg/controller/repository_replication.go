@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/appscode/go/types"
+	batchv1 "k8s.io/api/batch/v1"
+	batch_v1beta1 "k8s.io/api/batch/v1beta1"
+	core "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/tools/reference"
+	batch_util "kmodules.xyz/client-go/batch/v1"
+	batch_v1beta1_util "kmodules.xyz/client-go/batch/v1beta1"
+	core_util "kmodules.xyz/client-go/core/v1"
+	"stash.appscode.dev/stash/apis"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
+	"stash.appscode.dev/stash/pkg/docker"
+	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	RepositoryCopyPrefix = "stash-copy-"
+
+	// the name of a CronJob can be at most 52 characters long as the CronJob controller appends an 11 characters long
+	// suffix to the names of the Jobs. the one-off copy Jobs get a "-<unix time>" suffix of the same length.
+	repositoryCopyNameMaxLength = 52
+)
+
+// ensureRepositoryCopyCronJobs creates a CronJob for each replica of the Repository that is copied on its own schedule and
+// removes the CronJobs of the replicas that are not copied on schedule anymore. The CronJobs are owned by the Repository.
+func (c *StashController) ensureRepositoryCopyCronJobs(repository *api_v1alpha1.Repository) error {
+	schedules := map[string]string{}
+	for _, replica := range repository.Spec.CopyTo {
+		if replica.Schedule != "" {
+			schedules[replica.Name] = replica.Schedule
+		}
+	}
+
+	cronJobs, err := c.cronJobLister.CronJobs(repository.Namespace).List(copyJobSelector(repository.Name))
+	if err != nil {
+		return err
+	}
+	for _, cronJob := range cronJobs {
+		if _, scheduled := schedules[cronJob.Labels[util.LabelReplica]]; scheduled {
+			continue
+		}
+		err := c.kubeClient.BatchV1beta1().CronJobs(cronJob.Namespace).Delete(cronJob.Name, &metav1.DeleteOptions{})
+		if err != nil && !kerr.IsNotFound(err) {
+			return err
+		}
+	}
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	ref, err := reference.GetReference(stash_scheme.Scheme, repository)
+	if err != nil {
+		return err
+	}
+	serviceAccountName, err := c.ensureRepositoryMaintainerServiceAccount(repository, ref, map[string]string{util.LabelApp: util.AppLabelStash})
+	if err != nil {
+		return err
+	}
+
+	image := docker.Docker{
+		Registry: c.DockerRegistry,
+		Image:    docker.ImageStash,
+		Tag:      c.StashImageTag,
+	}
+	for replica, schedule := range schedules {
+		offshootLabels := copyJobLabels(repository.Name, replica)
+		meta := metav1.ObjectMeta{
+			Name:      getRepositoryCopyName(repository.Name, replica),
+			Namespace: repository.Namespace,
+			Labels:    offshootLabels,
+		}
+		jobTemplate := util.NewRepositoryCopyJob(repository, replica, image)
+		jobTemplate.Spec.ServiceAccountName = serviceAccountName
+
+		_, _, err = batch_v1beta1_util.CreateOrPatchCronJob(c.kubeClient, meta, func(in *batch_v1beta1.CronJob) *batch_v1beta1.CronJob {
+			// set Repository as owner of this CronJob
+			core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+
+			in.Spec.Schedule = schedule
+			// don't copy the same snapshots twice
+			in.Spec.ConcurrencyPolicy = batch_v1beta1.ForbidConcurrent
+			in.Spec.FailedJobsHistoryLimit = types.Int32P(1)
+
+			in.Spec.JobTemplate.Labels = core_util.UpsertMap(in.Spec.JobTemplate.Labels, offshootLabels)
+			// ensure that job gets deleted on completion
+			in.Spec.JobTemplate.Labels[apis.KeyDeleteJobOnCompletion] = "true"
+			// the result of a failed copy is recorded in the Repository. the next copy will copy the missed snapshots.
+			in.Spec.JobTemplate.Spec.BackoffLimit = types.Int32P(0)
+			in.Spec.JobTemplate.Spec.Template.Spec = jobTemplate.Spec
+			return in
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureRepositoryCopyJobs creates a Job for each replica of the Repository used by a succeeded BackupSession
+// that is not copied on its own schedule. It only writes an event on failure as the backup itself has succeeded.
+func (c *StashController) ensureRepositoryCopyJobs(backupSession *api_v1beta1.BackupSession) {
+	backupConfig, err := c.bcLister.BackupConfigurations(backupSession.Namespace).Get(backupSession.Spec.BackupConfiguration.Name)
+	if err != nil {
+		log.Warningf("failed to copy snapshots of BackupSession %s/%s. Reason: %v", backupSession.Namespace, backupSession.Name, err)
+		return
+	}
+	repository, err := c.repoLister.Repositories(backupConfig.Namespace).Get(backupConfig.Spec.Repository.Name)
+	if err != nil {
+		log.Warningf("failed to copy snapshots of BackupSession %s/%s. Reason: %v", backupSession.Namespace, backupSession.Name, err)
+		return
+	}
+
+	for _, replica := range repository.Spec.CopyTo {
+		if replica.Schedule != "" {
+			continue
+		}
+		if err := c.ensureRepositoryCopyJob(repository, replica.Name); err != nil {
+			log.Warningf("failed to copy snapshots of Repository %s/%s to %s. Reason: %v", repository.Namespace, repository.Name, replica.Name, err)
+			_, _ = eventer.CreateEvent(
+				c.kubeClient,
+				eventer.EventSourceRepositoryController,
+				repository,
+				core.EventTypeWarning,
+				eventer.EventReasonRepositoryCopyFailed,
+				fmt.Sprintf("failed to create job to copy snapshots to Repository %s. Reason: %v", replica.Name, err),
+			)
+		}
+	}
+}
+
+func (c *StashController) ensureRepositoryCopyJob(repository *api_v1alpha1.Repository, replica string) error {
+	offshootLabels := copyJobLabels(repository.Name, replica)
+
+	// a running copy job copies the snapshots that existed when it has started. skip this one so that the same
+	// snapshots are not copied twice. the snapshots missed by the running job are copied by the next one.
+	jobs, err := c.kubeClient.BatchV1().Jobs(repository.Namespace).List(metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(offshootLabels).String(),
+	})
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs.Items {
+		if job.Status.Active > 0 {
+			log.Infof("Skipping copy of Repository %s/%s to %s. Reason: job %s is still running.", repository.Namespace, repository.Name, replica, job.Name)
+			return nil
+		}
+	}
+
+	ref, err := reference.GetReference(stash_scheme.Scheme, repository)
+	if err != nil {
+		return err
+	}
+	serviceAccountName, err := c.ensureRepositoryMaintainerServiceAccount(repository, ref, map[string]string{util.LabelApp: util.AppLabelStash})
+	if err != nil {
+		return err
+	}
+
+	image := docker.Docker{
+		Registry: c.DockerRegistry,
+		Image:    docker.ImageStash,
+		Tag:      c.StashImageTag,
+	}
+	meta := metav1.ObjectMeta{
+		Name:      fmt.Sprintf("%s-%d", getRepositoryCopyName(repository.Name, replica), time.Now().Unix()),
+		Namespace: repository.Namespace,
+		Labels:    offshootLabels,
+	}
+	jobTemplate := util.NewRepositoryCopyJob(repository, replica, image)
+	_, _, err = batch_util.CreateOrPatchJob(c.kubeClient, meta, func(in *batchv1.Job) *batchv1.Job {
+		// set Repository as owner of this Job
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+
+		in.Labels = core_util.UpsertMap(in.Labels, offshootLabels)
+		// ensure that job gets deleted on completion
+		in.Labels[apis.KeyDeleteJobOnCompletion] = "true"
+		// the result of a failed copy is recorded in the Repository. the next copy will copy the missed snapshots.
+		in.Spec.BackoffLimit = types.Int32P(0)
+		in.Spec.Template.Spec = jobTemplate.Spec
+		in.Spec.Template.Spec.ServiceAccountName = serviceAccountName
+		return in
+	})
+	return err
+}
+
+func copyJobLabels(repository, replica string) map[string]string {
+	return map[string]string{
+		util.LabelApp:        util.AppLabelStash,
+		util.LabelRepository: repository,
+		util.LabelReplica:    replica,
+	}
+}
+
+// copyJobSelector selects the copy jobs of a Repository for all of its replicas
+func copyJobSelector(repository string) labels.Selector {
+	hasReplica, _ := labels.NewRequirement(util.LabelReplica, selection.Exists, nil)
+	return labels.SelectorFromSet(map[string]string{
+		util.LabelApp:        util.AppLabelStash,
+		util.LabelRepository: repository,
+	}).Add(*hasReplica)
+}
+
+func getRepositoryCopyName(repository, replica string) string {
+	name := RepositoryCopyPrefix + strings.ReplaceAll(repository, ".", "-") + "-" + strings.ReplaceAll(replica, ".", "-")
+	if len(name) <= repositoryCopyNameMaxLength {
+		return name
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+	return fmt.Sprintf("%s-%s", name[:31], strconv.FormatUint(hash.Sum64(), 10))
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestGetRepositoryCopyName(t *testing.T) {
+	short := getRepositoryCopyName("minio.repo", "gcs-repo")
+	if short != "stash-copy-minio-repo-gcs-repo" {
+		t.Errorf("expected stash-copy-minio-repo-gcs-repo, found %s", short)
+	}
+
+	repository, replica := strings.Repeat("repository-", 6), strings.Repeat("replica-", 6)
+	got := getRepositoryCopyName(repository, replica)
+	if len(got) > repositoryCopyNameMaxLength {
+		t.Errorf("%s is longer than %d characters", got, repositoryCopyNameMaxLength)
+	}
+	jobName := fmt.Sprintf("%s-%d", got, time.Now().Unix())
+	if errs := validation.IsDNS1123Label(jobName); len(errs) != 0 {
+		t.Errorf("%s is not a valid Job name: %v", jobName, errs)
+	}
+	if got != getRepositoryCopyName(repository, replica) {
+		t.Errorf("copy name of %s and %s is not stable", repository, replica)
+	}
+	if other := getRepositoryCopyName(repository, replica+"x"); other == got {
+		t.Errorf("expected different copy names for different replicas, found %s", got)
+	}
+}
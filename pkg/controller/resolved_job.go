@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	repo_v1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	cs "stash.appscode.dev/stash/client/clientset/versioned"
+	"stash.appscode.dev/stash/pkg/docker"
+	"stash.appscode.dev/stash/pkg/resolve"
+)
+
+// ResolveBackupJob resolves the backup job of a BackupConfiguration from its Task and Functions without creating
+// anything. It backs the ResolvedJob API so that custom Tasks and Functions can be reviewed before they are used.
+func (c *StashController) ResolveBackupJob(namespace, name string) (*repo_v1alpha1.ResolvedJob, error) {
+	backupConfig, err := c.stashClient.StashV1beta1().BackupConfigurations(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if backupConfig.Spec.Task.Name == "" {
+		return nil, fmt.Errorf("BackupConfiguration %s/%s does not use any Task", namespace, name)
+	}
+	repository, err := c.stashClient.StashV1alpha1().Repositories(namespace).Get(backupConfig.Spec.Repository.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	podSpec, unresolved, err := c.resolveBackupTask(backupConfig, repository, getBackupSessionName(backupConfig), true)
+	if err != nil {
+		return nil, err
+	}
+	return &repo_v1alpha1.ResolvedJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              backupConfig.Name,
+			Namespace:         backupConfig.Namespace,
+			CreationTimestamp: metav1.Now(),
+		},
+		Status: repo_v1alpha1.ResolvedJobStatus{
+			Task:                backupConfig.Spec.Task.Name,
+			PodSpec:             podSpec,
+			UnresolvedVariables: unresolved,
+		},
+	}, nil
+}
+
+// ResolveBackupTask resolves the PodSpec of the backup job of a BackupConfiguration in dry run mode. The Task and
+// Functions are read using the stashClient. So, it can resolve Tasks and Functions that haven't been applied yet
+// when a fake client is used.
+func ResolveBackupTask(stashClient cs.Interface, image docker.Docker, backupConfig *api_v1beta1.BackupConfiguration, repository *api_v1alpha1.Repository) (core.PodSpec, []string, error) {
+	c := newResolverController(stashClient, image)
+	return c.resolveBackupTask(backupConfig, repository, getBackupSessionName(backupConfig), true)
+}
+
+// ResolveRestoreTask resolves the PodSpec of the restore job of a RestoreSession for a host in dry run mode.
+func ResolveRestoreTask(stashClient cs.Interface, image docker.Docker, restoreSession *api_v1beta1.RestoreSession, repository *api_v1alpha1.Repository, hostname string) (core.PodSpec, []string, error) {
+	c := newResolverController(stashClient, image)
+	return c.resolveRestoreJobPodSpec(restoreSession, repository, hostname, true)
+}
+
+// newResolverController returns a controller that can only resolve Tasks. image is the stash image used by the operator.
+func newResolverController(stashClient cs.Interface, image docker.Docker) *StashController {
+	c := &StashController{stashClient: stashClient}
+	c.DockerRegistry = image.Registry
+	c.StashImageTag = image.Tag
+	return c
+}
+
+// stepRunnerImage returns the image that installs the step runner for the Tasks with conditional or parallel steps
+func (c *StashController) stepRunnerImage() string {
+	return docker.Docker{
+		Registry: c.DockerRegistry,
+		Image:    docker.ImageStash,
+		Tag:      c.StashImageTag,
+	}.ToContainerImage()
+}
+
+func getPodSpec(taskResolver resolve.TaskResolver, dryRun bool) (core.PodSpec, []string, error) {
+	if dryRun {
+		return taskResolver.GetPodSpecDryRun()
+	}
+	podSpec, err := taskResolver.GetPodSpec()
+	return podSpec, nil, err
+}
+
+// getBackupSessionName returns a name for the BackupSession that would be created for the BackupConfiguration now.
+func getBackupSessionName(backupConfig *api_v1beta1.BackupConfiguration) string {
+	return fmt.Sprintf("%s-%d", backupConfig.Name, time.Now().Unix())
+}
@@ -62,7 +62,7 @@ func (c *StashController) initResticWatcher() {
 			if r, ok := obj.(*api.Restic); ok {
 				if err := r.IsValid(); err != nil {
 					ref, rerr := reference.GetReference(scheme.Scheme, r)
-					if rerr == nil {
+					if rerr == nil && c.isLeader() {
 						c.recorder.Eventf(
 							ref,
 							core.EventTypeWarning,
@@ -89,7 +89,7 @@ func (c *StashController) initResticWatcher() {
 			}
 			if err := newRes.IsValid(); err != nil {
 				ref, rerr := reference.GetReference(scheme.Scheme, newRes)
-				if rerr == nil {
+				if rerr == nil && c.isLeader() {
 					c.recorder.Eventf(
 						ref,
 						core.EventTypeWarning,
@@ -0,0 +1,207 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	admission "k8s.io/api/admission/v1beta1"
+	authentication "k8s.io/api/authentication/v1"
+	authorization "k8s.io/api/authorization/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/reference"
+	core_util "kmodules.xyz/client-go/core/v1"
+	meta_util "kmodules.xyz/client-go/meta"
+	hooks "kmodules.xyz/webhook-runtime/admission/v1beta1"
+	webhook "kmodules.xyz/webhook-runtime/admission/v1beta1/generic"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
+	stash_rbac "stash.appscode.dev/stash/pkg/rbac"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+// getRestoreRepository returns the Repository of the RestoreSession. If the Repository is in a different namespace,
+// it must allow the namespace of the RestoreSession and its storage secret is copied into the namespace of the
+// RestoreSession so that it can be mounted into the restore job or init-container. The returned Repository refers
+// to the copied secret. When the caller is webhook, the same checks are done but the secret is not copied to keep
+// the webhooks side effect free. The controller copies it when it processes the workload.
+func (c *StashController) getRestoreRepository(restoreSession *api_v1beta1.RestoreSession, caller string) (*api_v1alpha1.Repository, error) {
+	repository, err := c.stashClient.StashV1alpha1().Repositories(restoreSession.RepositoryNamespace()).Get(restoreSession.Spec.Repository.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if repository.Namespace == restoreSession.Namespace {
+		return repository, nil
+	}
+	if err := checkRestoreNamespaceAllowed(repository, restoreSession.Namespace); err != nil {
+		return nil, err
+	}
+
+	// volumes of a different namespace can't be mounted
+	if repository.Spec.Backend.Local != nil && repository.Spec.Backend.Local.PersistentVolumeClaim != nil {
+		return nil, fmt.Errorf("can't restore from Repository %s/%s into namespace %s. Reason: local backend with PersistentVolumeClaim can't be used from a different namespace",
+			repository.Namespace, repository.Name, restoreSession.Namespace)
+	}
+	if repository.Spec.Backend.StorageSecretName == "" {
+		return repository, nil
+	}
+
+	secretMeta := metav1.ObjectMeta{
+		Name:      getRestoreRepositorySecretName(restoreSession, repository),
+		Namespace: restoreSession.Namespace,
+		Labels:    restoreSession.OffshootLabels(),
+	}
+	secret, err := c.kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if caller != util.CallerWebhook {
+		ref, err := reference.GetReference(stash_scheme.Scheme, restoreSession)
+		if err != nil {
+			return nil, err
+		}
+		_, _, err = core_util.CreateOrPatchSecret(c.kubeClient, secretMeta, func(in *core.Secret) *core.Secret {
+			core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+			in.Type = secret.Type
+			in.Data = secret.Data
+			return in
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	repository = repository.DeepCopy()
+	repository.Spec.Backend.StorageSecretName = secretMeta.Name
+	return repository, nil
+}
+
+// ensureRestoreRepositoryReader allows the ServiceAccount that runs the restore process to read the Repository
+// when the Repository is in a different namespace than the RestoreSession.
+func (c *StashController) ensureRestoreRepositoryReader(restoreSession *api_v1beta1.RestoreSession, repository *api_v1alpha1.Repository, serviceAccountName string) error {
+	if repository.Namespace == restoreSession.Namespace {
+		return nil
+	}
+	return stash_rbac.EnsureRepoReaderRoleBinding(
+		c.kubeClient,
+		repository,
+		stash_rbac.GetRepoReaderRoleBindingName(restoreSession.Name, restoreSession.Namespace),
+		serviceAccountName,
+		restoreSession.Namespace,
+		restoreSession.OffshootLabels(),
+	)
+}
+
+// ensureRestoreRepositoryReaderDeleted removes the RoleBinding that has been created in the namespace of the Repository.
+// It can't be garbage collected as owner reference does not work across namespaces.
+func (c *StashController) ensureRestoreRepositoryReaderDeleted(restoreSession *api_v1beta1.RestoreSession) error {
+	if restoreSession.RepositoryNamespace() == restoreSession.Namespace {
+		return nil
+	}
+	return stash_rbac.EnsureRepoReaderRoleBindingDeleted(
+		c.kubeClient,
+		stash_rbac.GetRepoReaderRoleBindingName(restoreSession.Name, restoreSession.Namespace),
+		restoreSession.RepositoryNamespace(),
+	)
+}
+
+// checkRestoreNamespaceAllowed ensures that the Repository allows the RestoreSessions of the namespace to restore from it.
+// The controller does not know who has created the RestoreSession. So, the owner of the Repository must opt in
+// before its storage secret is copied into another namespace.
+func checkRestoreNamespaceAllowed(repository *api_v1alpha1.Repository, namespace string) error {
+	allowed, _ := meta_util.GetStringValue(repository.Annotations, api_v1beta1.KeyAllowedRestoreNamespaces)
+	for _, ns := range strings.Split(allowed, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "*" || ns == namespace {
+			return nil
+		}
+	}
+	return fmt.Errorf("Repository %s/%s can't be used from namespace %s. Reason: the namespace is not listed in annotation %s of the Repository",
+		repository.Namespace, repository.Name, namespace, api_v1beta1.KeyAllowedRestoreNamespaces)
+}
+
+func getRestoreRepositorySecretName(restoreSession *api_v1beta1.RestoreSession, repository *api_v1alpha1.Repository) string {
+	return fmt.Sprintf("%s-%s", restoreSession.Name, repository.Spec.Backend.StorageSecretName)
+}
+
+// restoreSessionWebhook validates the RestoreSession. In addition, it ensures that the user creating a RestoreSession
+// has permission to read the Repository and its storage secret when the Repository is in a different namespace.
+// Otherwise, the user could get access to the backed up data of a namespace where they don't have any access.
+type restoreSessionWebhook struct {
+	*webhook.GenericWebhook
+	ctrl *StashController
+}
+
+func (h *restoreSessionWebhook) Admit(req *admission.AdmissionRequest) *admission.AdmissionResponse {
+	if req.Operation == admission.Create &&
+		len(req.SubResource) == 0 &&
+		req.Kind.Kind == api_v1beta1.ResourceKindRestoreSession {
+		restoreSession := &api_v1beta1.RestoreSession{}
+		if err := json.Unmarshal(req.Object.Raw, restoreSession); err != nil {
+			return hooks.StatusBadRequest(err)
+		}
+		restoreSession.Namespace = req.Namespace
+		if err := h.ctrl.checkRepositoryAccess(req.UserInfo, restoreSession); err != nil {
+			return hooks.StatusForbidden(err)
+		}
+	}
+	return h.GenericWebhook.Admit(req)
+}
+
+func (c *StashController) checkRepositoryAccess(user authentication.UserInfo, restoreSession *api_v1beta1.RestoreSession) error {
+	repoNamespace := restoreSession.RepositoryNamespace()
+	if repoNamespace == restoreSession.Namespace {
+		return nil
+	}
+
+	attributes := []authorization.ResourceAttributes{
+		{
+			Namespace: repoNamespace,
+			Verb:      "get",
+			Group:     api_v1alpha1.SchemeGroupVersion.Group,
+			Resource:  api_v1alpha1.ResourcePluralRepository,
+			Name:      restoreSession.Spec.Repository.Name,
+		},
+	}
+	repository, err := c.stashClient.StashV1alpha1().Repositories(repoNamespace).Get(restoreSession.Spec.Repository.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := checkRestoreNamespaceAllowed(repository, restoreSession.Namespace); err != nil {
+		return err
+	}
+	if repository.Spec.Backend.StorageSecretName != "" {
+		attributes = append(attributes, authorization.ResourceAttributes{
+			Namespace: repoNamespace,
+			Verb:      "get",
+			Resource:  "secrets",
+			Name:      repository.Spec.Backend.StorageSecretName,
+		})
+	}
+
+	extra := make(map[string]authorization.ExtraValue)
+	for k, v := range user.Extra {
+		extra[k] = authorization.ExtraValue(v)
+	}
+	for i := range attributes {
+		review, err := c.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(&authorization.SubjectAccessReview{
+			Spec: authorization.SubjectAccessReviewSpec{
+				ResourceAttributes: &attributes[i],
+				User:               user.Username,
+				Groups:             user.Groups,
+				UID:                user.UID,
+				Extra:              extra,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if !review.Status.Allowed {
+			return fmt.Errorf("user %q is not allowed to %s %s %s/%s. Reason: %s",
+				user.Username, attributes[i].Verb, attributes[i].Resource, attributes[i].Namespace, attributes[i].Name, review.Status.Reason)
+		}
+	}
+	return nil
+}
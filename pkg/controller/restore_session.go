@@ -43,7 +43,7 @@ const (
 )
 
 func (c *StashController) NewRestoreSessionWebhook() hooks.AdmissionHook {
-	return webhook.NewGenericWebhook(
+	validator := webhook.NewGenericWebhook(
 		schema.GroupVersionResource{
 			Group:    "admission.stash.appscode.com",
 			Version:  "v1beta1",
@@ -59,13 +59,27 @@ func (c *StashController) NewRestoreSessionWebhook() hooks.AdmissionHook {
 			},
 			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
 				// TODO: should not allow spec update ???
-				if !meta.Equal(oldObj.(*api_v1beta1.RestoreSession).Spec, newObj.(*api_v1beta1.RestoreSession).Spec) {
+				oldSpec := oldObj.(*api_v1beta1.RestoreSession).Spec
+				newSpec := newObj.(*api_v1beta1.RestoreSession).Spec
+				// RestoreSession in verify mode runs periodically. So, allow to update it except the Repository.
+				// The access to the Repository is checked only on creation.
+				if oldSpec.Verification != nil && newSpec.Verification != nil {
+					if !meta.Equal(oldSpec.Repository, newSpec.Repository) {
+						return nil, fmt.Errorf("spec.repository of RestoreSession is immutable")
+					}
+					return nil, newObj.(*api_v1beta1.RestoreSession).IsValid()
+				}
+				if !meta.Equal(oldSpec, newSpec) {
 					return nil, fmt.Errorf("RestoreSession spec is immutable")
 				}
 				return nil, nil
 			},
 		},
 	)
+	return &restoreSessionWebhook{
+		GenericWebhook: validator,
+		ctrl:           c,
+	}
 }
 
 // process only add events
@@ -110,6 +124,11 @@ func (c *StashController) runRestoreSessionProcessor(key string) error {
 					}
 				}
 
+				// remove the RoleBinding that has been created to read the Repository of a different namespace
+				if err := c.ensureRestoreRepositoryReaderDeleted(restoreSession); err != nil {
+					return err
+				}
+
 				// remove finalizer
 				_, _, err = v1beta1_util.PatchRestoreSession(c.stashClient.StashV1beta1(), restoreSession, func(in *api_v1beta1.RestoreSession) *api_v1beta1.RestoreSession {
 					in.ObjectMeta = core_util.RemoveFinalizer(in.ObjectMeta, api_v1beta1.StashKey)
@@ -130,6 +149,15 @@ func (c *StashController) runRestoreSessionProcessor(key string) error {
 				return err
 			}
 
+			// RestoreSession in verify mode does not restore the target. It periodically verifies the backups using a CronJob.
+			if restoreSession.Spec.Verification != nil {
+				err = c.ensureRestoreVerifierCronJob(restoreSession)
+				if err != nil {
+					return c.handleRestoreVerifierCronJobCreationFailure(restoreSession, err)
+				}
+				return nil
+			}
+
 			if restoreSession.Status.Phase == api_v1beta1.RestoreSessionFailed ||
 				restoreSession.Status.Phase == api_v1beta1.RestoreSessionSucceeded ||
 				restoreSession.Status.Phase == api_v1beta1.RestoreSessionUnknown {
@@ -251,10 +279,11 @@ func (c *StashController) ensureRestoreJob(restoreSession *api_v1beta1.RestoreSe
 	}
 
 	// get repository for RestoreSession
-	repository, err := c.stashClient.StashV1alpha1().Repositories(restoreSession.Namespace).Get(
-		restoreSession.Spec.Repository.Name,
-		metav1.GetOptions{},
-	)
+	repository, err := c.getRestoreRepository(restoreSession, util.CallerController)
+	if err != nil {
+		return err
+	}
+	err = c.ensureRestoreRepositoryReader(restoreSession, repository, serviceAccountName)
 	if err != nil {
 		return err
 	}
@@ -377,6 +406,21 @@ func (c *StashController) createRestoreJob(jobTemplate *core.PodTemplateSpec, me
 func (c *StashController) resolveRestoreTask(restoreSession *api_v1beta1.RestoreSession,
 	repository *api_v1alpha1.Repository, ref *core.ObjectReference, serviceAccountName, hostname string) (*core.PodTemplateSpec, error) {
 
+	podSpec, _, err := c.resolveRestoreJobPodSpec(restoreSession, repository, hostname, false)
+	if err != nil {
+		return nil, err
+	}
+	podTemplate := &core.PodTemplateSpec{
+		Spec: podSpec,
+	}
+	return podTemplate, nil
+}
+
+// resolveRestoreJobPodSpec resolves Functions and Tasks then returns the PodSpec of the job to restore the target.
+// In dry run mode, the variables that can't be resolved are left as it is and returned instead of failing.
+func (c *StashController) resolveRestoreJobPodSpec(restoreSession *api_v1beta1.RestoreSession,
+	repository *api_v1alpha1.Repository, hostname string, dryRun bool) (core.PodSpec, []string, error) {
+
 	// resolve task template
 	explicitInputs := make(map[string]string)
 	for _, param := range restoreSession.Spec.Task.Params {
@@ -385,11 +429,11 @@ func (c *StashController) resolveRestoreTask(restoreSession *api_v1beta1.Restore
 
 	repoInputs, err := c.inputsForRepository(repository)
 	if err != nil {
-		return nil, fmt.Errorf("cannot resolve implicit inputs for Repository %s/%s, reason: %s", repository.Namespace, repository.Name, err)
+		return core.PodSpec{}, nil, fmt.Errorf("cannot resolve implicit inputs for Repository %s/%s, reason: %s", repository.Namespace, repository.Name, err)
 	}
 	rsInputs, err := c.inputsForRestoreSession(*restoreSession, hostname)
 	if err != nil {
-		return nil, fmt.Errorf("cannot resolve implicit inputs for RestoreSession %s/%s, reason: %s", restoreSession.Namespace, restoreSession.Name, err)
+		return core.PodSpec{}, nil, fmt.Errorf("cannot resolve implicit inputs for RestoreSession %s/%s, reason: %s", restoreSession.Namespace, restoreSession.Name, err)
 	}
 
 	implicitInputs := core_util.UpsertMap(repoInputs, rsInputs)
@@ -403,6 +447,7 @@ func (c *StashController) resolveRestoreTask(restoreSession *api_v1beta1.Restore
 		Inputs:          core_util.UpsertMap(explicitInputs, implicitInputs),
 		RuntimeSettings: restoreSession.Spec.RuntimeSettings,
 		TempDir:         restoreSession.Spec.TempDir,
+		StepRunnerImage: c.stepRunnerImage(),
 	}
 
 	// In order to preserve file ownership, restore process need to be run as root user.
@@ -419,20 +464,16 @@ func (c *StashController) resolveRestoreTask(restoreSession *api_v1beta1.Restore
 	}
 	taskResolver.RuntimeSettings.Pod.SecurityContext = util.UpsertPodSecurityContext(defaultSecurityContext, taskResolver.RuntimeSettings.Pod.SecurityContext)
 
-	podSpec, err := taskResolver.GetPodSpec()
+	podSpec, unresolved, err := getPodSpec(taskResolver, dryRun)
 	if err != nil {
-		return nil, err
+		return core.PodSpec{}, nil, err
 	}
 
 	// for local backend, attach volume to all containers
 	if repository.Spec.Backend.Local != nil {
 		podSpec = util.AttachLocalBackend(podSpec, *repository.Spec.Backend.Local)
 	}
-
-	podTemplate := &core.PodTemplateSpec{
-		Spec: podSpec,
-	}
-	return podTemplate, nil
+	return podSpec, unresolved, nil
 }
 
 func (c *StashController) ensureVolumeRestorerJob(restoreSession *api_v1beta1.RestoreSession) error {
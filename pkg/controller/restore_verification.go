@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/appscode/go/log"
+	"github.com/appscode/go/types"
+	batch_v1beta1 "k8s.io/api/batch/v1beta1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/reference"
+	batch_util "kmodules.xyz/client-go/batch/v1beta1"
+	core_util "kmodules.xyz/client-go/core/v1"
+	"stash.appscode.dev/stash/apis"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
+	"stash.appscode.dev/stash/pkg/docker"
+	"stash.appscode.dev/stash/pkg/eventer"
+	stash_rbac "stash.appscode.dev/stash/pkg/rbac"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	RestoreVerifierPrefix = "stash-verify-"
+)
+
+// ensureRestoreVerifierCronJob creates a CronJob for a RestoreSession in verify mode. On each schedule, the CronJob
+// restores the latest snapshots into an EmptyDir, runs the checks and records the result in the BackupConfiguration.
+// The CronJob is owned by the RestoreSession. So, it is garbage collected when the RestoreSession is deleted.
+func (c *StashController) ensureRestoreVerifierCronJob(restoreSession *api_v1beta1.RestoreSession) error {
+	image := docker.Docker{
+		Registry: c.DockerRegistry,
+		Image:    docker.ImageStash,
+		Tag:      c.StashImageTag,
+	}
+
+	offshootLabels := restoreSession.OffshootLabels()
+
+	meta := metav1.ObjectMeta{
+		Name:      getRestoreVerifierName(restoreSession),
+		Namespace: restoreSession.Namespace,
+		Labels:    offshootLabels,
+	}
+
+	ref, err := reference.GetReference(stash_scheme.Scheme, restoreSession)
+	if err != nil {
+		return err
+	}
+
+	// Ensure respective RBAC and PSP stuff.
+	var serviceAccountName string
+	if restoreSession.Spec.RuntimeSettings.Pod != nil &&
+		restoreSession.Spec.RuntimeSettings.Pod.ServiceAccountName != "" {
+		// ServiceAccount has been specified, so use it.
+		serviceAccountName = restoreSession.Spec.RuntimeSettings.Pod.ServiceAccountName
+	} else {
+		// ServiceAccount hasn't been specified. so create new one with same name as the CronJob.
+		serviceAccountName = meta.Name
+
+		_, _, err = core_util.CreateOrPatchServiceAccount(c.kubeClient, meta, func(in *core.ServiceAccount) *core.ServiceAccount {
+			core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+			in.Labels = offshootLabels
+			return in
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	psps, err := c.getRestoreJobPSPNames(restoreSession)
+	if err != nil {
+		return err
+	}
+	err = stash_rbac.EnsureRestoreVerifierRBAC(c.kubeClient, ref, serviceAccountName, psps, offshootLabels)
+	if err != nil {
+		return err
+	}
+
+	repository, err := c.getRestoreRepository(restoreSession, util.CallerController)
+	if err != nil {
+		return err
+	}
+	err = c.ensureRestoreRepositoryReader(restoreSession, repository, serviceAccountName)
+	if err != nil {
+		return err
+	}
+
+	jobTemplate, err := util.NewRestoreVerifierJob(restoreSession, repository, image)
+	if err != nil {
+		return err
+	}
+	jobTemplate.Spec.ServiceAccountName = serviceAccountName
+
+	_, _, err = batch_util.CreateOrPatchCronJob(c.kubeClient, meta, func(in *batch_v1beta1.CronJob) *batch_v1beta1.CronJob {
+		// set RestoreSession as owner of this CronJob
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+
+		in.Spec.Schedule = restoreSession.Spec.Verification.Schedule
+		in.Spec.Suspend = types.BoolP(restoreSession.Spec.Verification.Paused)
+		// don't start a new verification while the previous one is still running
+		in.Spec.ConcurrencyPolicy = batch_v1beta1.ForbidConcurrent
+		in.Spec.FailedJobsHistoryLimit = types.Int32P(1)
+
+		in.Spec.JobTemplate.Labels = core_util.UpsertMap(in.Spec.JobTemplate.Labels, offshootLabels)
+		// ensure that job gets deleted on completion
+		in.Spec.JobTemplate.Labels[apis.KeyDeleteJobOnCompletion] = "true"
+		// the result of a failed verification is recorded in the BackupConfiguration. so, don't retry.
+		in.Spec.JobTemplate.Spec.BackoffLimit = types.Int32P(0)
+		in.Spec.JobTemplate.Spec.Template.Spec = jobTemplate.Spec
+		return in
+	})
+	return err
+}
+
+// handleRestoreVerifierCronJobCreationFailure writes an event to the RestoreSession and returns the error so that
+// the RestoreSession is requeued. The phase is not changed as the RestoreSession does not restore anything in verify mode.
+func (c *StashController) handleRestoreVerifierCronJobCreationFailure(restoreSession *api_v1beta1.RestoreSession, err error) error {
+	log.Warningf("failed to ensure restore verifier CronJob for RestoreSession %s/%s. Reason: %v", restoreSession.Namespace, restoreSession.Name, err)
+
+	// write event to RestoreSession
+	_, _ = eventer.CreateEvent(
+		c.kubeClient,
+		eventer.EventSourceRestoreSessionController,
+		restoreSession,
+		core.EventTypeWarning,
+		eventer.EventReasonCronJobCreationFailed,
+		fmt.Sprintf("failed to ensure restore verifier CronJob for RestoreSession %s/%s. Reason: %v", restoreSession.Namespace, restoreSession.Name, err),
+	)
+	return err
+}
+
+func getRestoreVerifierName(restoreSession *api_v1beta1.RestoreSession) string {
+	return RestoreVerifierPrefix + strings.ReplaceAll(restoreSession.Name, ".", "-")
+}
@@ -151,6 +151,14 @@ func (c *StashController) ensureBackupSidecar(w *wapi.Workload, bc *api_v1beta1.
 		if err != nil {
 			return err
 		}
+		var hooks []*api_v1beta1.Hook
+		if bc.Spec.Hooks != nil {
+			hooks = []*api_v1beta1.Hook{bc.Spec.Hooks.PreBackup, bc.Spec.Hooks.PostBackup}
+		}
+		err = stash_rbac.EnsureHookExecutorRBAC(c.kubeClient, ref, stash_rbac.StashSidecar, sa, bc.OffshootLabels(), hooks...)
+		if err != nil {
+			return err
+		}
 	}
 
 	repository, err := c.stashClient.StashV1alpha1().Repositories(bc.Namespace).Get(bc.Spec.Repository.Name, metav1.GetOptions{})
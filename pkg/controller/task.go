@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"kmodules.xyz/webhook-runtime/admission"
+	hooks "kmodules.xyz/webhook-runtime/admission/v1beta1"
+	webhook "kmodules.xyz/webhook-runtime/admission/v1beta1/generic"
+	"stash.appscode.dev/stash/apis/stash"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/resolve"
+)
+
+func (c *StashController) NewTaskWebhook() hooks.AdmissionHook {
+	return webhook.NewGenericWebhook(
+		schema.GroupVersionResource{
+			Group:    "admission.stash.appscode.com",
+			Version:  "v1beta1",
+			Resource: "taskvalidators",
+		},
+		"taskvalidator",
+		[]string{stash.GroupName},
+		api_v1beta1.SchemeGroupVersion.WithKind(api_v1beta1.ResourceKindTask),
+		nil,
+		&admission.ResourceHandlerFuncs{
+			CreateFunc: func(obj runtime.Object) (runtime.Object, error) {
+				return nil, c.validateTask(obj.(*api_v1beta1.Task))
+			},
+			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				return nil, c.validateTask(newObj.(*api_v1beta1.Task))
+			},
+		},
+	)
+}
+
+func (c *StashController) NewFunctionWebhook() hooks.AdmissionHook {
+	return webhook.NewGenericWebhook(
+		schema.GroupVersionResource{
+			Group:    "admission.stash.appscode.com",
+			Version:  "v1beta1",
+			Resource: "functionvalidators",
+		},
+		"functionvalidator",
+		[]string{stash.GroupName},
+		api_v1beta1.SchemeGroupVersion.WithKind(api_v1beta1.ResourceKindFunction),
+		nil,
+		&admission.ResourceHandlerFuncs{
+			CreateFunc: func(obj runtime.Object) (runtime.Object, error) {
+				return nil, validateFunction(obj.(*api_v1beta1.Function))
+			},
+			UpdateFunc: func(oldObj, newObj runtime.Object) (runtime.Object, error) {
+				return nil, validateFunction(newObj.(*api_v1beta1.Function))
+			},
+		},
+	)
+}
+
+// validateTask ensures that the Functions referred by the Task exist and that the params of the steps can be
+// resolved into the respective Functions.
+func (c *StashController) validateTask(task *api_v1beta1.Task) error {
+	if err := task.IsValid(); err != nil {
+		return err
+	}
+	return resolve.ValidateTask(c.stashClient, task)
+}
+
+func validateFunction(function *api_v1beta1.Function) error {
+	if err := function.IsValid(); err != nil {
+		return err
+	}
+	return resolve.ValidateFunction(function)
+}
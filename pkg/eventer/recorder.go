@@ -45,6 +45,10 @@ const (
 	EventSourceBackupTriggeringCronJob       = "Backup Triggering CronJob"
 	EventSourceStatusUpdater                 = "Status Updater"
 	EventSourceAutoBackupHandler             = "Auto Backup Handler"
+	EventSourceRestoreVerifier               = "Restore Verifier"
+	EventSourceRepositoryController          = "Repository Controller"
+	EventSourceRepositoryMaintainer          = "Repository Maintainer"
+	EventSourceLeaderElection                = "Leader Election"
 
 	// ======================= Event Reasons ========================
 	// BackupConfiguration Events
@@ -55,6 +59,9 @@ const (
 	EventReasonBackupSessionSkipped   = "BackupSession Skipped"
 	EventReasonBackupSessionRunning   = "BackupSession Running"
 	EventReasonBackupSessionSucceeded = "BackupSession Succeeded"
+	EventReasonBackupSessionRetrying  = "BackupSession Retrying"
+	EventReasonBackupSessionTimeout   = "BackupSession Timeout"
+	EventReasonBackupSessionReplaced  = "BackupSession Replaced"
 	EventReasonHostBackupSucceded     = "Host Backup Succeeded"
 	EventReasonHostBackupFailed       = "Host Backup Failed"
 	// RestoreSession Events
@@ -65,6 +72,23 @@ const (
 	EventReasonRestoreJobCreationFailed = "Restore Job Creation Failed"
 	EventReasonHostRestoreSucceeded     = "Host Restore Succeeded"
 	EventReasonHostRestoreFailed        = "Host Restore Failed"
+	// Restore Verification Events
+	EventReasonRestoreVerificationSucceeded = "Restore Verification Succeeded"
+	EventReasonRestoreVerificationFailed    = "Restore Verification Failed"
+	// Repository Maintenance Events
+	EventReasonRepositoryMaintenanceSucceeded = "Repository Maintenance Succeeded"
+	EventReasonRepositoryMaintenanceFailed    = "Repository Maintenance Failed"
+	EventReasonRepositoryLockRemoved          = "Repository Lock Removed"
+	EventReasonRepositoryLockRecoveryFailed   = "Repository Lock Recovery Failed"
+	EventReasonRepositoryKeyRotated           = "Repository Key Rotated"
+	EventReasonRepositoryKeyRotationFailed    = "Repository Key Rotation Failed"
+	EventReasonRepositoryCopySucceeded        = "Repository Copy Succeeded"
+	EventReasonRepositoryCopyFailed           = "Repository Copy Failed"
+	EventReasonRepositoryReady                = "Repository Ready"
+	EventReasonRepositoryNotReady             = "Repository Not Ready"
+	// Leader Election Events
+	EventReasonLeadershipAcquired = "Leadership Acquired"
+	EventReasonLeadershipLost     = "Leadership Lost"
 	// Auto Backup Events
 	EventReasonAutoBackupResourcesCreationFailed    = "Auto Backup Resources Creation Failed"
 	EventReasonAutoBackupResourcesCreationSucceeded = "Auto Backup Resources Creation Succeeded"
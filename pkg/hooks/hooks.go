@@ -0,0 +1,282 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+const (
+	DefaultHookTimeout = 60 * time.Second
+
+	FSFreezeCMD = "/bin/fsfreeze"
+)
+
+// HookExecutor executes the backup/restore hooks from inside the pod where the backup/restore is running.
+type HookExecutor struct {
+	Config     *rest.Config
+	KubeClient kubernetes.Interface
+	Namespace  string
+	// PodName is the name of the pod where the backup/restore is running.
+	// It is required to execute command in a different container of the pod or to resolve named port of http hook.
+	PodName string
+	// MountPaths are frozen by fsFreeze hook if no mount path has been specified in the hook
+	MountPaths []string
+}
+
+// Execute executes the hook and returns its statistics. The returned error is nil if the hook
+// has succeeded or the failure policy of the hook is "Ignore".
+func (e *HookExecutor) Execute(name string, hook *api_v1beta1.Hook) (*api_v1beta1.HookStats, error) {
+	if hook == nil {
+		return nil, nil
+	}
+	log.Infof("Executing %s hook", name)
+
+	startTime := time.Now()
+	timeout := DefaultHookTimeout
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+
+	var err error
+	switch {
+	case hook.Exec != nil:
+		err = e.exec(hook.Exec, timeout)
+	case hook.HTTPGet != nil:
+		err = e.httpGet(hook.HTTPGet, timeout)
+	case hook.FSFreeze != nil:
+		err = e.freeze(hook.FSFreeze, timeout)
+	default:
+		err = fmt.Errorf("no action has been specified")
+	}
+
+	stats := &api_v1beta1.HookStats{
+		Name:     name,
+		Phase:    api_v1beta1.HookSucceeded,
+		Duration: time.Since(startTime).String(),
+	}
+	if err != nil {
+		stats.Phase = api_v1beta1.HookFailed
+		stats.Error = err.Error()
+		if hook.FailurePolicy == api_v1beta1.HookFailurePolicyIgnore {
+			log.Warningf("Failed to execute %s hook. Ignoring the failure according to the failure policy. Reason: %v", name, err)
+			return stats, nil
+		}
+		return stats, fmt.Errorf("failed to execute %s hook. Reason: %v", name, err)
+	}
+	log.Infof("Successfully executed %s hook", name)
+	return stats, nil
+}
+
+// Unfreeze unfreezes the file systems that has been frozen by the fsFreeze hook.
+// It does nothing if the hook is not a fsFreeze hook.
+func (e *HookExecutor) Unfreeze(hook *api_v1beta1.Hook) error {
+	if hook == nil || hook.FSFreeze == nil {
+		return nil
+	}
+	var errs []string
+	for _, mountPath := range e.freezePaths(hook.FSFreeze) {
+		if err := runLocal(DefaultHookTimeout, FSFreezeCMD, "--unfreeze", mountPath); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to unfreeze %s. Reason: %v", mountPath, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func (e *HookExecutor) exec(hook *api_v1beta1.ExecHook, timeout time.Duration) error {
+	if len(hook.Command) == 0 {
+		return fmt.Errorf("empty command")
+	}
+	// no container has been specified. so, run the command in the current container
+	if hook.Container == "" {
+		return runLocal(timeout, hook.Command[0], hook.Command[1:]...)
+	}
+	return e.execInContainer(hook.Container, hook.Command, timeout)
+}
+
+func (e *HookExecutor) execInContainer(container string, command []string, timeout time.Duration) error {
+	if e.PodName == "" {
+		return fmt.Errorf("can't execute command in container %s. Reason: pod name is unknown", container)
+	}
+
+	var (
+		execOut bytes.Buffer
+		execErr bytes.Buffer
+	)
+
+	req := e.KubeClient.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(e.PodName).
+		Namespace(e.Namespace).
+		SubResource("exec")
+	req.VersionedParams(&core.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(e.Config, "POST", req.URL())
+	if err != nil {
+		return fmt.Errorf("failed to init executor: %v", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- executor.Stream(remotecommand.StreamOptions{
+			Stdout: &execOut,
+			Stderr: &execErr,
+		})
+	}()
+
+	select {
+	case err = <-errCh:
+		if err != nil {
+			return fmt.Errorf("could not execute: %v, reason: %s", err, execErr.String())
+		}
+		log.Infof("Output of command %v in container %s: %s", command, container, execOut.String())
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("command %v in container %s timed out after %v", command, container, timeout)
+	}
+}
+
+func (e *HookExecutor) httpGet(action *core.HTTPGetAction, timeout time.Duration) error {
+	port, err := e.resolvePort(action.Port)
+	if err != nil {
+		return err
+	}
+	host := action.Host
+	if host == "" {
+		// the hook is executed from the same pod. so, we can reach the containers of the pod through localhost.
+		host = "localhost"
+	}
+	scheme := strings.ToLower(string(action.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(host, strconv.Itoa(port)),
+	}
+	// path may contain query parameters
+	path, err := url.Parse(action.Path)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Path
+	u.RawQuery = path.RawQuery
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	for _, header := range action.HTTPHeaders {
+		if strings.EqualFold(header.Name, "Host") {
+			req.Host = header.Value
+			continue
+		}
+		req.Header.Add(header.Name, header.Value)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// follow the same convention as the http probe. any code between 200 and 399 indicates success.
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("http request to %s returned status code %d", u.String(), resp.StatusCode)
+	}
+	return nil
+}
+
+// resolvePort returns the port number. If the port has been specified by name,
+// it looks for the port in the containers of the pod.
+func (e *HookExecutor) resolvePort(port intstr.IntOrString) (int, error) {
+	if port.Type == intstr.Int {
+		return port.IntValue(), nil
+	}
+	if num, err := strconv.Atoi(port.StrVal); err == nil {
+		return num, nil
+	}
+	if e.PodName == "" {
+		return 0, fmt.Errorf("can't resolve port %q. Reason: pod name is unknown", port.StrVal)
+	}
+	pod, err := e.KubeClient.CoreV1().Pods(e.Namespace).Get(e.PodName, metav1.GetOptions{})
+	if err != nil {
+		return 0, err
+	}
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			if p.Name == port.StrVal {
+				return int(p.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no port named %q found in pod %s/%s", port.StrVal, e.Namespace, e.PodName)
+}
+
+func (e *HookExecutor) freeze(hook *api_v1beta1.FSFreezeHook, timeout time.Duration) error {
+	paths := e.freezePaths(hook)
+	if len(paths) == 0 {
+		return fmt.Errorf("no mount path found to freeze")
+	}
+	for i, mountPath := range paths {
+		if err := runLocal(timeout, FSFreezeCMD, "--freeze", mountPath); err != nil {
+			// unfreeze the file systems that has been frozen already
+			for _, p := range paths[:i] {
+				if uerr := runLocal(DefaultHookTimeout, FSFreezeCMD, "--unfreeze", p); uerr != nil {
+					log.Errorf("Failed to unfreeze %s. Reason: %v", p, uerr)
+				}
+			}
+			return fmt.Errorf("failed to freeze %s. Reason: %v", mountPath, err)
+		}
+	}
+	return nil
+}
+
+func (e *HookExecutor) freezePaths(hook *api_v1beta1.FSFreezeHook) []string {
+	if len(hook.MountPaths) > 0 {
+		return hook.MountPaths
+	}
+	return e.MountPaths
+}
+
+func runLocal(timeout time.Duration, name string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("command %s %v timed out after %v", name, args, timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("%v, output: %s", err, string(out))
+	}
+	log.Infof("Output of command %s %v: %s", name, args, string(out))
+	return nil
+}
@@ -0,0 +1,110 @@
+package rbac
+
+import (
+	"fmt"
+	"strings"
+
+	core "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	core_util "kmodules.xyz/client-go/core/v1"
+	meta_util "kmodules.xyz/client-go/meta"
+	rbac_util "kmodules.xyz/client-go/rbac/v1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+// EnsureHookExecutorRBAC gives the ServiceAccount of the workload the permissions required by the hooks that are executed
+// by the stash container of the given type (sidecar or init-container). The exec hooks need to execute commands in the other
+// containers of the pod and the http hooks need to read the pod to resolve named ports. The permissions are given through
+// a Role so that the workloads without such hooks don't get them. The Role is removed if none of the hooks needs them.
+func EnsureHookExecutorRBAC(kubeClient kubernetes.Interface, ref *core.ObjectReference, stashContainer, sa string, labels map[string]string, hooks ...*api_v1beta1.Hook) error {
+	meta := metav1.ObjectMeta{
+		Namespace: ref.Namespace,
+		Name:      getHookExecutorName(stashContainer, ref.Name, ref.Kind),
+		Labels:    labels,
+	}
+	rules := hookExecutorRules(hooks...)
+	if len(rules) == 0 {
+		return ensureHookExecutorRBACDeleted(kubeClient, meta.Namespace, meta.Name)
+	}
+
+	_, _, err := rbac_util.CreateOrPatchRole(kubeClient, meta, func(in *rbac.Role) *rbac.Role {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+		in.Rules = rules
+		return in
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = rbac_util.CreateOrPatchRoleBinding(kubeClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+
+		in.RoleRef = rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     meta.Name,
+		}
+		in.Subjects = []rbac.Subject{
+			{
+				Kind:      rbac.ServiceAccountKind,
+				Name:      sa,
+				Namespace: ref.Namespace,
+			},
+		}
+		return in
+	})
+	return err
+}
+
+func hookExecutorRules(hooks ...*api_v1beta1.Hook) []rbac.PolicyRule {
+	var readPod, execPod bool
+	for _, hook := range hooks {
+		if hook == nil {
+			continue
+		}
+		// the command is executed in the stash container itself when no container has been specified
+		if hook.Exec != nil && hook.Exec.Container != "" {
+			execPod = true
+		}
+		if hook.HTTPGet != nil && hook.HTTPGet.Port.Type == intstr.String {
+			readPod = true
+		}
+	}
+
+	var rules []rbac.PolicyRule
+	if readPod {
+		rules = append(rules, rbac.PolicyRule{
+			APIGroups: []string{core.GroupName},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get"},
+		})
+	}
+	if execPod {
+		rules = append(rules, rbac.PolicyRule{
+			APIGroups: []string{core.GroupName},
+			Resources: []string{"pods/exec"},
+			Verbs:     []string{"create"},
+		})
+	}
+	return rules
+}
+
+func ensureHookExecutorRBACDeleted(kubeClient kubernetes.Interface, namespace, name string) error {
+	err := kubeClient.RbacV1().RoleBindings(namespace).Delete(name, meta_util.DeleteInBackground())
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+	err = kubeClient.RbacV1().Roles(namespace).Delete(name, meta_util.DeleteInBackground())
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func getHookExecutorName(stashContainer, name, kind string) string {
+	return fmt.Sprintf("%s-hooks-%s-%s", stashContainer, strings.ToLower(kind), name)
+}
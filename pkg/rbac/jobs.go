@@ -204,6 +204,67 @@ func EnsureRepoReaderRBAC(kubeClient kubernetes.Interface, stashClient stash_cs.
 	return err
 }
 
+// EnsureRepoReaderRoleBinding allows a ServiceAccount of a different namespace to read the Repository.
+// The RoleBinding is created in the namespace of the Repository.
+func EnsureRepoReaderRoleBinding(kubeClient kubernetes.Interface, repo *api_v1alpha1.Repository, bindingName, sa, saNamespace string, labels map[string]string) error {
+	// ensure repo-reader role
+	err := ensureRepoReaderRole(kubeClient, repo)
+	if err != nil {
+		return err
+	}
+
+	meta := metav1.ObjectMeta{
+		Name:      bindingName,
+		Namespace: repo.Namespace,
+		Labels:    labels,
+	}
+	_, _, err = rbac_util.CreateOrPatchRoleBinding(kubeClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
+		in.RoleRef = rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     getRepoReaderRoleName(repo.Name),
+		}
+		in.Subjects = []rbac.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      sa,
+				Namespace: saNamespace,
+			},
+		}
+		return in
+	})
+	return err
+}
+
+func EnsureRepoReaderRoleBindingDeleted(kubeClient kubernetes.Interface, bindingName, namespace string) error {
+	err := kubeClient.RbacV1().RoleBindings(namespace).Delete(bindingName, meta_util.DeleteInBackground())
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// ListCrossNamespaceRepoReaders returns the namespaces whose ServiceAccounts have been allowed to read the Repository.
+// The storage secret of the Repository has been copied into these namespaces to restore from the Repository.
+func ListCrossNamespaceRepoReaders(kubeClient kubernetes.Interface, repo *api_v1alpha1.Repository) ([]string, error) {
+	bindings, err := kubeClient.RbacV1().RoleBindings(repo.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	var namespaces []string
+	for _, binding := range bindings.Items {
+		if binding.RoleRef.Kind != KindRole || binding.RoleRef.Name != getRepoReaderRoleName(repo.Name) {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			if subject.Namespace != "" && subject.Namespace != repo.Namespace {
+				namespaces = append(namespaces, subject.Namespace)
+			}
+		}
+	}
+	return namespaces, nil
+}
+
 func ensureRepoReaderRole(kubeClient kubernetes.Interface, repo *api_v1alpha1.Repository) error {
 	meta := metav1.ObjectMeta{
 		Name:      getRepoReaderRoleName(repo.Name),
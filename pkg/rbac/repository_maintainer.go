@@ -0,0 +1,170 @@
+package rbac
+
+import (
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	rbac "k8s.io/api/rbac/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	core_util "kmodules.xyz/client-go/core/v1"
+	meta_util "kmodules.xyz/client-go/meta"
+	rbac_util "kmodules.xyz/client-go/rbac/v1"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+const (
+	StashRepositoryMaintainer = "stash-repository-maintainer"
+)
+
+func EnsureRepositoryMaintainerRBAC(kubeClient kubernetes.Interface, ref *core.ObjectReference, sa string, psps []string, labels map[string]string) error {
+	// ensure ClusterRole for repository maintenance job
+	err := ensureRepositoryMaintainerClusterRole(kubeClient, psps, labels)
+	if err != nil {
+		return err
+	}
+
+	// ensure RoleBinding for repository maintenance job
+	return ensureRepositoryMaintainerRoleBinding(kubeClient, ref, sa, labels)
+}
+
+func ensureRepositoryMaintainerClusterRole(kubeClient kubernetes.Interface, psps []string, labels map[string]string) error {
+
+	meta := metav1.ObjectMeta{
+		Name:   StashRepositoryMaintainer,
+		Labels: labels,
+	}
+	_, _, err := rbac_util.CreateOrPatchClusterRole(kubeClient, meta, func(in *rbac.ClusterRole) *rbac.ClusterRole {
+
+		in.Rules = []rbac.PolicyRule{
+			{
+				APIGroups: []string{api_v1alpha1.SchemeGroupVersion.Group},
+				Resources: []string{
+					api_v1alpha1.ResourcePluralRepository,
+					fmt.Sprintf("%s/status", api_v1alpha1.ResourcePluralRepository)},
+				Verbs: []string{"get", "list", "update", "patch"},
+			},
+			{
+				APIGroups: []string{core.SchemeGroupVersion.Group},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"events"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups:     []string{policy.GroupName},
+				Resources:     []string{"podsecuritypolicies"},
+				Verbs:         []string{"use"},
+				ResourceNames: psps,
+			},
+		}
+		return in
+	})
+	return err
+}
+
+func ensureRepositoryMaintainerRoleBinding(kubeClient kubernetes.Interface, resource *core.ObjectReference, sa string, labels map[string]string) error {
+
+	meta := metav1.ObjectMeta{
+		Namespace: resource.Namespace,
+		Name:      getRepositoryMaintainerRoleBindingName(resource.Name),
+		Labels:    labels,
+	}
+	_, _, err := rbac_util.CreateOrPatchRoleBinding(kubeClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, resource)
+
+		in.RoleRef = rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "ClusterRole",
+			Name:     StashRepositoryMaintainer,
+		}
+		in.Subjects = []rbac.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      sa,
+				Namespace: resource.Namespace,
+			},
+		}
+		return in
+	})
+	return err
+}
+
+func getRepositoryMaintainerRoleBindingName(name string) string {
+	return fmt.Sprintf("%s-%s", StashRepositoryMaintainer, name)
+}
+
+// EnsureRepositoryKeyRotatorRBAC gives the ServiceAccount of the key rotation job the permission to update the storage secret
+// of the Repository. The permission is given through a Role so that the job can't update any other secret of the namespace.
+// The job also lists the RoleBindings of the namespace to find out whether the storage secret has been copied into other namespaces.
+func EnsureRepositoryKeyRotatorRBAC(kubeClient kubernetes.Interface, ref *core.ObjectReference, sa, secretName string, labels map[string]string) error {
+	meta := metav1.ObjectMeta{
+		Namespace: ref.Namespace,
+		Name:      getRepositoryKeyRotatorName(ref.Name),
+		Labels:    labels,
+	}
+	_, _, err := rbac_util.CreateOrPatchRole(kubeClient, meta, func(in *rbac.Role) *rbac.Role {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+
+		in.Rules = []rbac.PolicyRule{
+			{
+				APIGroups:     []string{core.GroupName},
+				Resources:     []string{"secrets"},
+				ResourceNames: []string{secretName},
+				Verbs:         []string{"get", "update", "patch"},
+			},
+			{
+				// the storage secret is copied into the namespaces that are allowed to read the Repository
+				APIGroups: []string{rbac.GroupName},
+				Resources: []string{"rolebindings"},
+				Verbs:     []string{"list"},
+			},
+		}
+		return in
+	})
+	if err != nil {
+		return err
+	}
+
+	_, _, err = rbac_util.CreateOrPatchRoleBinding(kubeClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, ref)
+
+		in.RoleRef = rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     meta.Name,
+		}
+		in.Subjects = []rbac.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      sa,
+				Namespace: ref.Namespace,
+			},
+		}
+		return in
+	})
+	return err
+}
+
+// EnsureRepositoryKeyRotatorRBACDeleted removes the permission to update the storage secret when the key rotation is not scheduled anymore
+func EnsureRepositoryKeyRotatorRBACDeleted(kubeClient kubernetes.Interface, namespace, repoName string) error {
+	name := getRepositoryKeyRotatorName(repoName)
+	err := kubeClient.RbacV1().RoleBindings(namespace).Delete(name, meta_util.DeleteInBackground())
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+	err = kubeClient.RbacV1().Roles(namespace).Delete(name, meta_util.DeleteInBackground())
+	if err != nil && !kerr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func getRepositoryKeyRotatorName(name string) string {
+	return fmt.Sprintf("stash-key-rotator-%s", name)
+}
@@ -71,6 +71,12 @@ func ensureRestoreJobClusterRole(kubeClient kubernetes.Interface, psps []string,
 				Resources: []string{"events"},
 				Verbs:     []string{"create"},
 			},
+			// the step runner watches the containers of the previous steps of a Task
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get"},
+			},
 			{
 				APIGroups:     []string{policy.GroupName},
 				Resources:     []string{"podsecuritypolicies"},
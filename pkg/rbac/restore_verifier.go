@@ -0,0 +1,109 @@
+package rbac
+
+import (
+	"fmt"
+
+	core "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	rbac "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	core_util "kmodules.xyz/client-go/core/v1"
+	rbac_util "kmodules.xyz/client-go/rbac/v1"
+	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+const (
+	StashRestoreVerifier = "stash-restore-verifier"
+)
+
+func EnsureRestoreVerifierRBAC(kubeClient kubernetes.Interface, ref *core.ObjectReference, sa string, psps []string, labels map[string]string) error {
+	// ensure ClusterRole for restore verifier job
+	err := ensureRestoreVerifierClusterRole(kubeClient, psps, labels)
+	if err != nil {
+		return err
+	}
+
+	// ensure RoleBinding for restore verifier job
+	return ensureRestoreVerifierRoleBinding(kubeClient, ref, sa, labels)
+}
+
+func ensureRestoreVerifierClusterRole(kubeClient kubernetes.Interface, psps []string, labels map[string]string) error {
+
+	meta := metav1.ObjectMeta{
+		Name:   StashRestoreVerifier,
+		Labels: labels,
+	}
+	_, _, err := rbac_util.CreateOrPatchClusterRole(kubeClient, meta, func(in *rbac.ClusterRole) *rbac.ClusterRole {
+
+		in.Rules = []rbac.PolicyRule{
+			{
+				APIGroups: []string{api_v1beta1.SchemeGroupVersion.Group},
+				Resources: []string{api_v1beta1.ResourcePluralRestoreSession},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{api_v1beta1.SchemeGroupVersion.Group},
+				Resources: []string{
+					api_v1beta1.ResourcePluralBackupConfiguration,
+					fmt.Sprintf("%s/status", api_v1beta1.ResourcePluralBackupConfiguration)},
+				Verbs: []string{"get", "update", "patch"},
+			},
+			{
+				APIGroups: []string{api_v1alpha1.SchemeGroupVersion.Group},
+				Resources: []string{api_v1alpha1.ResourcePluralRepository},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{core.SchemeGroupVersion.Group},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get"},
+			},
+			{
+				APIGroups: []string{core.GroupName},
+				Resources: []string{"events"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups:     []string{policy.GroupName},
+				Resources:     []string{"podsecuritypolicies"},
+				Verbs:         []string{"use"},
+				ResourceNames: psps,
+			},
+		}
+		return in
+	})
+	return err
+}
+
+func ensureRestoreVerifierRoleBinding(kubeClient kubernetes.Interface, resource *core.ObjectReference, sa string, labels map[string]string) error {
+
+	meta := metav1.ObjectMeta{
+		Namespace: resource.Namespace,
+		Name:      getRestoreVerifierRoleBindingName(resource.Name),
+		Labels:    labels,
+	}
+	_, _, err := rbac_util.CreateOrPatchRoleBinding(kubeClient, meta, func(in *rbac.RoleBinding) *rbac.RoleBinding {
+		core_util.EnsureOwnerReference(&in.ObjectMeta, resource)
+
+		in.RoleRef = rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "ClusterRole",
+			Name:     StashRestoreVerifier,
+		}
+		in.Subjects = []rbac.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      sa,
+				Namespace: resource.Namespace,
+			},
+		}
+		return in
+	})
+	return err
+}
+
+func getRestoreVerifierRoleBindingName(name string) string {
+	return fmt.Sprintf("%s-%s", StashRestoreVerifier, name)
+}
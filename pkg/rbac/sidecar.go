@@ -149,6 +149,10 @@ func EnsureUnnecessaryWorkloadRBACDeleted(kubeClient kubernetes.Interface, w *wa
 		if err != nil && !kerr.IsNotFound(err) {
 			return err
 		}
+		err = ensureHookExecutorRBACDeleted(kubeClient, w.Namespace, getHookExecutorName(StashSidecar, w.Name, w.Kind))
+		if err != nil {
+			return err
+		}
 	}
 
 	// delete restore init-container RoleBinding if workload does not have sash init-container
@@ -157,6 +161,10 @@ func EnsureUnnecessaryWorkloadRBACDeleted(kubeClient kubernetes.Interface, w *wa
 		if err != nil && !kerr.IsNotFound(err) {
 			return err
 		}
+		err = ensureHookExecutorRBACDeleted(kubeClient, w.Namespace, getHookExecutorName(StashRestoreInitContainer, w.Name, w.Kind))
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
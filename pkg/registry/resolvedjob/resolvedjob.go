@@ -0,0 +1,68 @@
+package resolvedjob
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"stash.appscode.dev/stash/apis/repositories"
+	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+)
+
+// Resolver resolves the backup job of a BackupConfiguration without creating it.
+type Resolver interface {
+	ResolveBackupJob(namespace, name string) (*repov1alpha1.ResolvedJob, error)
+}
+
+type REST struct {
+	resolver Resolver
+}
+
+var _ rest.Scoper = &REST{}
+var _ rest.Getter = &REST{}
+var _ rest.GroupVersionKindProvider = &REST{}
+
+func NewREST(resolver Resolver) *REST {
+	return &REST{
+		resolver: resolver,
+	}
+}
+
+func (r *REST) NamespaceScoped() bool {
+	return true
+}
+
+func (r *REST) New() runtime.Object {
+	return &repositories.ResolvedJob{}
+}
+
+func (r *REST) GroupVersionKind(containingGV schema.GroupVersion) schema.GroupVersionKind {
+	return repov1alpha1.SchemeGroupVersion.WithKind(repov1alpha1.ResourceKindResolvedJob)
+}
+
+// Get returns the resolved backup job of the BackupConfiguration with the same name.
+func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	in, err := r.resolver.ResolveBackupJob(ns, name)
+	if err != nil {
+		if _, ok := err.(apierrors.APIStatus); ok {
+			return nil, err
+		}
+		// the Task, Functions or their inputs are invalid
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
+	out := &repositories.ResolvedJob{}
+	if err = repov1alpha1.Convert_v1alpha1_ResolvedJob_To_repositories_ResolvedJob(in, out, nil); err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	return out, nil
+}
@@ -0,0 +1,130 @@
+package snapshot
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	core_util "kmodules.xyz/client-go/core/v1"
+	"stash.appscode.dev/stash/apis/repositories"
+	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
+	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+// continueToken identifies the last snapshot returned in a paginated list.
+// The snapshots are listed in the order of their Repository name and then snapshot name.
+type continueToken struct {
+	Repository string `json:"repository"`
+	Snapshot   string `json:"snapshot"`
+}
+
+func encodeContinueToken(token continueToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeContinueToken(s string) (*continueToken, error) {
+	if s == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid continue token: %v", err)
+	}
+	token := &continueToken{}
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("invalid continue token: %v", err)
+	}
+	return token, nil
+}
+
+// selectRepositories returns the repositories whose labels match the label selector sorted by name.
+// Snapshots inherit the labels of their repository along with "repository: <repository name>" label.
+func selectRepositories(repos []stash.Repository, selector labels.Selector) []stash.Repository {
+	selected := make([]stash.Repository, 0, len(repos))
+	for _, repo := range repos {
+		if selector != nil && !selector.Empty() {
+			repoLabels := map[string]string{
+				"repository": repo.Name,
+			}
+			if repo.Labels != nil {
+				repoLabels = core_util.UpsertMap(repoLabels, repo.Labels)
+			}
+			if !selector.Matches(labels.Set(repoLabels)) {
+				continue
+			}
+		}
+		selected = append(selected, repo)
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Name < selected[j].Name
+	})
+	return selected
+}
+
+// filterSnapshots returns the snapshots that match the field selector sorted by name.
+func filterSnapshots(snapshots []repositories.Snapshot, selector fields.Selector) []repositories.Snapshot {
+	filtered := make([]repositories.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshotMatchesFields(snapshot, selector) {
+			filtered = append(filtered, snapshot)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+	return filtered
+}
+
+// snapshotMatchesFields evaluates the field selector against a snapshot. As "status.paths" and "status.tags"
+// are lists, "=" matches a snapshot if any of its paths/tags is equal to the value and "!=" matches if none is.
+func snapshotMatchesFields(snapshot repositories.Snapshot, selector fields.Selector) bool {
+	if selector == nil || selector.Empty() {
+		return true
+	}
+	for _, req := range selector.Requirements() {
+		var values []string
+		switch req.Field {
+		case repov1alpha1.SnapshotFieldName:
+			values = []string{snapshot.Name}
+		case repov1alpha1.SnapshotFieldNamespace:
+			values = []string{snapshot.Namespace}
+		case repov1alpha1.SnapshotFieldHostname:
+			values = []string{snapshot.Status.Hostname}
+		case repov1alpha1.SnapshotFieldPaths:
+			values = snapshot.Status.Paths
+		case repov1alpha1.SnapshotFieldTags:
+			values = snapshot.Status.Tags
+		default:
+			return false
+		}
+
+		found := false
+		for _, v := range values {
+			if v == req.Value {
+				found = true
+				break
+			}
+		}
+		switch req.Operator {
+		case selection.Equals, selection.DoubleEquals:
+			if !found {
+				return false
+			}
+		case selection.NotEquals:
+			if found {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,267 @@
+package snapshot
+
+import (
+	"sync"
+	"time"
+
+	"github.com/appscode/go/log"
+	"github.com/prometheus/client_golang/prometheus"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"stash.appscode.dev/stash/apis/repositories"
+	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	stashinformers "stash.appscode.dev/stash/client/informers/externalversions"
+)
+
+// snapshotIndex keeps the snapshots of the Repositories in memory so that the Snapshot API does not need to
+// list the snapshots from the backend on every request. The snapshots of a Repository are indexed on the first
+// request for the Repository and refreshed periodically afterward. The entry is invalidated whenever a
+// BackupSession of the Repository completes or a snapshot is deleted through the API.
+type snapshotIndex struct {
+	refreshPeriod time.Duration
+
+	lock    sync.RWMutex
+	entries map[string]*indexEntry
+}
+
+type indexEntry struct {
+	namespace   string
+	repository  string
+	snapshots   []repositories.Snapshot
+	refreshedAt time.Time
+	// invalidated entries are refreshed on the next request
+	invalidated bool
+}
+
+var _ prometheus.Collector = &snapshotIndex{}
+
+func newSnapshotIndex(refreshPeriod time.Duration) *snapshotIndex {
+	return &snapshotIndex{
+		refreshPeriod: refreshPeriod,
+		entries:       make(map[string]*indexEntry),
+	}
+}
+
+func indexKey(namespace, repository string) string {
+	return namespace + "/" + repository
+}
+
+// get returns the indexed snapshots of the Repository. The second return value is false if the
+// Repository hasn't been indexed yet or the entry has been invalidated.
+func (idx *snapshotIndex) get(namespace, repository string) ([]repositories.Snapshot, bool) {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	entry, ok := idx.entries[indexKey(namespace, repository)]
+	if !ok || entry.invalidated {
+		return nil, false
+	}
+	return copySnapshots(entry.snapshots), true
+}
+
+func (idx *snapshotIndex) set(namespace, repository string, snapshots []repositories.Snapshot) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.entries[indexKey(namespace, repository)] = &indexEntry{
+		namespace:   namespace,
+		repository:  repository,
+		snapshots:   copySnapshots(snapshots),
+		refreshedAt: time.Now(),
+	}
+}
+
+func (idx *snapshotIndex) invalidate(namespace, repository string) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	if entry, ok := idx.entries[indexKey(namespace, repository)]; ok {
+		entry.invalidated = true
+	}
+}
+
+func (idx *snapshotIndex) remove(namespace, repository string) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	delete(idx.entries, indexKey(namespace, repository))
+}
+
+// indexedRepositories returns the namespace and name of the indexed Repositories.
+func (idx *snapshotIndex) indexedRepositories() [][2]string {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	repos := make([][2]string, 0, len(idx.entries))
+	for _, entry := range idx.entries {
+		repos = append(repos, [2]string{entry.namespace, entry.repository})
+	}
+	return repos
+}
+
+// Describe implements prometheus.Collector
+func (idx *snapshotIndex) Describe(ch chan<- *prometheus.Desc) {
+	ch <- snapshotIndexStalenessDesc
+	ch <- snapshotIndexSnapshotsDesc
+}
+
+// Collect implements prometheus.Collector. The staleness of an entry is the time since it has been refreshed last.
+func (idx *snapshotIndex) Collect(ch chan<- prometheus.Metric) {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+
+	now := time.Now()
+	for _, entry := range idx.entries {
+		ch <- prometheus.MustNewConstMetric(
+			snapshotIndexStalenessDesc,
+			prometheus.GaugeValue,
+			now.Sub(entry.refreshedAt).Seconds(),
+			entry.namespace, entry.repository,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			snapshotIndexSnapshotsDesc,
+			prometheus.GaugeValue,
+			float64(len(entry.snapshots)),
+			entry.namespace, entry.repository,
+		)
+	}
+}
+
+// EnableIndex enables the in-memory snapshot index. The indexed snapshots are refreshed after every refreshPeriod.
+// RunIndex must be called to start the periodic refresh and the invalidation on BackupSession completion.
+func (r *REST) EnableIndex(refreshPeriod time.Duration) {
+	r.index = newSnapshotIndex(refreshPeriod)
+	prometheus.MustRegister(r.index)
+}
+
+// RunIndex refreshes the indexed snapshots periodically and invalidates the snapshots
+// of a Repository whenever a BackupSession of the Repository completes.
+func (r *REST) RunIndex(stopCh <-chan struct{}) {
+	if r.index == nil {
+		return
+	}
+
+	informerFactory := stashinformers.NewSharedInformerFactory(r.stashClient, 0)
+	backupConfigLister := informerFactory.Stash().V1beta1().BackupConfigurations().Lister()
+	informerFactory.Stash().V1beta1().BackupSessions().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldBS, ok := oldObj.(*api_v1beta1.BackupSession)
+			if !ok {
+				return
+			}
+			newBS, ok := newObj.(*api_v1beta1.BackupSession)
+			if !ok {
+				return
+			}
+			// a failed BackupSession may have taken snapshots of some of the hosts. so, invalidate for both phases.
+			if oldBS.Status.Phase == newBS.Status.Phase ||
+				(newBS.Status.Phase != api_v1beta1.BackupSessionSucceeded && newBS.Status.Phase != api_v1beta1.BackupSessionFailed) {
+				return
+			}
+			backupConfig, err := backupConfigLister.BackupConfigurations(newBS.Namespace).Get(newBS.Spec.BackupConfiguration.Name)
+			if err != nil {
+				return
+			}
+			log.Infof("Invalidating indexed snapshots of Repository %s/%s as BackupSession %s has completed", newBS.Namespace, backupConfig.Spec.Repository.Name, newBS.Name)
+			r.index.invalidate(newBS.Namespace, backupConfig.Spec.Repository.Name)
+		},
+	})
+	informerFactory.Start(stopCh)
+	informerFactory.WaitForCacheSync(stopCh)
+
+	wait.Until(r.refreshIndex, r.index.refreshPeriod, stopCh)
+}
+
+// refreshIndex refreshes the snapshots of all the indexed Repositories.
+// The Repositories that have been deleted are removed from the index.
+func (r *REST) refreshIndex() {
+	for _, key := range r.index.indexedRepositories() {
+		namespace, name := key[0], key[1]
+		repo, err := r.stashClient.StashV1alpha1().Repositories(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			if kerr.IsNotFound(err) {
+				r.index.remove(namespace, name)
+			} else {
+				log.Errorf("Failed to refresh indexed snapshots of Repository %s/%s. Reason: %v", namespace, name, err)
+			}
+			continue
+		}
+		if _, err := r.refreshSnapshots(repo); err != nil {
+			log.Errorf("Failed to refresh indexed snapshots of Repository %s/%s. Reason: %v", namespace, name, err)
+		}
+	}
+}
+
+// listSnapshots returns the snapshots of the Repository from the index.
+// The snapshots are listed from the backend if the Repository hasn't been indexed yet.
+func (r *REST) listSnapshots(repo *stash.Repository) ([]repositories.Snapshot, error) {
+	if r.index == nil {
+		return r.GetVersionedSnapshots(repo, nil, false)
+	}
+	if snapshots, ok := r.index.get(repo.Namespace, repo.Name); ok {
+		snapshotIndexRequests.WithLabelValues(metricsResultHit).Inc()
+		return snapshots, nil
+	}
+	snapshotIndexRequests.WithLabelValues(metricsResultMiss).Inc()
+	return r.refreshSnapshots(repo)
+}
+
+// refreshSnapshots lists the snapshots of the Repository from the backend and updates the index.
+func (r *REST) refreshSnapshots(repo *stash.Repository) ([]repositories.Snapshot, error) {
+	startTime := time.Now()
+	snapshots, err := r.GetVersionedSnapshots(repo, nil, false)
+	if r.index == nil {
+		return snapshots, err
+	}
+	snapshotIndexRefreshDuration.Observe(time.Since(startTime).Seconds())
+	if err != nil {
+		snapshotIndexRefreshes.WithLabelValues(metricsResultFailure).Inc()
+		return nil, err
+	}
+	snapshotIndexRefreshes.WithLabelValues(metricsResultSuccess).Inc()
+	r.index.set(repo.Namespace, repo.Name, snapshots)
+	return snapshots, nil
+}
+
+// getSnapshot returns the snapshot with the given id from the index. The snapshot may have been taken after
+// the Repository has been indexed. So, the index is refreshed once if the snapshot is not found there.
+func (r *REST) getSnapshot(repo *stash.Repository, snapshotID string) ([]repositories.Snapshot, error) {
+	if r.index == nil {
+		return r.GetVersionedSnapshots(repo, []string{snapshotID}, false)
+	}
+	snapshots, err := r.listSnapshots(repo)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot := findSnapshot(snapshots, repo.Name, snapshotID); snapshot != nil {
+		return []repositories.Snapshot{*snapshot}, nil
+	}
+	snapshots, err = r.refreshSnapshots(repo)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot := findSnapshot(snapshots, repo.Name, snapshotID); snapshot != nil {
+		return []repositories.Snapshot{*snapshot}, nil
+	}
+	return nil, nil
+}
+
+func findSnapshot(snapshots []repositories.Snapshot, repoName, snapshotID string) *repositories.Snapshot {
+	for i := range snapshots {
+		if snapshots[i].Name == repoName+"-"+snapshotID {
+			return &snapshots[i]
+		}
+	}
+	return nil
+}
+
+func copySnapshots(snapshots []repositories.Snapshot) []repositories.Snapshot {
+	out := make([]repositories.Snapshot, len(snapshots))
+	for i := range snapshots {
+		snapshots[i].DeepCopyInto(&out[i])
+	}
+	return out
+}
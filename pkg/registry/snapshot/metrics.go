@@ -0,0 +1,63 @@
+package snapshot
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsResultHit     = "hit"
+	metricsResultMiss    = "miss"
+	metricsResultSuccess = "success"
+	metricsResultFailure = "failure"
+)
+
+var (
+	snapshotIndexRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "stash",
+			Subsystem: "snapshot_index",
+			Name:      "requests_total",
+			Help:      "Number of snapshot list requests served by the snapshot index partitioned by whether it was served from the index (hit) or not (miss)",
+		},
+		[]string{"result"},
+	)
+	snapshotIndexRefreshes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "stash",
+			Subsystem: "snapshot_index",
+			Name:      "refresh_total",
+			Help:      "Number of times the snapshots of a repository have been listed from the backend partitioned by result",
+		},
+		[]string{"result"},
+	)
+	snapshotIndexRefreshDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "stash",
+			Subsystem: "snapshot_index",
+			Name:      "refresh_duration_seconds",
+			Help:      "Time taken to list the snapshots of a repository from the backend",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		},
+	)
+
+	snapshotIndexStalenessDesc = prometheus.NewDesc(
+		"stash_snapshot_index_staleness_seconds",
+		"Time since the indexed snapshots of a repository have been refreshed",
+		[]string{"namespace", "repository"},
+		nil,
+	)
+	snapshotIndexSnapshotsDesc = prometheus.NewDesc(
+		"stash_snapshot_index_snapshots",
+		"Number of indexed snapshots of a repository",
+		[]string{"namespace", "repository"},
+		nil,
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		snapshotIndexRequests,
+		snapshotIndexRefreshes,
+		snapshotIndexRefreshDuration,
+	)
+}
@@ -7,14 +7,12 @@ import (
 	kerr "k8s.io/apimachinery/pkg/api/errors"
 	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	apirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
 	"k8s.io/client-go/kubernetes"
 	restconfig "k8s.io/client-go/rest"
-	core_util "kmodules.xyz/client-go/core/v1"
 	"stash.appscode.dev/stash/apis/repositories"
 	repov1alpha1 "stash.appscode.dev/stash/apis/repositories/v1alpha1"
 	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
@@ -26,11 +24,14 @@ type REST struct {
 	stashClient versioned.Interface
 	kubeClient  kubernetes.Interface
 	config      *restconfig.Config
+	// index is nil unless EnableIndex has been called
+	index *snapshotIndex
 }
 
 var _ rest.Scoper = &REST{}
 var _ rest.Getter = &REST{}
 var _ rest.Lister = &REST{}
+var _ rest.Watcher = &REST{}
 var _ rest.GracefulDeleter = &REST{}
 var _ rest.GroupVersionKindProvider = &REST{}
 var _ rest.CategoriesProvider = &REST{}
@@ -79,7 +80,7 @@ func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions)
 		}
 	}
 
-	snapshots, err := r.GetVersionedSnapshots(repo, []string{snapshotId}, false)
+	snapshots, err := r.getSnapshot(repo, snapshotId)
 	if err != nil {
 		return nil, apierrors.NewInternalError(err)
 	}
@@ -103,41 +104,62 @@ func (r *REST) List(ctx context.Context, options *metainternalversion.ListOption
 		return nil, apierrors.NewBadRequest("missing namespace")
 	}
 
+	start, err := decodeContinueToken(options.Continue)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+
 	repos, err := r.stashClient.StashV1alpha1().Repositories(ns).List(metav1.ListOptions{})
 	if err != nil {
 		return nil, apierrors.NewInternalError(err)
 	}
 
-	var selectedRepos []stash.Repository
-	if options.LabelSelector != nil {
-		for _, r := range repos.Items {
-			repoLabels := map[string]string{
-				"repository": r.Name,
-			}
-			if r.Labels != nil {
-				repoLabels = core_util.UpsertMap(repoLabels, r.Labels)
-			}
-			if options.LabelSelector.Matches(labels.Set(repoLabels)) {
-				selectedRepos = append(selectedRepos, r)
-			}
-		}
-	} else {
-		selectedRepos = repos.Items
+	// snapshot watch is driven by BackupSessions. so, use the resourceVersion of BackupSession list
+	// so that the clients can start watching from the point where the list has been taken.
+	resourceVersion, err := r.backupSessionResourceVersion(ns)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
 	}
 
 	snapshotList := &repositories.SnapshotList{
 		Items: make([]repositories.Snapshot, 0),
 	}
-	for _, repo := range selectedRepos {
-		snapshots, err := r.GetVersionedSnapshots(&repo, nil, false)
+	snapshotList.ResourceVersion = resourceVersion
+	// name of the repository of each snapshot in the list. it is used to generate the continue token.
+	repoNames := make([]string, 0)
+
+	// repositories and their snapshots are processed in order of their name. so, we can skip the repositories
+	// that has been already listed in the previous pages and stop as soon as we have enough snapshots for this page.
+	for _, repo := range selectRepositories(repos.Items, options.LabelSelector) {
+		if start != nil && repo.Name < start.Repository {
+			continue
+		}
+		snapshots, err := r.listSnapshots(&repo)
 		if err != nil {
 			return nil, apierrors.NewInternalError(err)
 		}
-		snapshotList.Items = append(snapshotList.Items, snapshots...)
+		for _, snapshot := range filterSnapshots(snapshots, options.FieldSelector) {
+			if start != nil && repo.Name == start.Repository && snapshot.Name <= start.Snapshot {
+				continue
+			}
+			snapshotList.Items = append(snapshotList.Items, snapshot)
+			repoNames = append(repoNames, repo.Name)
+		}
+		if options.Limit > 0 && int64(len(snapshotList.Items)) > options.Limit {
+			break
+		}
 	}
 
-	// k8s.io/apimachinery/pkg/apis/meta/v1/unstructured/unstructured_list.go
-	// unstructured.UnstructuredList{}
+	if options.Limit > 0 && int64(len(snapshotList.Items)) > options.Limit {
+		snapshotList.Items = snapshotList.Items[:options.Limit]
+		snapshotList.Continue, err = encodeContinueToken(continueToken{
+			Repository: repoNames[options.Limit-1],
+			Snapshot:   snapshotList.Items[options.Limit-1].Name,
+		})
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
 	return snapshotList, nil
 }
 
@@ -160,7 +182,7 @@ func (r *REST) Delete(ctx context.Context, name string, options *metav1.DeleteOp
 	}
 
 	// first, check if the snapshot exist
-	snapshots, err := r.GetVersionedSnapshots(repo, []string{snapshotId}, false)
+	snapshots, err := r.getSnapshot(repo, snapshotId)
 	if err != nil {
 		return nil, false, apierrors.NewInternalError(err)
 	} else if len(snapshots) == 0 {
@@ -170,6 +192,9 @@ func (r *REST) Delete(ctx context.Context, name string, options *metav1.DeleteOp
 	if err = r.ForgetVersionedSnapshots(repo, []string{snapshotId}, false); err != nil {
 		return nil, false, apierrors.NewInternalError(err)
 	}
+	if r.index != nil {
+		r.index.invalidate(repo.Namespace, repo.Name)
+	}
 
 	return nil, true, nil
 }
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/appscode/go/log"
@@ -20,14 +19,11 @@ import (
 	"stash.appscode.dev/stash/apis/repositories"
 	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
 	"stash.appscode.dev/stash/pkg/cli"
-	"stash.appscode.dev/stash/pkg/restic"
 	"stash.appscode.dev/stash/pkg/util"
 )
 
 const (
-	ExecStash      = "/bin/stash"
-	scratchDirName = "scratch"
-	secretDirName  = "secret"
+	ExecStash = "/bin/stash"
 )
 
 func (r *REST) getSnapshots(repository *stash.Repository, snapshotIDs []string) ([]repositories.Snapshot, error) {
@@ -254,38 +250,8 @@ func (r *REST) getV1Beta1Snapshots(repository *stash.Repository, snapshotIDs []s
 	// cleanup whole tempDir dir at the end
 	defer os.RemoveAll(tempDir)
 
-	scratchDir := filepath.Join(tempDir, scratchDirName)
-	secretDir := filepath.Join(tempDir, secretDirName)
-
-	// get source repository secret
-	secret, err := r.kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
-	if err != nil {
-		return nil, err
-	}
-
-	// write repository secrets in a temp dir
-	if err := os.MkdirAll(secretDir, 0755); err != nil {
-		return nil, err
-	}
-	for key, value := range secret.Data {
-		if err := ioutil.WriteFile(filepath.Join(secretDir, key), value, 0755); err != nil {
-			return nil, err
-		}
-	}
-
-	// configure restic wrapper
-	extraOpt := util.ExtraOptions{
-		SecretDir:   secretDir,
-		EnableCache: false,
-		ScratchDir:  scratchDir,
-	}
-	// configure setupOption
-	setupOpt, err := util.SetupOptionsForRepository(*repository, extraOpt)
-	if err != nil {
-		return nil, fmt.Errorf("setup option for repository failed, reason: %s", err)
-	}
-	// init restic wrapper
-	resticWrapper, err := restic.NewResticWrapper(setupOpt)
+	// write the repository secret in the temp dir and init restic wrapper
+	resticWrapper, err := util.NewResticWrapperForRepository(r.kubeClient, *repository, tempDir)
 	if err != nil {
 		return nil, err
 	}
@@ -337,38 +303,8 @@ func (r *REST) forgetV1Beta1Snapshots(repository *stash.Repository, snapshotIDs
 	// cleanup whole tempDir dir at the end
 	defer os.RemoveAll(tempDir)
 
-	scratchDir := filepath.Join(tempDir, scratchDirName)
-	secretDir := filepath.Join(tempDir, secretDirName)
-
-	// get source repository secret
-	secret, err := r.kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
-	if err != nil {
-		return err
-	}
-
-	// write repository secrets in a temp dir
-	if err := os.MkdirAll(secretDir, 0755); err != nil {
-		return err
-	}
-	for key, value := range secret.Data {
-		if err := ioutil.WriteFile(filepath.Join(secretDir, key), value, 0755); err != nil {
-			return err
-		}
-	}
-
-	// configure restic wrapper
-	extraOpt := util.ExtraOptions{
-		SecretDir:   secretDir,
-		EnableCache: false,
-		ScratchDir:  scratchDir,
-	}
-	// configure setupOption
-	setupOpt, err := util.SetupOptionsForRepository(*repository, extraOpt)
-	if err != nil {
-		return fmt.Errorf("setup option for repository failed, reason: %s", err)
-	}
-	// init restic wrapper
-	resticWrapper, err := restic.NewResticWrapper(setupOpt)
+	// write the repository secret in the temp dir and init restic wrapper
+	resticWrapper, err := util.NewResticWrapperForRepository(r.kubeClient, *repository, tempDir)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,263 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/appscode/go/log"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"stash.appscode.dev/stash/apis/repositories"
+	stash "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+)
+
+var _ watch.Interface = &snapshotWatcher{}
+
+// Watch watches the snapshots of the Repositories of a namespace. Restic does not provide any way to get notified
+// about new snapshots. So, the watch is driven by BackupSession. Whenever a BackupSession completes, the snapshots
+// of the respective Repository are listed again and the difference from the previous listing is sent as
+// "ADDED" and "DELETED" events. Snapshots of the v1alpha1 Repositories are not watched as they are not backed up
+// using BackupSession.
+func (r *REST) Watch(ctx context.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	ns, ok := apirequest.NamespaceFrom(ctx)
+	if !ok {
+		return nil, apierrors.NewBadRequest("missing namespace")
+	}
+
+	// the watch of the BackupSessions sends a synthetic "ADDED" event for each existing BackupSession when no resourceVersion
+	// has been specified. the existing snapshots are sent from the listing of the Repositories instead. so, start the watch
+	// from the current state of the BackupSessions and mark the completed ones as processed. otherwise, each of them would
+	// list the snapshots of its Repository from the backend again.
+	processed := make(map[types.UID]api_v1beta1.BackupSessionPhase)
+	sendInitialEvents := options.ResourceVersion == "" || options.ResourceVersion == "0"
+	resourceVersion := options.ResourceVersion
+	if sendInitialEvents {
+		backupSessions, err := r.stashClient.StashV1beta1().BackupSessions(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		for _, backupSession := range backupSessions.Items {
+			if backupSession.Status.Phase == api_v1beta1.BackupSessionSucceeded ||
+				backupSession.Status.Phase == api_v1beta1.BackupSessionFailed {
+				processed[backupSession.UID] = backupSession.Status.Phase
+			}
+		}
+		resourceVersion = backupSessions.ResourceVersion
+	}
+
+	bsWatcher, err := r.stashClient.StashV1beta1().BackupSessions(ns).Watch(metav1.ListOptions{
+		ResourceVersion: resourceVersion,
+	})
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+
+	w := &snapshotWatcher{
+		rest:           r,
+		namespace:      ns,
+		labelSelector:  options.LabelSelector,
+		fieldSelector:  options.FieldSelector,
+		bsWatcher:      bsWatcher,
+		result:         make(chan watch.Event),
+		stopCh:         make(chan struct{}),
+		knownSnapshots: make(map[string]map[string]repositories.Snapshot),
+		processed:      processed,
+	}
+	go w.run(ctx, sendInitialEvents)
+	return w, nil
+}
+
+type snapshotWatcher struct {
+	rest          *REST
+	namespace     string
+	labelSelector labels.Selector
+	fieldSelector fields.Selector
+
+	bsWatcher watch.Interface
+	result    chan watch.Event
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+
+	// knownSnapshots holds the last observed snapshots of each Repository
+	knownSnapshots map[string]map[string]repositories.Snapshot
+	// processed holds the BackupSessions that has been already processed with their phase
+	processed map[types.UID]api_v1beta1.BackupSessionPhase
+}
+
+func (w *snapshotWatcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stopCh)
+		w.bsWatcher.Stop()
+	})
+}
+
+func (w *snapshotWatcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+func (w *snapshotWatcher) run(ctx context.Context, sendInitialEvents bool) {
+	defer close(w.result)
+	defer w.Stop()
+
+	// no resourceVersion has been specified. so, send the existing snapshots first.
+	if sendInitialEvents {
+		if err := w.sendInitialSnapshots(); err != nil {
+			w.sendError(err)
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-w.stopCh:
+			return
+		case event, ok := <-w.bsWatcher.ResultChan():
+			if !ok {
+				return
+			}
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				backupSession, ok := event.Object.(*api_v1beta1.BackupSession)
+				if !ok {
+					continue
+				}
+				if err := w.handleBackupSession(backupSession); err != nil {
+					log.Errorf("Failed to sync snapshots for BackupSession %s/%s. Reason: %v", backupSession.Namespace, backupSession.Name, err)
+				}
+			case watch.Deleted:
+				if backupSession, ok := event.Object.(*api_v1beta1.BackupSession); ok {
+					delete(w.processed, backupSession.UID)
+				}
+			case watch.Error:
+				w.sendEvent(event)
+				return
+			}
+		}
+	}
+}
+
+func (w *snapshotWatcher) sendInitialSnapshots() error {
+	repos, err := w.rest.stashClient.StashV1alpha1().Repositories(w.namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	for _, repo := range selectRepositories(repos.Items, w.labelSelector) {
+		snapshots, err := w.rest.listSnapshots(&repo)
+		if err != nil {
+			return err
+		}
+		known := make(map[string]repositories.Snapshot)
+		for _, snapshot := range filterSnapshots(snapshots, w.fieldSelector) {
+			known[snapshot.Name] = snapshot
+			if !w.sendEvent(watch.Event{Type: watch.Added, Object: snapshot.DeepCopy()}) {
+				return nil
+			}
+		}
+		w.knownSnapshots[repo.Name] = known
+	}
+	return nil
+}
+
+// handleBackupSession lists the snapshots of the Repository of a completed BackupSession
+// and sends the new and removed snapshots as events.
+func (w *snapshotWatcher) handleBackupSession(backupSession *api_v1beta1.BackupSession) error {
+	if backupSession.Status.Phase != api_v1beta1.BackupSessionSucceeded &&
+		backupSession.Status.Phase != api_v1beta1.BackupSessionFailed {
+		return nil
+	}
+	if phase, ok := w.processed[backupSession.UID]; ok && phase == backupSession.Status.Phase {
+		return nil
+	}
+	w.processed[backupSession.UID] = backupSession.Status.Phase
+
+	backupConfig, err := w.rest.stashClient.StashV1beta1().BackupConfigurations(w.namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	// VolumeSnapshotter driver does not create restic snapshots
+	if backupConfig.Spec.Driver == api_v1beta1.VolumeSnapshotter {
+		return nil
+	}
+	repo, err := w.rest.stashClient.StashV1alpha1().Repositories(w.namespace).Get(backupConfig.Spec.Repository.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(selectRepositories([]stash.Repository{*repo}, w.labelSelector)) == 0 {
+		return nil
+	}
+
+	snapshots, err := w.rest.refreshSnapshots(repo)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]repositories.Snapshot)
+	for _, snapshot := range filterSnapshots(snapshots, w.fieldSelector) {
+		current[snapshot.Name] = snapshot
+	}
+
+	known, observed := w.knownSnapshots[repo.Name]
+	for name, snapshot := range current {
+		if _, exist := known[name]; exist {
+			continue
+		}
+		// this Repository hasn't been observed before. so, we don't know which snapshots are new.
+		// consider only the snapshots that has been taken in this BackupSession as new.
+		if !observed && snapshot.CreationTimestamp.Before(&backupSession.CreationTimestamp) {
+			continue
+		}
+		if !w.sendEvent(watch.Event{Type: watch.Added, Object: snapshot.DeepCopy()}) {
+			return nil
+		}
+	}
+	for name, snapshot := range known {
+		if _, exist := current[name]; exist {
+			continue
+		}
+		// snapshot has been removed according to the retention policy
+		if !w.sendEvent(watch.Event{Type: watch.Deleted, Object: snapshot.DeepCopy()}) {
+			return nil
+		}
+	}
+	w.knownSnapshots[repo.Name] = current
+	return nil
+}
+
+// sendEvent sends the event to the client. It returns false if the watcher has been stopped.
+func (w *snapshotWatcher) sendEvent(event watch.Event) bool {
+	select {
+	case w.result <- event:
+		return true
+	case <-w.stopCh:
+		return false
+	}
+}
+
+func (w *snapshotWatcher) sendError(err error) {
+	status := apierrors.NewInternalError(fmt.Errorf("failed to watch snapshots. Reason: %v", err)).ErrStatus
+	w.sendEvent(watch.Event{Type: watch.Error, Object: &status})
+}
+
+// backupSessionResourceVersion returns the current resourceVersion of the BackupSessions of the namespace.
+func (r *REST) backupSessionResourceVersion(namespace string) (string, error) {
+	backupSessions, err := r.stashClient.StashV1beta1().BackupSessions(namespace).List(metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return "", err
+	}
+	return backupSessions.ResourceVersion, nil
+}
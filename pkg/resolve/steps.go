@@ -0,0 +1,119 @@
+package resolve
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	core "k8s.io/api/core/v1"
+	core_util "kmodules.xyz/client-go/core/v1"
+	v1beta1_api "stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+// usesStepRunner returns true if any step of the Task has a condition or belongs to a parallel group.
+// Such Tasks can't run the steps as init containers as a failed init container aborts the pod
+// and init containers can't run in parallel.
+func usesStepRunner(steps []v1beta1_api.FunctionRef) bool {
+	for _, step := range steps {
+		if step.When != "" || step.ParallelGroup != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// getStages returns the stage of each step. The steps of a stage run in parallel and a stage starts
+// after all the steps of the previous stages have completed.
+func getStages(steps []v1beta1_api.FunctionRef) []int {
+	stages := make([]int, len(steps))
+	for i := 1; i < len(steps); i++ {
+		stages[i] = stages[i-1]
+		if steps[i].ParallelGroup == "" || steps[i].ParallelGroup != steps[i-1].ParallelGroup {
+			stages[i]++
+		}
+	}
+	return stages
+}
+
+// getStepRunnerPodSpec returns a PodSpec where all the steps run as containers through the step runner.
+// An init container installs the step runner into a shared volume. Then, the step runner of each container
+// waits for the steps of the previous stages to complete or their containers to terminate, checks the condition of the step and runs the
+// command of the Function. The step runner exits with the exit code of the command, so the pod fails if any step fails.
+func (o TaskResolver) getStepRunnerPodSpec(task *v1beta1_api.Task, containers []core.Container) (core.PodSpec, error) {
+	if o.StepRunnerImage == "" {
+		return core.PodSpec{}, fmt.Errorf("step runner image is required for Task %s as it has conditional or parallel steps", task.Name)
+	}
+
+	stepsVolumeMount := core.VolumeMount{
+		Name:      util.StepsDirVolumeName,
+		MountPath: util.StepsDirMountPath,
+	}
+	stages := getStages(task.Spec.Steps)
+	for i := range containers {
+		step := task.Spec.Steps[i]
+		if len(containers[i].Command) == 0 {
+			return core.PodSpec{}, fmt.Errorf("Function %s must specify command to run as a conditional or parallel step of Task %s", step.Name, task.Name)
+		}
+		when := step.When
+		if when == "" {
+			when = v1beta1_api.StepOnSuccess
+		}
+		args := []string{
+			"run-step",
+			fmt.Sprintf("--step=%d", i),
+			fmt.Sprintf("--when=%s", when),
+		}
+		var waitFor, waitForContainers []string
+		for j := 0; j < i; j++ {
+			if stages[j] < stages[i] {
+				waitFor = append(waitFor, strconv.Itoa(j))
+				waitForContainers = append(waitForContainers, containers[j].Name)
+			}
+		}
+		if len(waitFor) > 0 {
+			args = append(args,
+				fmt.Sprintf("--wait-for=%s", strings.Join(waitFor, ",")),
+				fmt.Sprintf("--wait-for-containers=%s", strings.Join(waitForContainers, ",")),
+			)
+		}
+		args = append(args, "--")
+		args = append(args, containers[i].Command...)
+		args = append(args, containers[i].Args...)
+
+		containers[i].Command = []string{util.StepRunnerBinary}
+		containers[i].Args = args
+		containers[i].VolumeMounts = core_util.UpsertVolumeMountByPath(containers[i].VolumeMounts, stepsVolumeMount)
+		// the step runner watches the containers of the previous steps in its own pod
+		containers[i].Env = core_util.UpsertEnvVars(containers[i].Env, core.EnvVar{
+			Name: util.KeyPodName,
+			ValueFrom: &core.EnvVarSource{
+				FieldRef: &core.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		})
+	}
+
+	installer := core.Container{
+		Name:  util.StepRunnerContainer,
+		Image: o.StepRunnerImage,
+		Args: []string{
+			"install-step-runner",
+			fmt.Sprintf("--dir=%s", util.StepsDirMountPath),
+		},
+		VolumeMounts:    []core.VolumeMount{stepsVolumeMount},
+		ImagePullPolicy: core.PullIfNotPresent,
+	}
+	return core.PodSpec{
+		Volumes: core_util.UpsertVolume(task.Spec.Volumes, core.Volume{
+			Name: util.StepsDirVolumeName,
+			VolumeSource: core.VolumeSource{
+				EmptyDir: &core.EmptyDirVolumeSource{},
+			},
+		}),
+		InitContainers: []core.Container{installer},
+		Containers:     containers,
+		RestartPolicy:  core.RestartPolicyNever,
+	}, nil
+}
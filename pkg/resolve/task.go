@@ -3,6 +3,7 @@ package resolve
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -17,12 +18,22 @@ import (
 	"stash.appscode.dev/stash/pkg/util"
 )
 
+// valueNotFoundErrorPrefix is the prefix of the error returned by envsubst when a variable has no input or default value
+const valueNotFoundErrorPrefix = "input/default value not found for key "
+
 type TaskResolver struct {
 	StashClient     cs.Interface
 	TaskName        string
 	Inputs          map[string]string
 	RuntimeSettings ofst.RuntimeSettings
 	TempDir         v1beta1_api.EmptyDirSettings
+	// StepRunnerImage is the image that installs the step runner.
+	// It is required when the Task has conditional or parallel steps.
+	StepRunnerImage string
+
+	// unresolved collects the variables that have neither an input nor a default value.
+	// It is nil unless the PodSpec is resolved in dry run mode.
+	unresolved map[string]bool
 }
 
 func (o TaskResolver) GetPodSpec() (core.PodSpec, error) {
@@ -31,7 +42,7 @@ func (o TaskResolver) GetPodSpec() (core.PodSpec, error) {
 		return core.PodSpec{}, err
 	}
 	// resolve Task with inputs, modify in place
-	if err = resolveWithInputs(task, o.Inputs); err != nil {
+	if err = o.resolve(task, o.Inputs); err != nil {
 		return core.PodSpec{}, err
 	}
 
@@ -53,7 +64,7 @@ func (o TaskResolver) GetPodSpec() (core.PodSpec, error) {
 		inputs = core_util.UpsertMap(inputs, o.Inputs)
 
 		// resolve Function with inputs, modify in place
-		if err = resolveWithInputs(function, inputs); err != nil {
+		if err = o.resolve(function, inputs); err != nil {
 			return core.PodSpec{}, fmt.Errorf("can't resolve Function %s for Task %s, reason: %s", fn.Name, task.Name, err)
 		}
 
@@ -96,11 +107,18 @@ func (o TaskResolver) GetPodSpec() (core.PodSpec, error) {
 		return core.PodSpec{}, fmt.Errorf("empty steps/containers for Task %s", task.Name)
 	}
 	// podSpec from task
-	podSpec := core.PodSpec{
-		Volumes:        task.Spec.Volumes,
-		InitContainers: containers[:len(containers)-1],
-		Containers:     containers[len(containers)-1:],
-		RestartPolicy:  core.RestartPolicyNever, // TODO: use OnFailure ?
+	var podSpec core.PodSpec
+	if usesStepRunner(task.Spec.Steps) {
+		if podSpec, err = o.getStepRunnerPodSpec(task, containers); err != nil {
+			return core.PodSpec{}, err
+		}
+	} else {
+		podSpec = core.PodSpec{
+			Volumes:        task.Spec.Volumes,
+			InitContainers: containers[:len(containers)-1],
+			Containers:     containers[len(containers)-1:],
+			RestartPolicy:  core.RestartPolicyNever, // TODO: use OnFailure ?
+		}
 	}
 	// apply default pod level security context.
 	// don't overwrite user provided sc.
@@ -115,13 +133,106 @@ func (o TaskResolver) GetPodSpec() (core.PodSpec, error) {
 	return podSpec, nil
 }
 
+// GetPodSpecDryRun resolves the PodSpec like GetPodSpec. However, the variables that have neither an input nor
+// a default value are left as it is in the PodSpec and returned instead of failing the resolution.
+func (o TaskResolver) GetPodSpecDryRun() (core.PodSpec, []string, error) {
+	o.unresolved = make(map[string]bool)
+	podSpec, err := o.GetPodSpec()
+	if err != nil {
+		return core.PodSpec{}, nil, err
+	}
+	unresolved := make([]string, 0, len(o.unresolved))
+	for key := range o.unresolved {
+		unresolved = append(unresolved, key)
+	}
+	sort.Strings(unresolved)
+	return podSpec, unresolved, nil
+}
+
+// resolve resolves obj with the inputs. In dry run mode, the variables that can't be resolved are kept as placeholder.
+func (o TaskResolver) resolve(obj interface{}, inputs map[string]string) error {
+	if o.unresolved == nil {
+		return resolveWithInputs(obj, inputs)
+	}
+	inputs = core_util.UpsertMap(make(map[string]string), inputs)
+	for {
+		err := resolveWithInputs(obj, inputs)
+		if err == nil || !envsubst.IsValueNotFoundError(err) {
+			return err
+		}
+		key := strings.TrimPrefix(err.Error(), valueNotFoundErrorPrefix)
+		if _, found := inputs[key]; found {
+			return err
+		}
+		// values are not evaluated again. so, the placeholder is kept in the resolved object.
+		inputs[key] = "${" + key + "}"
+		o.unresolved[key] = true
+	}
+}
+
+// IsValid ensures that the Task and its Functions exist and can be resolved with the inputs.
+func (o TaskResolver) IsValid() error {
+	_, err := o.GetPodSpec()
+	return err
+}
+
+// ValidateTask ensures that the Functions of the Task exist and that the Task and its Functions can be resolved with
+// the params of the respective steps. Variables that are not provided by the params are ignored as they are provided
+// by the BackupConfiguration or RestoreSession that refers to the Task.
+func ValidateTask(stashClient cs.Interface, task *v1beta1_api.Task) error {
+	if err := ignoreValueNotFound(resolveWithInputs(task.DeepCopy(), nil)); err != nil {
+		return fmt.Errorf("can't resolve Task %s, reason: %s", task.Name, err)
+	}
+	for _, fn := range task.Spec.Steps {
+		function, err := stashClient.StashV1beta1().Functions().Get(fn.Name, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("can't get Function %s for Task %s, reason: %s", fn.Name, task.Name, err)
+		}
+		if usesStepRunner(task.Spec.Steps) && len(function.Spec.Command) == 0 {
+			return fmt.Errorf("Function %s must specify command to run as a conditional or parallel step of Task %s", fn.Name, task.Name)
+		}
+		inputs := make(map[string]string)
+		for _, param := range fn.Params {
+			inputs[param.Name] = param.Value
+		}
+		if err = ignoreValueNotFound(resolveWithInputs(function, inputs)); err != nil {
+			return fmt.Errorf("can't resolve Function %s for Task %s, reason: %s", fn.Name, task.Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateFunction ensures that the variables of the Function are well formed.
+func ValidateFunction(function *v1beta1_api.Function) error {
+	if err := ignoreValueNotFound(resolveWithInputs(function.DeepCopy(), nil)); err != nil {
+		return fmt.Errorf("can't resolve Function %s, reason: %s", function.Name, err)
+	}
+	return nil
+}
+
+func ignoreValueNotFound(err error) error {
+	if err != nil && envsubst.IsValueNotFoundError(err) {
+		return nil
+	}
+	return err
+}
+
 func resolveWithInputs(obj interface{}, inputs map[string]string) error {
 	// convert to JSON, apply replacements and convert back to struct
 	jsonObj, err := json.Marshal(obj)
 	if err != nil {
 		return err
 	}
-	resolved, err := envsubst.EvalMap(string(jsonObj), inputs)
+	// the inputs are substituted inside the JSON strings. so, they must be escaped as JSON string.
+	escaped := make(map[string]string, len(inputs))
+	for key, value := range inputs {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		escaped[key] = string(data[1 : len(data)-1])
+	}
+	resolved, err := envsubst.EvalMap(string(jsonObj), escaped)
 	if err != nil {
 		return err
 	}
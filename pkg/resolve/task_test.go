@@ -1,10 +1,13 @@
 package resolve
 
 import (
+	"reflect"
 	"testing"
 
 	"gomodules.xyz/envsubst"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"stash.appscode.dev/stash/apis/stash/v1beta1"
+	"stash.appscode.dev/stash/client/clientset/versioned/fake"
 )
 
 func TestResolveWithInputs(t *testing.T) {
@@ -40,4 +43,171 @@ func TestResolveWithInputs(t *testing.T) {
 	if err == nil || !envsubst.IsValueNotFoundError(err) {
 		t.Error("Expected ValueNotFoundError")
 	}
+
+	// values containing JSON special characters are kept intact
+	function = v1beta1.Function{
+		Spec: v1beta1.FunctionSpec{
+			Args: []string{"--include=${p1}"},
+		},
+	}
+	inputs = map[string]string{
+		"p1": `"a,b",c\\d`,
+	}
+	err = resolveWithInputs(&function, inputs)
+	if err != nil {
+		t.Error(err)
+	}
+	if want := "--include=" + inputs["p1"]; function.Spec.Args[0] != want {
+		t.Errorf("expected %s, found %s", want, function.Spec.Args[0])
+	}
+}
+
+func TestValidateFunction(t *testing.T) {
+	function := v1beta1.Function{
+		Spec: v1beta1.FunctionSpec{
+			Args: []string{
+				"--p1=${p1}",
+				"--p2=${p2:=d2}",
+			},
+		},
+	}
+	if err := ValidateFunction(&function); err != nil {
+		t.Errorf("Expected no error for unresolved variables, found: %v", err)
+	}
+	if function.Spec.Args[0] != "--p1=${p1}" {
+		t.Errorf("Expected Function to be unmodified, found: %v", function.Spec.Args)
+	}
+
+	function.Spec.Args = []string{"--p1=${p1"}
+	if err := ValidateFunction(&function); err == nil {
+		t.Error("Expected error for malformed variable")
+	}
+}
+
+func TestGetPodSpecDryRun(t *testing.T) {
+	stashClient := fake.NewSimpleClientset(
+		&v1beta1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc-backup"},
+			Spec: v1beta1.FunctionSpec{
+				Image: "appscode/stash:${STASH_VERSION}",
+				Args: []string{
+					"--repo=${REPOSITORY_NAME}",
+					"--paths=${TARGET_PATHS}",
+					"--hostname=${HOSTNAME:=host-0}",
+				},
+			},
+		},
+		&v1beta1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc-backup"},
+			Spec: v1beta1.TaskSpec{
+				Steps: []v1beta1.FunctionRef{
+					{
+						Name: "pvc-backup",
+						Params: []v1beta1.Param{
+							{Name: "STASH_VERSION", Value: "v0.9.0"},
+						},
+					},
+				},
+			},
+		},
+	)
+	taskResolver := TaskResolver{
+		StashClient: stashClient,
+		TaskName:    "pvc-backup",
+		Inputs: map[string]string{
+			"REPOSITORY_NAME": "gcs-repo",
+		},
+	}
+
+	if _, err := taskResolver.GetPodSpec(); err == nil {
+		t.Error("Expected error for unresolved variable")
+	}
+
+	podSpec, unresolved, err := taskResolver.GetPodSpecDryRun()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(unresolved, []string{"TARGET_PATHS"}) {
+		t.Errorf("Expected unresolved variables [TARGET_PATHS], found: %v", unresolved)
+	}
+	container := podSpec.Containers[0]
+	if container.Image != "appscode/stash:v0.9.0" {
+		t.Errorf("Expected image appscode/stash:v0.9.0, found: %s", container.Image)
+	}
+	expectedArgs := []string{"--repo=gcs-repo", "--paths=${TARGET_PATHS}", "--hostname=host-0"}
+	if !reflect.DeepEqual(container.Args, expectedArgs) {
+		t.Errorf("Expected args %v, found: %v", expectedArgs, container.Args)
+	}
+}
+
+func TestGetStages(t *testing.T) {
+	steps := []v1beta1.FunctionRef{
+		{Name: "pre-backup"},
+		{Name: "dump-db1", ParallelGroup: "dump"},
+		{Name: "dump-db2", ParallelGroup: "dump"},
+		{Name: "upload"},
+		{Name: "update-status", When: v1beta1.StepAlways},
+	}
+	expected := []int{0, 1, 1, 2, 3}
+	if stages := getStages(steps); !reflect.DeepEqual(stages, expected) {
+		t.Errorf("Expected stages %v, found: %v", expected, stages)
+	}
+}
+
+func TestGetPodSpecWithStepRunner(t *testing.T) {
+	stashClient := fake.NewSimpleClientset(
+		&v1beta1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: "pg-backup"},
+			Spec: v1beta1.FunctionSpec{
+				Image:   "appscode/postgres-stash:11",
+				Command: []string{"/stash-postgres"},
+				Args:    []string{"backup-pg", "--db=${DB}"},
+			},
+		},
+		&v1beta1.Function{
+			ObjectMeta: metav1.ObjectMeta{Name: "update-status"},
+			Spec: v1beta1.FunctionSpec{
+				Image:   "appscode/stash:v0.9.0",
+				Command: []string{"/stash"},
+				Args:    []string{"update-status"},
+			},
+		},
+		&v1beta1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "pg-backup"},
+			Spec: v1beta1.TaskSpec{
+				Steps: []v1beta1.FunctionRef{
+					{Name: "pg-backup", ParallelGroup: "dump", Params: []v1beta1.Param{{Name: "DB", Value: "db1"}}},
+					{Name: "pg-backup", ParallelGroup: "dump", Params: []v1beta1.Param{{Name: "DB", Value: "db2"}}},
+					{Name: "update-status", When: v1beta1.StepAlways},
+				},
+			},
+		},
+	)
+	taskResolver := TaskResolver{
+		StashClient:     stashClient,
+		TaskName:        "pg-backup",
+		StepRunnerImage: "appscode/stash:v0.9.0",
+	}
+	podSpec, err := taskResolver.GetPodSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(podSpec.InitContainers) != 1 || len(podSpec.Containers) != 3 {
+		t.Fatalf("Expected 1 init container and 3 containers, found: %d and %d", len(podSpec.InitContainers), len(podSpec.Containers))
+	}
+	expectedArgs := [][]string{
+		{"run-step", "--step=0", "--when=OnSuccess", "--", "/stash-postgres", "backup-pg", "--db=db1"},
+		{"run-step", "--step=1", "--when=OnSuccess", "--", "/stash-postgres", "backup-pg", "--db=db2"},
+		{"run-step", "--step=2", "--when=Always", "--wait-for=0,1", "--wait-for-containers=pg-backup-0,pg-backup-1", "--", "/stash", "update-status"},
+	}
+	for i, container := range podSpec.Containers {
+		if !reflect.DeepEqual(container.Args, expectedArgs[i]) {
+			t.Errorf("Expected args %v for step %d, found: %v", expectedArgs[i], i, container.Args)
+		}
+	}
+
+	taskResolver.StepRunnerImage = ""
+	if _, err = taskResolver.GetPodSpec(); err == nil {
+		t.Error("Expected error for missing step runner image")
+	}
 }
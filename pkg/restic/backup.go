@@ -31,16 +31,18 @@ func (w *ResticWrapper) RunBackup(backupOption BackupOptions) (*BackupOutput, er
 	backupOutput.HostBackupStats = []api_v1beta1.HostBackupStats{hostStats}
 
 	// Check repository integrity
-	out, err := w.check()
-	if err != nil {
-		return nil, err
+	if !backupOption.SkipCheck {
+		out, err := w.check("")
+		if err != nil {
+			return nil, err
+		}
+		// Extract information from output of "check" command
+		integrity := extractCheckInfo(out)
+		backupOutput.RepositoryStats.Integrity = types.BoolP(integrity)
 	}
-	// Extract information from output of "check" command
-	integrity := extractCheckInfo(out)
-	backupOutput.RepositoryStats.Integrity = types.BoolP(integrity)
 
 	// Cleanup old snapshots according to retention policy
-	out, err = w.cleanup(backupOption.RetentionPolicy, "")
+	out, err := w.cleanup(backupOption.RetentionPolicy, "")
 	if err != nil {
 		return nil, err
 	}
@@ -53,17 +55,19 @@ func (w *ResticWrapper) RunBackup(backupOption BackupOptions) (*BackupOutput, er
 	backupOutput.RepositoryStats.SnapshotsRemovedOnLastCleanup = removed
 
 	// Read repository statics after cleanup
-	out, err = w.stats("")
-	if err != nil {
-		return nil, err
-	}
+	if !backupOption.SkipStats {
+		out, err = w.stats("")
+		if err != nil {
+			return nil, err
+		}
 
-	// Extract information from output of "stats" command
-	repoSize, err := extractStatsInfo(out)
-	if err != nil {
-		return nil, err
+		// Extract information from output of "stats" command
+		repoSize, err := extractStatsInfo(out)
+		if err != nil {
+			return nil, err
+		}
+		backupOutput.RepositoryStats.Size = repoSize
 	}
-	backupOutput.RepositoryStats.Size = repoSize
 
 	for idx := range backupOutput.HostBackupStats {
 		if backupOutput.HostBackupStats[idx].Hostname == backupOption.Host {
@@ -144,20 +148,28 @@ func (w *ResticWrapper) RunParallelBackup(backupOptions []BackupOptions, maxConc
 		return nil, errors.NewAggregate(backupErrs)
 	}
 
+	// the maintenance of the repository is same for all the hosts
+	var skipCheck, skipStats bool
+	if len(backupOptions) > 0 {
+		skipCheck, skipStats = backupOptions[0].SkipCheck, backupOptions[0].SkipStats
+	}
+
 	// Check repository integrity
-	out, err := w.check()
-	if err != nil {
-		return nil, err
+	if !skipCheck {
+		out, err := w.check("")
+		if err != nil {
+			return nil, err
+		}
+		// Extract information from output of "check" command
+		integrity := extractCheckInfo(out)
+		backupOutput.RepositoryStats.Integrity = types.BoolP(integrity)
 	}
-	// Extract information from output of "check" command
-	integrity := extractCheckInfo(out)
-	backupOutput.RepositoryStats.Integrity = types.BoolP(integrity)
 
 	// Cleanup old snapshots according to retention policy
 	backupOutput.RepositoryStats.SnapshotCount = 0
 	backupOutput.RepositoryStats.SnapshotsRemovedOnLastCleanup = 0
 	for _, opt := range backupOptions {
-		out, err = w.cleanup(opt.RetentionPolicy, opt.Host)
+		out, err := w.cleanup(opt.RetentionPolicy, opt.Host)
 		if err != nil {
 			return nil, err
 		}
@@ -171,17 +183,19 @@ func (w *ResticWrapper) RunParallelBackup(backupOptions []BackupOptions, maxConc
 	}
 
 	// Read repository statics after cleanup
-	out, err = w.stats("")
-	if err != nil {
-		return nil, err
-	}
+	if !skipStats {
+		out, err := w.stats("")
+		if err != nil {
+			return nil, err
+		}
 
-	// Extract information from output of "stats" command
-	repoSize, err := extractStatsInfo(out)
-	if err != nil {
-		return nil, err
+		// Extract information from output of "stats" command
+		repoSize, err := extractStatsInfo(out)
+		if err != nil {
+			return nil, err
+		}
+		backupOutput.RepositoryStats.Size = repoSize
 	}
-	backupOutput.RepositoryStats.Size = repoSize
 
 	return backupOutput, nil
 }
@@ -209,7 +223,7 @@ func (w *ResticWrapper) runBackup(backupOption BackupOptions) (api_v1beta1.HostB
 
 	// Backup all target paths
 	for _, path := range backupOption.BackupPaths {
-		out, err := w.backup(path, backupOption.Host, nil)
+		out, err := w.backup(path, backupOption.Host, backupOption.Tags, backupOption.FileFilter)
 		if err != nil {
 			return hostStats, err
 		}
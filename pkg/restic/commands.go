@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -19,6 +20,9 @@ import (
 
 const (
 	ResticCMD = "/bin/restic_0.9.5"
+	// ResticNewerCMD is used for the features that are not available in ResticCMD,
+	// i.e. copying snapshots between repositories and accessing Azure using SAS token
+	ResticNewerCMD = "/bin/restic_0.12.1"
 )
 
 type Snapshot struct {
@@ -38,6 +42,7 @@ func (w *ResticWrapper) listSnapshots(snapshotIDs []string) ([]Snapshot, error)
 	args := w.appendCacheDirFlag([]interface{}{"snapshots", "--json", "--quiet", "--no-lock"})
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 	for _, id := range snapshotIDs {
 		args = append(args, id)
 	}
@@ -53,6 +58,7 @@ func (w *ResticWrapper) deleteSnapshots(snapshotIDs []string) ([]byte, error) {
 	args := w.appendCacheDirFlag([]interface{}{"forget", "--quiet", "--prune"})
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 	for _, id := range snapshotIDs {
 		args = append(args, id)
 	}
@@ -65,19 +71,34 @@ func (w *ResticWrapper) initRepositoryIfAbsent() ([]byte, error) {
 	args := w.appendCacheDirFlag([]interface{}{"snapshots", "--json"})
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 	if _, err := w.run(Command{Name: ResticCMD, Args: args}); err != nil {
 		args = w.appendCacheDirFlag([]interface{}{"init"})
 		args = w.appendCaCertFlag(args)
 		args = w.appendMaxConnectionsFlag(args)
+		args = w.appendBandwidthLimitFlags(args)
 
 		return w.run(Command{Name: ResticCMD, Args: args})
 	}
 	return nil, nil
 }
 
-func (w *ResticWrapper) backup(path, host string, tags []string) ([]byte, error) {
+func (w *ResticWrapper) backup(path, host string, tags []string, filter v1alpha1.FileFilter) ([]byte, error) {
 	log.Infoln("Backing up target data")
-	args := []interface{}{"backup", path, "--quiet", "--json"}
+	args := []interface{}{"backup"}
+	// if include patterns are specified, then backup only the matching files of the path.
+	// otherwise, backup the entire path.
+	if len(filter.Includes) > 0 {
+		includeFile, err := WriteIncludeFile(w.config.ScratchDir, path, filter.Includes)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(includeFile)
+		args = append(args, "--files-from", includeFile)
+	} else {
+		args = append(args, path)
+	}
+	args = append(args, "--quiet", "--json")
 	if host != "" {
 		args = append(args, "--host")
 		args = append(args, host)
@@ -87,10 +108,12 @@ func (w *ResticWrapper) backup(path, host string, tags []string) ([]byte, error)
 		args = append(args, "--tag")
 		args = append(args, tag)
 	}
+	args = AppendFileFilterFlags(args, filter)
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCleanupCacheFlag(args)
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
@@ -113,10 +136,16 @@ func (w *ResticWrapper) backupFromStdin(options BackupOptions) ([]byte, error) {
 		args = append(args, "--host")
 		args = append(args, options.Host)
 	}
+	// add tags if any
+	for _, tag := range options.Tags {
+		args = append(args, "--tag")
+		args = append(args, tag)
+	}
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCleanupCacheFlag(args)
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 
 	commands = append(commands, Command{Name: ResticCMD, Args: args})
 	return w.run(commands...)
@@ -171,13 +200,14 @@ func (w *ResticWrapper) cleanup(retentionPolicy v1alpha1.RetentionPolicy, host s
 		args = w.appendCacheDirFlag(args)
 		args = w.appendCaCertFlag(args)
 		args = w.appendMaxConnectionsFlag(args)
+		args = w.appendBandwidthLimitFlags(args)
 
 		return w.run(Command{Name: ResticCMD, Args: args})
 	}
 	return nil, nil
 }
 
-func (w *ResticWrapper) restore(path, host, snapshotID, destination string) ([]byte, error) {
+func (w *ResticWrapper) restore(path, host, snapshotID, destination string, includes, excludes []string) ([]byte, error) {
 	log.Infoln("Restoring backed up data")
 
 	args := []interface{}{"restore"}
@@ -199,10 +229,17 @@ func (w *ResticWrapper) restore(path, host, snapshotID, destination string) ([]b
 		destination = "/" // restore in absolute path
 	}
 	args = append(args, "--target", destination)
+	for _, pattern := range includes {
+		args = append(args, "--include", pattern)
+	}
+	for _, pattern := range excludes {
+		args = append(args, "--exclude", pattern)
+	}
 
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
@@ -233,6 +270,7 @@ func (w *ResticWrapper) dump(dumpOptions DumpOptions) ([]byte, error) {
 	args = w.appendCacheDirFlag(args)
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 
 	// first add restic command, then add StdoutPipeCommand
 	commands := []Command{
@@ -244,11 +282,26 @@ func (w *ResticWrapper) dump(dumpOptions DumpOptions) ([]byte, error) {
 	return w.run(commands...)
 }
 
-func (w *ResticWrapper) check() ([]byte, error) {
+func (w *ResticWrapper) check(readDataSubset string) ([]byte, error) {
 	log.Infoln("Checking integrity of repository")
 	args := w.appendCacheDirFlag([]interface{}{"check"})
+	if readDataSubset != "" {
+		args = append(args, "--read-data-subset", readDataSubset)
+	}
+	args = w.appendCaCertFlag(args)
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) prune() ([]byte, error) {
+	log.Infoln("Pruning unreferenced data from repository")
+	args := w.appendCacheDirFlag([]interface{}{"prune"})
+	args = w.appendCleanupCacheFlag(args)
 	args = w.appendCaCertFlag(args)
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
@@ -260,6 +313,7 @@ func (w *ResticWrapper) stats(snapshotID string) ([]byte, error) {
 		args = append(args, snapshotID)
 	}
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 	args = append(args, "--quiet", "--json")
 	args = w.appendCaCertFlag(args)
 
@@ -270,11 +324,120 @@ func (w *ResticWrapper) unlock() ([]byte, error) {
 	log.Infoln("Unlocking restic repository")
 	args := w.appendCacheDirFlag([]interface{}{"unlock", "--remove-all"})
 	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) unlockStale() ([]byte, error) {
+	log.Infoln("Removing stale locks from restic repository")
+	args := w.appendCacheDirFlag([]interface{}{"unlock"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
 	args = w.appendCaCertFlag(args)
 
 	return w.run(Command{Name: ResticCMD, Args: args})
 }
 
+func (w *ResticWrapper) listLocks() ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"list", "locks", "--no-lock"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) catLock(id string) ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"cat", "lock", id, "--no-lock"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) catConfig() ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"cat", "config", "--no-lock"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) listKeys() ([]byte, error) {
+	args := w.appendCacheDirFlag([]interface{}{"key", "list"})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) addKey(newPasswordFile string) ([]byte, error) {
+	log.Infoln("Adding new key to restic repository")
+	args := w.appendCacheDirFlag([]interface{}{"key", "add", "--new-password-file", newPasswordFile})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+func (w *ResticWrapper) removeKey(id string) ([]byte, error) {
+	log.Infoln("Removing key", id, "from restic repository")
+	args := w.appendCacheDirFlag([]interface{}{"key", "remove", id})
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	args = w.appendCaCertFlag(args)
+
+	return w.run(Command{Name: ResticCMD, Args: args})
+}
+
+// copySnapshots copies the given snapshots of the repository to the repository specified by RESTIC_REPOSITORY2
+// environment variable. All snapshots are copied if no snapshot is given. The caller has to set the environment
+// variables of the destination repository.
+func (w *ResticWrapper) copySnapshots(dstCacertFile string, snapshotIDs []string) ([]byte, error) {
+	log.Infoln("Copying snapshots to", w.sh.Env[RESTIC_REPOSITORY2])
+	args := w.appendCacheDirFlag([]interface{}{"copy"})
+	args = w.appendCaCertFlag(args)
+	if dstCacertFile != "" && dstCacertFile != w.config.CacertFile {
+		args = append(args, "--cacert", dstCacertFile)
+	}
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	for _, id := range snapshotIDs {
+		args = append(args, id)
+	}
+
+	return w.run(Command{Name: ResticNewerCMD, Args: args})
+}
+
+// initRepositoryWithChunkerParamsIfAbsent initializes the repository with the chunker parameters of the repository
+// specified by RESTIC_REPOSITORY2 environment variable if it does not exist. The snapshots copied from that repository
+// are deduplicated only if both repositories use the same chunker parameters.
+func (w *ResticWrapper) initRepositoryWithChunkerParamsIfAbsent(srcCacertFile string) ([]byte, error) {
+	log.Infoln("Ensuring restic repository in the backend")
+	args := w.appendCacheDirFlag([]interface{}{"snapshots", "--json"})
+	args = w.appendCaCertFlag(args)
+	args = w.appendMaxConnectionsFlag(args)
+	args = w.appendBandwidthLimitFlags(args)
+	if _, err := w.run(Command{Name: ResticCMD, Args: args}); err != nil {
+		args = w.appendCacheDirFlag([]interface{}{"init", "--copy-chunker-params"})
+		args = w.appendCaCertFlag(args)
+		if srcCacertFile != "" && srcCacertFile != w.config.CacertFile {
+			args = append(args, "--cacert", srcCacertFile)
+		}
+		args = w.appendMaxConnectionsFlag(args)
+		args = w.appendBandwidthLimitFlags(args)
+
+		return w.run(Command{Name: ResticNewerCMD, Args: args})
+	}
+	return nil, nil
+}
+
 func (w *ResticWrapper) appendCacheDirFlag(args []interface{}) []interface{} {
 	if w.config.EnableCache {
 		cacheDir := filepath.Join(w.config.ScratchDir, resticCacheDir)
@@ -301,6 +464,17 @@ func (w *ResticWrapper) appendMaxConnectionsFlag(args []interface{}) []interface
 	return args
 }
 
+// appendBandwidthLimitFlags limits the upload and download rate to the backend. The limits are in KiB/s.
+func (w *ResticWrapper) appendBandwidthLimitFlags(args []interface{}) []interface{} {
+	if w.config.LimitUpload > 0 {
+		args = append(args, "--limit-upload", strconv.Itoa(w.config.LimitUpload))
+	}
+	if w.config.LimitDownload > 0 {
+		args = append(args, "--limit-download", strconv.Itoa(w.config.LimitDownload))
+	}
+	return args
+}
+
 func (w *ResticWrapper) appendCleanupCacheFlag(args []interface{}) []interface{} {
 	if w.config.EnableCache {
 		return append(args, "--cleanup-cache")
@@ -308,6 +482,46 @@ func (w *ResticWrapper) appendCleanupCacheFlag(args []interface{}) []interface{}
 	return args
 }
 
+// AppendFileFilterFlags appends the flags to ignore files during backup
+func AppendFileFilterFlags(args []interface{}, filter v1alpha1.FileFilter) []interface{} {
+	for _, pattern := range filter.Excludes {
+		args = append(args, "--exclude", pattern)
+	}
+	for _, pattern := range filter.IExcludes {
+		args = append(args, "--iexclude", pattern)
+	}
+	for _, file := range filter.ExcludeFiles {
+		args = append(args, "--exclude-file", file)
+	}
+	if filter.ExcludeCaches {
+		args = append(args, "--exclude-caches")
+	}
+	for _, file := range filter.ExcludeIfPresent {
+		args = append(args, "--exclude-if-present", file)
+	}
+	if filter.OneFileSystem {
+		args = append(args, "--one-file-system")
+	}
+	return args
+}
+
+// WriteIncludeFile writes the include patterns joined with the target path in a temporary file of dir
+// to use with "--files-from" flag. restic expands the glob patterns of this file and backup only the matching files.
+func WriteIncludeFile(dir, path string, includes []string) (string, error) {
+	f, err := ioutil.TempFile(dir, "include-")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	for _, pattern := range includes {
+		if _, err = fmt.Fprintln(f, filepath.Join(path, pattern)); err != nil {
+			return "", err
+		}
+	}
+	return f.Name(), nil
+}
+
 func (w *ResticWrapper) appendCaCertFlag(args []interface{}) []interface{} {
 	if w.config.CacertFile != "" {
 		return append(args, "--cacert", w.config.CacertFile)
@@ -323,8 +537,17 @@ func (w *ResticWrapper) run(commands ...Command) ([]byte, error) {
 	}
 	w.sh.Stderr = io.MultiWriter(os.Stderr, errBuff)
 
+	// fetch the short-lived credentials of the backend before each invocation
+	if err := w.refreshCredentials(); err != nil {
+		return nil, err
+	}
+
 	for _, cmd := range commands {
-		if cmd.Name == ResticCMD {
+		if cmd.Name == ResticCMD || cmd.Name == ResticNewerCMD {
+			// the backend requires a feature that is not available in the default restic
+			if w.useNewerRestic {
+				cmd.Name = ResticNewerCMD
+			}
 			// first apply NiceSettings, then apply IONiceSettings
 			cmd, err = w.applyNiceSettings(cmd)
 			if err != nil {
@@ -337,6 +560,14 @@ func (w *ResticWrapper) run(commands ...Command) ([]byte, error) {
 		}
 		w.sh.Command(cmd.Name, cmd.Args...)
 	}
+	// kill the command if it does not complete before the deadline
+	if !w.deadline.IsZero() {
+		remaining := time.Until(w.deadline)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("deadline exceeded")
+		}
+		w.sh.SetTimeout(remaining)
+	}
 	out, err := w.sh.Output()
 	if err != nil {
 		return nil, formatError(err, errBuff.String())
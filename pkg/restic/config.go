@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"time"
 
 	shell "github.com/codeskyblue/go-sh"
 	ofst "kmodules.xyz/offshoot-api/api/v1"
@@ -21,6 +22,13 @@ const (
 type ResticWrapper struct {
 	sh     *shell.Session
 	config SetupOptions
+	// deadline indicates the time after which the running restic command is killed
+	deadline time.Time
+	// credentialProviders fetch the short-lived credentials of the backend before each restic invocation
+	credentialProviders []CredentialProvider
+	// useNewerRestic indicates that the backend can only be accessed using ResticNewerCMD (i.e. Azure with SAS token).
+	// Then, every command is run using ResticNewerCMD.
+	useNewerRestic bool
 }
 
 type Command struct {
@@ -36,6 +44,10 @@ type BackupOptions struct {
 	StdinPipeCommand Command
 	StdinFileName    string // default "stdin"
 	RetentionPolicy  v1alpha1.RetentionPolicy
+	FileFilter       v1alpha1.FileFilter // will not be used for backup from stdin
+	Tags             []string            // tags that will be added to the snapshots
+	SkipCheck        bool                // integrity check is done by the repository maintenance job
+	SkipStats        bool                // repository stats are read by the repository maintenance job
 }
 
 // RestoreOptions specifies restore information
@@ -43,8 +55,14 @@ type RestoreOptions struct {
 	Host         string
 	SourceHost   string
 	RestorePaths []string
-	Snapshots    []string // when Snapshots are specified SourceHost and RestorePaths will not be used
-	Destination  string   // destination path where snapshot will be restored, used in cli
+	Snapshots    []string          // when Snapshots are specified SourceHost and RestorePaths will not be used
+	Destination  string            // destination path where snapshot will be restored, used in cli
+	PathMapping  map[string]string // backed up path -> directory where its content will be restored
+	Includes     []string          // restore only the files matching these patterns
+	Excludes     []string          // don't restore the files matching these patterns
+	Before       *time.Time        // restore the latest snapshot taken before this time
+	After        *time.Time        // restore the latest snapshot taken after this time
+	Tags         []string          // restore the latest snapshot having all of these tags
 }
 
 type DumpOptions struct {
@@ -66,8 +84,13 @@ type SetupOptions struct {
 	ScratchDir     string
 	EnableCache    bool
 	MaxConnections int
+	LimitUpload    int // KiB/s, no limit if zero
+	LimitDownload  int // KiB/s, no limit if zero
 	Nice           *ofst.NiceSettings
 	IONice         *ofst.IONiceSettings
+	// DisableCredentialProviders ignores the credential provider hooks and the web identity of the storage secret.
+	// It must be set by the processes that access the repositories of other users, i.e. the operator.
+	DisableCredentialProviders bool
 }
 
 type MetricsOptions struct {
@@ -131,6 +154,11 @@ func (w *ResticWrapper) HideCMD() {
 	}
 }
 
+// SetDeadline sets the time after which the running restic command will be killed
+func (w *ResticWrapper) SetDeadline(deadline time.Time) {
+	w.deadline = deadline
+}
+
 func (w *ResticWrapper) GetRepo() string {
 	if w.sh != nil {
 		return w.sh.Env[RESTIC_REPOSITORY]
@@ -162,5 +190,7 @@ func (w *ResticWrapper) Copy() *ResticWrapper {
 
 	}
 	out.config = w.config
+	out.credentialProviders = w.credentialProviders
+	out.useNewerRestic = w.useNewerRestic
 	return out
 }
@@ -0,0 +1,199 @@
+package restic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/appscode/go/log"
+)
+
+var copiedSnapshotRegex = regexp.MustCompile(`(?m)^snapshot [0-9a-f]+ saved$`)
+
+// CopyStats holds the result of copying the snapshots of a repository to another repository
+type CopyStats struct {
+	// SnapshotsCopied shows the number of snapshots copied to the destination repository
+	SnapshotsCopied int
+	// LatestSnapshotTime shows the creation time of the latest snapshot of the source repository
+	// that existed when the copy has started
+	LatestSnapshotTime *time.Time
+}
+
+// CopySnapshots copies the snapshots of the repository that are not in the destination repository yet.
+// The destination repository is initialized with the chunker parameters of the repository if it does not exist,
+// so that the copied data is deduplicated. restic reads the credentials of both backends from the same environment
+// variables. So, if the backends require different values for the same variable (i.e. two S3 buckets with different
+// access keys), the snapshots are copied through a local repository in the scratch directory using two processes
+// that access one backend each.
+func (w *ResticWrapper) CopySnapshots(dst *ResticWrapper) (*CopyStats, error) {
+	// the short-lived credentials of the destination have to be in its environment before they are merged
+	if err := w.refreshCredentials(); err != nil {
+		return nil, err
+	}
+	if err := dst.refreshCredentials(); err != nil {
+		return nil, err
+	}
+
+	stats := &CopyStats{}
+	snapshots, err := w.ListSnapshots(nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range snapshots {
+		if stats.LatestSnapshotTime == nil || snapshots[i].Time.After(*stats.LatestSnapshotTime) {
+			stats.LatestSnapshotTime = &snapshots[i].Time
+		}
+	}
+
+	var out []byte
+	if _, err := copyEnv(w.sh.Env, dst.sh.Env); err == nil {
+		out, err = w.copySnapshotsDirectly(dst, snapshots)
+	} else {
+		log.Infof("Copying snapshots through a local repository. Reason: %v", err)
+		out, err = w.copySnapshotsThroughLocalRepository(dst, snapshots)
+	}
+	if err != nil {
+		return stats, err
+	}
+	stats.SnapshotsCopied = len(copiedSnapshotRegex.FindAll(out, -1))
+	return stats, nil
+}
+
+// copySnapshotsDirectly copies the snapshots using a single restic process that accesses both backends
+func (w *ResticWrapper) copySnapshotsDirectly(dst *ResticWrapper, snapshots []Snapshot) ([]byte, error) {
+	// initialize the destination with the chunker parameters of the source
+	initWrapper, err := dst.withSecondaryRepository(w)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := initWrapper.initRepositoryWithChunkerParamsIfAbsent(w.config.CacertFile); err != nil {
+		return nil, err
+	}
+	ids, err := dst.missingSnapshots(snapshots)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	// don't add the environment variables of the destination repository to this wrapper
+	copyWrapper, err := w.withSecondaryRepository(dst)
+	if err != nil {
+		return nil, err
+	}
+	return copyWrapper.copySnapshots(dst.config.CacertFile, ids)
+}
+
+// copySnapshotsThroughLocalRepository copies the missing snapshots into a local repository using the credentials of
+// the source and then copies them from the local repository into the destination using the credentials of the
+// destination. The local repository has the chunker parameters of the source. So, the data is deduplicated.
+func (w *ResticWrapper) copySnapshotsThroughLocalRepository(dst *ResticWrapper, snapshots []Snapshot) ([]byte, error) {
+	localRepo := filepath.Join(w.config.ScratchDir, resticCopyDir)
+	// the local repository holds the data of the copied snapshots only while they are being copied
+	if err := os.RemoveAll(localRepo); err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(localRepo)
+	password := w.sh.Env[RESTIC_PASSWORD]
+
+	// source -> local repository
+	localWrapper := w.Copy()
+	localWrapper.config.CacertFile = ""
+	localWrapper.SetEnv(RESTIC_REPOSITORY, localRepo)
+	localWrapper.SetEnv(RESTIC_REPOSITORY2, w.sh.Env[RESTIC_REPOSITORY])
+	localWrapper.SetEnv(RESTIC_PASSWORD2, password)
+	if _, err := localWrapper.initRepositoryWithChunkerParamsIfAbsent(w.config.CacertFile); err != nil {
+		return nil, err
+	}
+
+	// initialize the destination with the chunker parameters of the local repository, i.e. of the source
+	initWrapper := dst.Copy()
+	initWrapper.SetEnv(RESTIC_REPOSITORY2, localRepo)
+	initWrapper.SetEnv(RESTIC_PASSWORD2, password)
+	if _, err := initWrapper.initRepositoryWithChunkerParamsIfAbsent(""); err != nil {
+		return nil, err
+	}
+	ids, err := dst.missingSnapshots(snapshots)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	srcWrapper := w.Copy()
+	srcWrapper.SetEnv(RESTIC_REPOSITORY2, localRepo)
+	srcWrapper.SetEnv(RESTIC_PASSWORD2, password)
+	if _, err := srcWrapper.copySnapshots("", ids); err != nil {
+		return nil, err
+	}
+
+	// local repository -> destination
+	dstWrapper := dst.Copy()
+	dstWrapper.SetEnv(RESTIC_REPOSITORY, localRepo)
+	dstWrapper.SetEnv(RESTIC_PASSWORD, password)
+	dstWrapper.SetEnv(RESTIC_REPOSITORY2, dst.sh.Env[RESTIC_REPOSITORY])
+	dstWrapper.SetEnv(RESTIC_PASSWORD2, dst.sh.Env[RESTIC_PASSWORD])
+	return dstWrapper.copySnapshots("", nil)
+}
+
+// withSecondaryRepository returns a copy of the wrapper that can also access the repository of the given wrapper
+// as the secondary repository (i.e. RESTIC_REPOSITORY2)
+func (w *ResticWrapper) withSecondaryRepository(secondary *ResticWrapper) (*ResticWrapper, error) {
+	env, err := copyEnv(w.sh.Env, secondary.sh.Env)
+	if err != nil {
+		return nil, err
+	}
+	out := w.Copy()
+	for k, v := range env {
+		out.SetEnv(k, v)
+	}
+	return out, nil
+}
+
+// missingSnapshots returns the IDs of the snapshots that are not in the repository. restic keeps the time,
+// the host and the tree of a snapshot when it is copied.
+func (w *ResticWrapper) missingSnapshots(snapshots []Snapshot) ([]string, error) {
+	existing, err := w.ListSnapshots(nil)
+	if err != nil {
+		return nil, err
+	}
+	return missingSnapshotIDs(snapshots, existing), nil
+}
+
+func missingSnapshotIDs(snapshots, existing []Snapshot) []string {
+	copied := make(map[string]bool, len(existing))
+	for _, s := range existing {
+		copied[snapshotCopyKey(s)] = true
+	}
+	ids := make([]string, 0)
+	for _, s := range snapshots {
+		if !copied[snapshotCopyKey(s)] {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
+
+func snapshotCopyKey(s Snapshot) string {
+	return fmt.Sprintf("%s/%s/%s", s.Time.UTC().Format(time.RFC3339Nano), s.Hostname, s.Tree)
+}
+
+// copyEnv returns the environment variables that have to be added to the primary repository to access the secondary
+// repository. It returns an error if both repositories require different values for the same environment variable.
+func copyEnv(srcEnv, dstEnv map[string]string) (map[string]string, error) {
+	env := map[string]string{}
+	for k, v := range dstEnv {
+		switch k {
+		case RESTIC_REPOSITORY:
+			env[RESTIC_REPOSITORY2] = v
+		case RESTIC_PASSWORD:
+			env[RESTIC_PASSWORD2] = v
+		case TMPDIR:
+			// use the temporary directory of the primary repository
+		default:
+			if srcValue, found := srcEnv[k]; found && srcValue != v {
+				return nil, fmt.Errorf("both repositories require different values for environment variable %s. restic can't access them at the same time", k)
+			}
+			env[k] = v
+		}
+	}
+	return env, nil
+}
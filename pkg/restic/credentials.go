@@ -0,0 +1,241 @@
+package restic
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	AWS_SESSION_TOKEN           = "AWS_SESSION_TOKEN"
+	AWS_ROLE_ARN                = "AWS_ROLE_ARN"
+	AWS_WEB_IDENTITY_TOKEN_FILE = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	AWS_REGION                  = "AWS_REGION"
+	// AWS_STS_ENDPOINT overrides the endpoint of the STS service used to exchange the web identity token
+	AWS_STS_ENDPOINT = "AWS_STS_ENDPOINT"
+
+	// AZURE_ACCOUNT_SAS is the SAS token used to access Azure instead of the account key. SAS token is supported
+	// since restic 0.12.0. So, every restic command of a repository whose storage secret has it is run using
+	// ResticNewerCMD instead of ResticCMD.
+	AZURE_ACCOUNT_SAS = "AZURE_ACCOUNT_SAS"
+
+	// CREDENTIAL_PROVIDER_COMMAND is a command that prints the credentials of the backend as a JSON object
+	// of environment variables (i.e. {"AWS_ACCESS_KEY_ID": "...", "AWS_SECRET_ACCESS_KEY": "..."})
+	CREDENTIAL_PROVIDER_COMMAND = "CREDENTIAL_PROVIDER_COMMAND"
+	// CREDENTIAL_PROVIDER_FILE is the path of a file holding the credentials of the backend either as a JSON object
+	// of environment variables or as KEY=VALUE lines. It is expected to be kept up to date by another process (i.e. an agent sidecar).
+	CREDENTIAL_PROVIDER_FILE = "CREDENTIAL_PROVIDER_FILE"
+	// The credential providers run the command, read the file or send the web identity token with the privileges of the
+	// process. So, they are used only by the backup, restore and maintenance processes of the workloads and the jobs.
+	// The operator disables them using SetupOptions.DisableCredentialProviders.
+
+	defaultSTSEndpoint = "https://sts.amazonaws.com"
+	// refresh the temporary credentials before they expire so that they don't expire during a restic invocation
+	credentialExpiryWindow = 5 * time.Minute
+)
+
+// stsClient is used to exchange the web identity token. The request must not block the backup if STS is unreachable.
+var stsClient = &http.Client{Timeout: 30 * time.Second}
+
+// CredentialProvider provides the short-lived credentials of a backend as environment variables of restic.
+// The credentials are fetched before each restic invocation.
+type CredentialProvider interface {
+	Credentials() (map[string]string, error)
+}
+
+func (w *ResticWrapper) refreshCredentials() error {
+	for _, provider := range w.credentialProviders {
+		env, err := provider.Credentials()
+		if err != nil {
+			return fmt.Errorf("failed to fetch credentials of the backend. Reason: %v", err)
+		}
+		for k, v := range env {
+			w.sh.SetEnv(k, v)
+		}
+	}
+	return nil
+}
+
+// setupCredentialProviders configures the credential provider hook specified in the storage secret
+func (w *ResticWrapper) setupCredentialProviders() {
+	if w.config.DisableCredentialProviders {
+		return
+	}
+	if v, err := ioutil.ReadFile(filepath.Join(w.config.SecretDir, CREDENTIAL_PROVIDER_COMMAND)); err == nil {
+		w.credentialProviders = append(w.credentialProviders, &commandCredentialProvider{command: strings.TrimSpace(string(v))})
+	}
+	if v, err := ioutil.ReadFile(filepath.Join(w.config.SecretDir, CREDENTIAL_PROVIDER_FILE)); err == nil {
+		w.credentialProviders = append(w.credentialProviders, &fileCredentialProvider{path: strings.TrimSpace(string(v))})
+	}
+}
+
+// commandCredentialProvider runs a local command and reads the credentials from its output
+type commandCredentialProvider struct {
+	command string
+}
+
+func (p *commandCredentialProvider) Credentials() (map[string]string, error) {
+	args := strings.Fields(p.command)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty credential provider command")
+	}
+	out, err := exec.Command(args[0], args[1:]...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("credential provider command %q has failed. Reason: %v", args[0], err)
+	}
+	return parseCredentials(out)
+}
+
+// fileCredentialProvider reads the credentials from a file
+type fileCredentialProvider struct {
+	path string
+}
+
+func (p *fileCredentialProvider) Credentials() (map[string]string, error) {
+	data, err := ioutil.ReadFile(p.path)
+	if err != nil {
+		return nil, err
+	}
+	return parseCredentials(data)
+}
+
+// parseCredentials parses the credentials written either as a JSON object or as KEY=VALUE lines
+func parseCredentials(data []byte) (map[string]string, error) {
+	data = bytes.TrimSpace(data)
+	env := map[string]string{}
+	if bytes.HasPrefix(data, []byte("{")) {
+		if err := json.Unmarshal(data, &env); err != nil {
+			// don't add the content to the error as it may be any file of the process
+			return nil, fmt.Errorf("failed to parse credentials. Reason: not a JSON object of strings")
+		}
+		return env, nil
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(line, "export "), "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("failed to parse credentials. Reason: line %d is not in KEY=VALUE format", lineNo)
+		}
+		env[strings.TrimSpace(parts[0])] = strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+	}
+	return env, scanner.Err()
+}
+
+// webIdentityCredentialProvider exchanges the web identity token of the pod (i.e. the projected ServiceAccount token of
+// EKS IAM roles for service accounts) for temporary AWS credentials using STS AssumeRoleWithWebIdentity.
+type webIdentityCredentialProvider struct {
+	roleARN     string
+	tokenFile   string
+	endpoint    string
+	sessionName string
+
+	mu          sync.Mutex
+	credentials map[string]string
+	expiration  time.Time
+}
+
+// newWebIdentityCredentialProvider returns a provider if the role and the token file are specified either
+// in the storage secret or in the environment of the pod. Otherwise, it returns nil.
+func newWebIdentityCredentialProvider(secretDir string) *webIdentityCredentialProvider {
+	roleARN := readSecretOrEnv(secretDir, AWS_ROLE_ARN)
+	tokenFile := readSecretOrEnv(secretDir, AWS_WEB_IDENTITY_TOKEN_FILE)
+	if roleARN == "" || tokenFile == "" {
+		return nil
+	}
+
+	endpoint := readSecretOrEnv(secretDir, AWS_STS_ENDPOINT)
+	if endpoint == "" {
+		endpoint = defaultSTSEndpoint
+		if region := readSecretOrEnv(secretDir, AWS_REGION); region != "" {
+			endpoint = fmt.Sprintf("https://sts.%s.amazonaws.com", region)
+		}
+	}
+	sessionName, _ := os.Hostname()
+	return &webIdentityCredentialProvider{
+		roleARN:     roleARN,
+		tokenFile:   tokenFile,
+		endpoint:    endpoint,
+		sessionName: "stash-" + sessionName,
+	}
+}
+
+type assumeRoleWithWebIdentityResponse struct {
+	Credentials struct {
+		AccessKeyId     string    `xml:"AccessKeyId"`
+		SecretAccessKey string    `xml:"SecretAccessKey"`
+		SessionToken    string    `xml:"SessionToken"`
+		Expiration      time.Time `xml:"Expiration"`
+	} `xml:"AssumeRoleWithWebIdentityResult>Credentials"`
+}
+
+func (p *webIdentityCredentialProvider) Credentials() (map[string]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.credentials != nil && time.Now().Add(credentialExpiryWindow).Before(p.expiration) {
+		return p.credentials, nil
+	}
+
+	// the token is rotated by kubelet. so, read it every time.
+	token, err := ioutil.ReadFile(p.tokenFile)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{}
+	form.Set("Action", "AssumeRoleWithWebIdentity")
+	form.Set("Version", "2011-06-15")
+	form.Set("RoleArn", p.roleARN)
+	form.Set("RoleSessionName", p.sessionName)
+	form.Set("WebIdentityToken", strings.TrimSpace(string(token)))
+
+	resp, err := stsClient.PostForm(p.endpoint, form)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to assume role %s. Reason: %s: %s", p.roleARN, resp.Status, string(body))
+	}
+
+	result := assumeRoleWithWebIdentityResponse{}
+	if err := xml.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse the response of STS. Reason: %v", err)
+	}
+	p.credentials = map[string]string{
+		AWS_ACCESS_KEY_ID:     result.Credentials.AccessKeyId,
+		AWS_SECRET_ACCESS_KEY: result.Credentials.SecretAccessKey,
+		AWS_SESSION_TOKEN:     result.Credentials.SessionToken,
+	}
+	p.expiration = result.Credentials.Expiration
+	return p.credentials, nil
+}
+
+// readSecretOrEnv reads the value of a key from the storage secret. If it is not there, it reads the environment variable.
+func readSecretOrEnv(secretDir, key string) string {
+	if v, err := ioutil.ReadFile(filepath.Join(secretDir, key)); err == nil {
+		return strings.TrimSpace(string(v))
+	}
+	return os.Getenv(key)
+}
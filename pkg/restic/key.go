@@ -0,0 +1,136 @@
+package restic
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const passwordLength = 32
+
+// Key holds the information of a key of the repository
+type Key struct {
+	ID       string
+	Current  bool // whether the key has been used to open the repository
+	Username string
+	Hostname string
+	Created  string
+}
+
+// ListKeys returns the keys of the repository
+func (w *ResticWrapper) ListKeys() ([]Key, error) {
+	out, err := w.listKeys()
+	if err != nil {
+		return nil, err
+	}
+	return parseKeys(out), nil
+}
+
+// AddKey adds a new key with the given password to the repository and returns the ID of the new key
+func (w *ResticWrapper) AddKey(newPassword string) (string, error) {
+	oldKeys, err := w.ListKeys()
+	if err != nil {
+		return "", err
+	}
+
+	// write the new password in a file so that it does not appear in the arguments of the command
+	passwordFile := filepath.Join(w.config.ScratchDir, "new-password")
+	if err := ioutil.WriteFile(passwordFile, []byte(newPassword), 0600); err != nil {
+		return "", err
+	}
+	defer os.Remove(passwordFile)
+
+	if _, err := w.addKey(passwordFile); err != nil {
+		return "", err
+	}
+
+	newKeys, err := w.ListKeys()
+	if err != nil {
+		return "", err
+	}
+	added := addedKeys(oldKeys, newKeys)
+	if len(added) != 1 {
+		return "", fmt.Errorf("failed to identify the new key. found %d new keys", len(added))
+	}
+	return added[0].ID, nil
+}
+
+// RemoveKey removes the key with the given ID from the repository. The current key can't be removed.
+func (w *ResticWrapper) RemoveKey(id string) error {
+	_, err := w.removeKey(id)
+	return err
+}
+
+// CurrentKey returns the key that has been used to open the repository
+func (w *ResticWrapper) CurrentKey() (*Key, error) {
+	keys, err := w.ListKeys()
+	if err != nil {
+		return nil, err
+	}
+	for i := range keys {
+		if keys[i].Current {
+			return &keys[i], nil
+		}
+	}
+	return nil, fmt.Errorf("failed to identify the current key of the repository")
+}
+
+// SetPassword changes the password used to open the repository by the subsequent commands
+func (w *ResticWrapper) SetPassword(password string) {
+	w.sh.SetEnv(RESTIC_PASSWORD, password)
+}
+
+// GeneratePassword generates a random password for a new key
+func GeneratePassword() (string, error) {
+	buf := make([]byte, passwordLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// parseKeys parses the output of "key list" command. The output looks like:
+//
+//	 ID        User  Host    Created
+//	----------------------------------------------
+//	*4e5b1a62  root  host-0  2019-10-10 10:10:10
+//	 9f8e7d6c  root  host-1  2019-10-11 10:10:10
+//	----------------------------------------------
+//
+// The current key is marked with "*".
+func parseKeys(out []byte) []Key {
+	keys := make([]Key, 0)
+	for _, line := range strings.Split(string(out), "\n") {
+		current := strings.HasPrefix(line, "*")
+		fields := strings.Fields(strings.TrimPrefix(line, "*"))
+		if len(fields) < 3 || fields[0] == "ID" || strings.HasPrefix(fields[0], "-") {
+			continue
+		}
+		keys = append(keys, Key{
+			ID:       fields[0],
+			Current:  current,
+			Username: fields[1],
+			Hostname: fields[2],
+			Created:  strings.Join(fields[3:], " "),
+		})
+	}
+	return keys
+}
+
+func addedKeys(oldKeys, newKeys []Key) []Key {
+	present := make(map[string]bool, len(oldKeys))
+	for _, key := range oldKeys {
+		present[key.ID] = true
+	}
+	var added []Key
+	for _, key := range newKeys {
+		if !present[key.ID] {
+			added = append(added, key)
+		}
+	}
+	return added
+}
@@ -0,0 +1,77 @@
+package restic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/appscode/go/types"
+)
+
+type MaintenanceOperation string
+
+const (
+	MaintenanceCheck MaintenanceOperation = "check"
+	MaintenancePrune MaintenanceOperation = "prune"
+	// MaintenanceRotateKey replaces the key of the repository. It is not run by RunMaintenance
+	// as it has to update the storage secret too.
+	MaintenanceRotateKey MaintenanceOperation = "rotate-key"
+)
+
+// MaintenanceOptions specifies the maintenance operation to run on a repository
+type MaintenanceOptions struct {
+	Operation      MaintenanceOperation
+	ReadDataSubset string // subset of the data packs to verify, only used by check
+}
+
+// RunMaintenance checks the integrity of the repository or removes the unreferenced data from the repository.
+// Then, it reads the statistics of the repository. If the integrity check finds errors in the repository,
+// the returned RepositoryStats holds the failed integrity along with the error.
+func (w *ResticWrapper) RunMaintenance(opt MaintenanceOptions) (*RepositoryStats, error) {
+	repoStats := &RepositoryStats{}
+
+	switch opt.Operation {
+	case MaintenanceCheck:
+		out, err := w.check(opt.ReadDataSubset)
+		if err != nil {
+			// the check might not have run at all (i.e. the backend is unreachable or the repository is locked).
+			// so, the integrity is reported as failed only if restic has found errors in the repository.
+			if !isIntegrityError(err) {
+				return nil, err
+			}
+			repoStats.Integrity = types.FalseP()
+			return repoStats, err
+		}
+		// Extract information from output of "check" command
+		repoStats.Integrity = types.BoolP(extractCheckInfo(out))
+	case MaintenancePrune:
+		if _, err := w.prune(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown maintenance operation %q", opt.Operation)
+	}
+
+	snapshots, err := w.ListSnapshots(nil)
+	if err != nil {
+		return nil, err
+	}
+	repoStats.SnapshotCount = len(snapshots)
+
+	// Read repository statics after maintenance
+	out, err := w.stats("")
+	if err != nil {
+		return nil, err
+	}
+	// Extract information from output of "stats" command
+	repoStats.Size, err = extractStatsInfo(out)
+	if err != nil {
+		return nil, err
+	}
+	return repoStats, nil
+}
+
+// isIntegrityError checks whether "restic check" has failed because it has found errors in the repository.
+// restic ends the output with "Fatal: repository contains errors" in this case.
+func isIntegrityError(err error) bool {
+	return strings.Contains(err.Error(), "repository contains errors")
+}
@@ -15,6 +15,7 @@ import (
 	appcatalog "kmodules.xyz/custom-resources/apis/appcatalog/v1alpha1"
 	appcatalog_cs "kmodules.xyz/custom-resources/client/clientset/versioned"
 	"stash.appscode.dev/stash/apis"
+	"stash.appscode.dev/stash/apis/stash/v1alpha1"
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 	cs "stash.appscode.dev/stash/client/clientset/versioned"
 )
@@ -29,6 +30,9 @@ const (
 	MetricsLabelBackend    = "backend"
 	MetricsLabelBucket     = "bucket"
 	MetricsLabelPrefix     = "prefix"
+
+	MetricsLabelBackupConfiguration = "backup_configuration"
+	MetricsLabelReplica             = "replica"
 )
 
 // BackupMetrics defines prometheus metrics for backup process
@@ -103,6 +107,18 @@ type RestoreHostMetrics struct {
 	RestoreDuration prometheus.Gauge
 }
 
+// RestoreVerificationMetrics defines metrics for the restore verification of a BackupConfiguration
+type RestoreVerificationMetrics struct {
+	// VerificationSuccess indicates whether the restore verification succeeded or not
+	VerificationSuccess prometheus.Gauge
+	// VerificationDuration indicates the time taken to complete the restore verification
+	VerificationDuration prometheus.Gauge
+	// VerificationTime indicates the time when the restore verification has completed
+	VerificationTime prometheus.Gauge
+	// CheckSuccess indicates whether the individual checks succeeded or not
+	CheckSuccess *prometheus.GaugeVec
+}
+
 // RepositoryMetrics defines Prometheus metrics for Repository state after each backup
 type RepositoryMetrics struct {
 	// RepoIntegrity shows result of repository integrity check after last backup
@@ -315,6 +331,121 @@ func newRestoreSessionMetrics(labels prometheus.Labels) *RestoreMetrics {
 	}
 }
 
+// ReplicationMetrics defines Prometheus metrics for the replication of a Repository to another Repository
+type ReplicationMetrics struct {
+	// ReplicationSuccess indicates whether the latest copy succeeded or not
+	ReplicationSuccess prometheus.Gauge
+	// ReplicationLag indicates how far the replica is behind the Repository
+	ReplicationLag prometheus.Gauge
+	// LastSuccessTime indicates the time when the snapshots have been copied successfully last time
+	LastSuccessTime prometheus.Gauge
+	// SnapshotsCopied indicates the number of snapshots copied by the latest copy
+	SnapshotsCopied prometheus.Gauge
+}
+
+func newReplicationMetrics(labels prometheus.Labels) *ReplicationMetrics {
+	return &ReplicationMetrics{
+		ReplicationSuccess: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "repository_replication",
+				Name:        "success",
+				Help:        "Indicates whether the latest copy of the snapshots to the replica succeeded or not",
+				ConstLabels: labels,
+			},
+		),
+		ReplicationLag: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "repository_replication",
+				Name:        "lag_seconds",
+				Help:        "Indicates how far the replica is behind the repository",
+				ConstLabels: labels,
+			},
+		),
+		LastSuccessTime: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "repository_replication",
+				Name:        "last_success_time_seconds",
+				Help:        "Indicates the time when the snapshots have been copied to the replica successfully last time in unix timestamp",
+				ConstLabels: labels,
+			},
+		),
+		SnapshotsCopied: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "repository_replication",
+				Name:        "snapshots_copied",
+				Help:        "Indicates the number of snapshots copied to the replica by the latest copy",
+				ConstLabels: labels,
+			},
+		),
+	}
+}
+
+// RepositoryHealthMetrics defines Prometheus metrics for the health probe of a Repository
+type RepositoryHealthMetrics struct {
+	// RepositoryReady indicates whether the repository has passed the latest health probe or not
+	RepositoryReady prometheus.Gauge
+}
+
+func newRepositoryHealthMetrics(labels prometheus.Labels) *RepositoryHealthMetrics {
+	return &RepositoryHealthMetrics{
+		RepositoryReady: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "repository",
+				Name:        "ready",
+				Help:        "Indicates whether the repository has passed the latest health probe or not",
+				ConstLabels: labels,
+			},
+		),
+	}
+}
+
+func newRestoreVerificationMetrics(labels prometheus.Labels) *RestoreVerificationMetrics {
+	return &RestoreVerificationMetrics{
+		VerificationSuccess: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "restore_verification",
+				Name:        "success",
+				Help:        "Indicates whether the restore verification succeeded or not",
+				ConstLabels: labels,
+			},
+		),
+		VerificationDuration: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "restore_verification",
+				Name:        "duration_seconds",
+				Help:        "Indicates the time taken to complete the restore verification",
+				ConstLabels: labels,
+			},
+		),
+		VerificationTime: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "restore_verification",
+				Name:        "last_time_seconds",
+				Help:        "Indicates the time when the restore verification has completed in unix timestamp",
+				ConstLabels: labels,
+			},
+		),
+		CheckSuccess: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace:   "stash",
+				Subsystem:   "restore_verification",
+				Name:        "check_success",
+				Help:        "Indicates whether the individual checks of the restore verification succeeded or not",
+				ConstLabels: labels,
+			},
+			[]string{"check"},
+		),
+	}
+}
+
 func newRestoreHostMetrics(labels prometheus.Labels) *RestoreMetrics {
 	return &RestoreMetrics{
 		RestoreHostMetrics: &RestoreHostMetrics{
@@ -456,6 +587,19 @@ func (metricOpt *MetricsOptions) SendBackupHostMetrics(config *rest.Config, back
 			repoMetrics.SnapshotCount,
 			repoMetrics.SnapshotsRemovedOnLastCleanup,
 		)
+	} else if backupConfig != nil && backupConfig.Spec.Driver == api_v1beta1.VolumeSnapshotter {
+		// VolumeSnapshotter does not use any repository. So, we only report the number of
+		// VolumeSnapshots and the number of old VolumeSnapshots removed according to retention policy.
+		// BackupConfiguration name is used to identify the VolumeSnapshots of this backup.
+		repoMetrics := newRepositoryMetrics(upsertLabel(labels, map[string]string{MetricsLabelName: backupConfig.Name}))
+		repoMetrics.SnapshotCount.Set(float64(backupOutput.RepositoryStats.SnapshotCount))
+		repoMetrics.SnapshotsRemovedOnLastCleanup.Set(float64(backupOutput.RepositoryStats.SnapshotsRemovedOnLastCleanup))
+
+		// register repository metrics
+		registry.MustRegister(
+			repoMetrics.SnapshotCount,
+			repoMetrics.SnapshotsRemovedOnLastCleanup,
+		)
 	}
 
 	return metricOpt.sendMetrics(registry, metricOpt.JobName)
@@ -506,6 +650,116 @@ func (metricOpt *MetricsOptions) SendRestoreSessionMetrics(config *rest.Config,
 	return metricOpt.sendMetrics(registry, metricOpt.JobName)
 }
 
+// SendRestoreVerificationMetrics send the result of the restore verification to the Pushgateway
+func (metricOpt *MetricsOptions) SendRestoreVerificationMetrics(config *rest.Config, restoreSession *api_v1beta1.RestoreSession, verificationStatus *api_v1beta1.RestoreVerificationStatus) error {
+	if verificationStatus == nil {
+		return fmt.Errorf("invalid verification status. Verification status shouldn't be nil")
+	}
+
+	// create metric registry
+	registry := prometheus.NewRegistry()
+
+	labels, err := restoreMetricLabels(config, restoreSession, metricOpt.Labels)
+	if err != nil {
+		return err
+	}
+	if restoreSession.Spec.Verification != nil {
+		labels[MetricsLabelBackupConfiguration] = restoreSession.Spec.Verification.BackupConfiguration.Name
+	}
+	metrics := newRestoreVerificationMetrics(labels)
+
+	if verificationStatus.Phase == api_v1beta1.VerificationSucceeded {
+		metrics.VerificationSuccess.Set(1)
+	} else {
+		metrics.VerificationSuccess.Set(0)
+	}
+	if verificationStatus.Duration != "" {
+		duration, err := time.ParseDuration(verificationStatus.Duration)
+		if err != nil {
+			return err
+		}
+		metrics.VerificationDuration.Set(duration.Seconds())
+	}
+	if verificationStatus.LastVerificationTime != nil {
+		metrics.VerificationTime.Set(float64(verificationStatus.LastVerificationTime.Unix()))
+	}
+	for _, check := range verificationStatus.Checks {
+		if check.Phase == api_v1beta1.VerificationSucceeded {
+			metrics.CheckSuccess.WithLabelValues(check.Name).Set(1)
+		} else {
+			metrics.CheckSuccess.WithLabelValues(check.Name).Set(0)
+		}
+	}
+
+	registry.MustRegister(
+		metrics.VerificationSuccess,
+		metrics.VerificationDuration,
+		metrics.VerificationTime,
+		metrics.CheckSuccess,
+	)
+	// send metrics to the pushgateway
+	return metricOpt.sendMetrics(registry, metricOpt.JobName)
+}
+
+// SendReplicationMetrics sends the metrics of the replication of a Repository to the Pushgateway
+func (metricOpt *MetricsOptions) SendReplicationMetrics(repository *v1alpha1.Repository, replicaStatus v1alpha1.ReplicaStatus) error {
+	// create metric registry
+	registry := prometheus.NewRegistry()
+
+	labels := parseUserProvidedLabels(metricOpt.Labels)
+	labels[MetricsLabelName] = repository.Name
+	labels[MetricsLabelNamespace] = repository.Namespace
+	labels[MetricsLabelReplica] = replicaStatus.Name
+	metrics := newReplicationMetrics(labels)
+
+	if replicaStatus.Phase == v1alpha1.ReplicationSucceeded {
+		metrics.ReplicationSuccess.Set(1)
+	} else {
+		metrics.ReplicationSuccess.Set(0)
+	}
+	if replicaStatus.Lag != "" {
+		lag, err := time.ParseDuration(replicaStatus.Lag)
+		if err != nil {
+			return err
+		}
+		metrics.ReplicationLag.Set(lag.Seconds())
+	}
+	if replicaStatus.LastSuccessfulCopyTime != nil {
+		metrics.LastSuccessTime.Set(float64(replicaStatus.LastSuccessfulCopyTime.Unix()))
+	}
+	metrics.SnapshotsCopied.Set(float64(replicaStatus.SnapshotsCopied))
+
+	registry.MustRegister(
+		metrics.ReplicationSuccess,
+		metrics.ReplicationLag,
+		metrics.LastSuccessTime,
+		metrics.SnapshotsCopied,
+	)
+	// send metrics to the pushgateway
+	return metricOpt.sendMetrics(registry, metricOpt.JobName)
+}
+
+// SendRepositoryHealthMetrics sends the result of the health probe of a Repository to the Pushgateway
+func (metricOpt *MetricsOptions) SendRepositoryHealthMetrics(repository *v1alpha1.Repository, ready bool) error {
+	// create metric registry
+	registry := prometheus.NewRegistry()
+
+	labels := parseUserProvidedLabels(metricOpt.Labels)
+	labels[MetricsLabelName] = repository.Name
+	labels[MetricsLabelNamespace] = repository.Namespace
+	metrics := newRepositoryHealthMetrics(labels)
+
+	if ready {
+		metrics.RepositoryReady.Set(1)
+	} else {
+		metrics.RepositoryReady.Set(0)
+	}
+
+	registry.MustRegister(metrics.RepositoryReady)
+	// send metrics to the pushgateway
+	return metricOpt.sendMetrics(registry, metricOpt.JobName)
+}
+
 // SendRestoreHostMetrics send restore metrics for individual hosts to the Pushgateway
 func (metricOpt *MetricsOptions) SendRestoreHostMetrics(config *rest.Config, restoreSession *api_v1beta1.RestoreSession, restoreOutput *RestoreOutput) error {
 	if restoreOutput == nil {
@@ -0,0 +1,21 @@
+package restic
+
+import (
+	"strings"
+)
+
+// VerifyRepository checks whether the repository can be opened with the password. It does not lock the repository.
+func (w *ResticWrapper) VerifyRepository() error {
+	_, err := w.catConfig()
+	return err
+}
+
+// IsRepositoryNotInitializedMessage returns true if the message reports that no repository exists at the location
+func IsRepositoryNotInitializedMessage(msg string) bool {
+	return strings.Contains(msg, "Is there a repository at the following location?")
+}
+
+// IsWrongPasswordMessage returns true if the message reports that the repository can't be opened with the password
+func IsWrongPasswordMessage(msg string) bool {
+	return strings.Contains(msg, "wrong password or no key found")
+}
@@ -3,9 +3,12 @@ package restic
 import (
 	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/appscode/go/types"
 	"github.com/stretchr/testify/assert"
@@ -126,6 +129,498 @@ func TestBackupRestoreDirs(t *testing.T) {
 	assert.Equal(t, fileContent, string(fileContentByte))
 }
 
+func TestBackupRestoreWithTags(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "stash-unit-test-")
+	if err != nil {
+		t.Error(err)
+	}
+
+	w, err := setupTest(tempDir)
+	if err != nil {
+		t.Error(err)
+	}
+	defer cleanup(tempDir)
+
+	backupOpt := BackupOptions{
+		BackupPaths: []string{targetPath},
+		Tags:        []string{"backup-session=sample-1", "env=test"},
+	}
+	backupOut, err := w.RunBackup(backupOpt)
+	if err != nil {
+		t.Error(err)
+	}
+	fmt.Println(backupOut)
+
+	// delete target then restore the snapshot having the tags
+	if err = os.RemoveAll(targetPath); err != nil {
+		t.Error(err)
+	}
+	restoreOpt := RestoreOptions{
+		RestorePaths: []string{targetPath},
+		Tags:         []string{"env=test"},
+	}
+	restoreOut, err := w.RunRestore(restoreOpt)
+	if err != nil {
+		t.Error(err)
+	}
+	fmt.Println(restoreOut)
+
+	// check file
+	fileContentByte, err := ioutil.ReadFile(filepath.Join(targetPath, fileName))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, fileContent, string(fileContentByte))
+}
+
+func TestRestoreWithPathMapping(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "stash-unit-test-")
+	if err != nil {
+		t.Error(err)
+	}
+
+	w, err := setupTest(tempDir)
+	if err != nil {
+		t.Error(err)
+	}
+	defer cleanup(tempDir)
+
+	backupOpt := BackupOptions{
+		BackupPaths: []string{targetPath},
+	}
+	backupOut, err := w.RunBackup(backupOpt)
+	if err != nil {
+		t.Error(err)
+	}
+	fmt.Println(backupOut)
+
+	// restore the target into a different directory
+	mappedPath := filepath.Join(tempDir, "mapped")
+	restoreOpt := RestoreOptions{
+		RestorePaths: []string{targetPath},
+		PathMapping: map[string]string{
+			targetPath: mappedPath,
+		},
+	}
+	restoreOut, err := w.RunRestore(restoreOpt)
+	if err != nil {
+		t.Error(err)
+	}
+	fmt.Println(restoreOut)
+
+	// check file
+	fileContentByte, err := ioutil.ReadFile(filepath.Join(mappedPath, fileName))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, fileContent, string(fileContentByte))
+
+	// staging directory should be removed
+	files, err := ioutil.ReadDir(mappedPath)
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, 1, len(files))
+}
+
+func TestSelectSnapshot(t *testing.T) {
+	now := time.Now()
+	snapshots := []Snapshot{
+		{ID: "1", Time: now.Add(-3 * time.Hour), Paths: []string{"/data"}, Hostname: "host-0", Tags: []string{"daily"}},
+		{ID: "2", Time: now.Add(-2 * time.Hour), Paths: []string{"/data"}, Hostname: "host-0"},
+		{ID: "3", Time: now.Add(-1 * time.Hour), Paths: []string{"/data"}, Hostname: "host-0"},
+		{ID: "4", Time: now, Paths: []string{"/data"}, Hostname: "host-1"},
+		{ID: "5", Time: now, Paths: []string{"/logs"}, Hostname: "host-0"},
+	}
+	before := now.Add(-90 * time.Minute)
+	after := now.Add(-150 * time.Minute)
+
+	testCases := []struct {
+		name       string
+		opt        RestoreOptions
+		expectedID string
+	}{
+		{"latest", RestoreOptions{SourceHost: "host-0"}, "3"},
+		{"before", RestoreOptions{SourceHost: "host-0", Before: &before}, "2"},
+		{"after", RestoreOptions{SourceHost: "host-0", After: &after}, "3"},
+		{"tags", RestoreOptions{SourceHost: "host-0", Tags: []string{"daily"}}, "1"},
+		{"no match", RestoreOptions{SourceHost: "host-0", Before: &before, Tags: []string{"weekly"}}, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			snapshot, err := selectSnapshot(snapshots, "/data", tc.opt)
+			if tc.expectedID == "" {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedID, snapshot.ID)
+			}
+		})
+	}
+}
+
+func TestAppendBandwidthLimitFlags(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   SetupOptions
+		expected []interface{}
+	}{
+		{"no limit", SetupOptions{}, []interface{}{"backup"}},
+		{"upload", SetupOptions{LimitUpload: 1024}, []interface{}{"backup", "--limit-upload", "1024"}},
+		{"both", SetupOptions{LimitUpload: 1024, LimitDownload: 2048}, []interface{}{"backup", "--limit-upload", "1024", "--limit-download", "2048"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := &ResticWrapper{config: tc.config}
+			assert.Equal(t, tc.expected, w.appendBandwidthLimitFlags([]interface{}{"backup"}))
+		})
+	}
+}
+
+func TestParseLock(t *testing.T) {
+	data := []byte(`{"time":"2019-10-10T10:10:10.000000001Z","exclusive":true,"hostname":"stash-backup-sample-0","username":"root","pid":12,"uid":0,"gid":0}`)
+	lock, err := parseLock("a1b2c3", data)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "a1b2c3", lock.ID)
+		assert.Equal(t, "stash-backup-sample-0", lock.Hostname)
+		assert.Equal(t, 12, lock.PID)
+		assert.True(t, lock.Exclusive)
+	}
+
+	_, err = parseLock("a1b2c3", []byte("invalid"))
+	assert.Error(t, err)
+}
+
+func TestIsLockError(t *testing.T) {
+	assert.True(t, IsLockError(fmt.Errorf("exit status 1, reason: Fatal: unable to create lock in backend: repository is already locked by PID 12 on stash-backup-sample-0 by root (UID 0, GID 0)")))
+	assert.True(t, IsLockError(fmt.Errorf("exit status 1, reason: Fatal: unable to create lock in backend: repository is already locked exclusively by PID 12 on stash-backup-sample-0 by root (UID 0, GID 0)")))
+	assert.False(t, IsLockError(fmt.Errorf("exit status 1, reason: Fatal: wrong password or no key found")))
+	assert.False(t, IsLockError(nil))
+}
+
+func TestIsIntegrityError(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "check has found errors",
+			err:  fmt.Errorf("exit status 1, reason: error for tree 4bba301c:\n  tree 4bba301c: file \"some-file\" blob 0 size could not be found\nFatal: repository contains errors"),
+			want: true,
+		},
+		{
+			name: "repository is locked",
+			err:  fmt.Errorf("exit status 1, reason: Fatal: unable to create lock in backend: repository is already locked exclusively by PID 12 on stash-backup-sample-0 by root (UID 0, GID 0)"),
+		},
+		{
+			name: "backend is unreachable",
+			err:  fmt.Errorf("exit status 1, reason: Fatal: unable to open config file: Stat: Get https://minio.storage.svc:9000/backup/?location=: dial tcp: lookup minio.storage.svc: no such host"),
+		},
+		{
+			name: "wrong password",
+			err:  fmt.Errorf("exit status 1, reason: Fatal: wrong password or no key found"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, isIntegrityError(tc.err))
+		})
+	}
+}
+
+func TestRunMaintenance(t *testing.T) {
+	tempDir, err := ioutil.TempDir("", "stash-unit-test-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := setupTest(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup(tempDir)
+
+	_, err = w.RunBackup(BackupOptions{BackupPaths: []string{targetPath}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name          string
+		opt           MaintenanceOptions
+		wantIntegrity *bool
+		wantErr       bool
+	}{
+		{
+			name:          "check reports the integrity",
+			opt:           MaintenanceOptions{Operation: MaintenanceCheck},
+			wantIntegrity: types.TrueP(),
+		},
+		{
+			name:          "check reads a subset of the data",
+			opt:           MaintenanceOptions{Operation: MaintenanceCheck, ReadDataSubset: "1/2"},
+			wantIntegrity: types.TrueP(),
+		},
+		{
+			name: "prune doesn't report the integrity",
+			opt:  MaintenanceOptions{Operation: MaintenancePrune},
+		},
+		{
+			name:    "rotate-key is not run by RunMaintenance",
+			opt:     MaintenanceOptions{Operation: MaintenanceRotateKey},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stats, err := w.RunMaintenance(tc.opt)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.wantIntegrity, stats.Integrity)
+				assert.Equal(t, 1, stats.SnapshotCount)
+			}
+		})
+	}
+}
+
+func TestIsBackendErrorMessage(t *testing.T) {
+	assert.True(t, IsBackendErrorMessage("exit status 1, reason: Fatal: unable to open config file: Stat: Get https://minio.storage.svc:9000/backup/?location=: dial tcp: lookup minio.storage.svc: no such host"))
+	assert.True(t, IsBackendErrorMessage("exit status 1, reason: Fatal: unable to open config file: Stat: The AWS Access Key Id you provided does not exist in our records. (InvalidAccessKeyId)"))
+	assert.False(t, IsBackendErrorMessage("exit status 1, reason: Fatal: wrong password or no key found"))
+	assert.False(t, IsBackendErrorMessage("exit status 3, reason: Warning: at least one source file could not be read"))
+}
+
+func TestValidateSecretKeys(t *testing.T) {
+	secret := func(keys ...string) map[string][]byte {
+		data := map[string][]byte{}
+		for _, key := range keys {
+			data[key] = []byte("value")
+		}
+		return data
+	}
+
+	assert.Error(t, ValidateSecretKeys(storage.ProviderLocal, secret()))
+	assert.NoError(t, ValidateSecretKeys(storage.ProviderLocal, secret(RESTIC_PASSWORD)))
+	// IAM role of the pod
+	assert.NoError(t, ValidateSecretKeys(storage.ProviderS3, secret(RESTIC_PASSWORD)))
+	assert.Error(t, ValidateSecretKeys(storage.ProviderS3, secret(RESTIC_PASSWORD, AWS_ACCESS_KEY_ID)))
+	assert.NoError(t, ValidateSecretKeys(storage.ProviderS3, secret(RESTIC_PASSWORD, AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY)))
+	assert.Error(t, ValidateSecretKeys(storage.ProviderAzure, secret(RESTIC_PASSWORD, AZURE_ACCOUNT_NAME)))
+	assert.NoError(t, ValidateSecretKeys(storage.ProviderAzure, secret(RESTIC_PASSWORD, AZURE_ACCOUNT_NAME, AZURE_ACCOUNT_SAS)))
+	assert.Error(t, ValidateSecretKeys(storage.ProviderB2, secret(RESTIC_PASSWORD, B2_ACCOUNT_ID)))
+	assert.Error(t, ValidateSecretKeys(storage.ProviderSwift, secret(RESTIC_PASSWORD, OS_AUTH_URL, OS_USERNAME)))
+	assert.NoError(t, ValidateSecretKeys(storage.ProviderSwift, secret(RESTIC_PASSWORD, OS_STORAGE_URL, OS_AUTH_TOKEN)))
+	// the credentials are fetched by the credential provider hook
+	assert.NoError(t, ValidateSecretKeys(storage.ProviderB2, secret(RESTIC_PASSWORD, CREDENTIAL_PROVIDER_COMMAND)))
+}
+
+func TestProbeErrorMessages(t *testing.T) {
+	notInitialized := "exit status 1, reason: Fatal: unable to open config file: Stat: The specified key does not exist.\nIs there a repository at the following location?\ns3:http://minio.storage.svc:9000/backup/demo"
+	assert.True(t, IsRepositoryNotInitializedMessage(notInitialized))
+	assert.False(t, IsWrongPasswordMessage(notInitialized))
+	assert.True(t, IsWrongPasswordMessage("exit status 1, reason: Fatal: wrong password or no key found"))
+}
+
+func TestRemovedLocks(t *testing.T) {
+	locks := []Lock{{ID: "1"}, {ID: "2"}, {ID: "3"}}
+	remaining := []Lock{{ID: "2"}, {ID: "4"}}
+	assert.Equal(t, []Lock{{ID: "1"}, {ID: "3"}}, removedLocks(locks, remaining))
+}
+
+func TestParseKeys(t *testing.T) {
+	out := []byte(` ID        User  Host    Created
+----------------------------------------------
+*4e5b1a62  root  host-0  2019-10-10 10:10:10
+ 9f8e7d6c  root  host-1  2019-10-11 10:10:10
+----------------------------------------------
+`)
+	keys := parseKeys(out)
+	assert.Equal(t, []Key{
+		{ID: "4e5b1a62", Current: true, Username: "root", Hostname: "host-0", Created: "2019-10-10 10:10:10"},
+		{ID: "9f8e7d6c", Current: false, Username: "root", Hostname: "host-1", Created: "2019-10-11 10:10:10"},
+	}, keys)
+
+	added := addedKeys(keys[:1], keys)
+	assert.Equal(t, []Key{keys[1]}, added)
+}
+
+func TestCopyEnv(t *testing.T) {
+	srcEnv := map[string]string{
+		RESTIC_REPOSITORY:     "s3:http://minio.storage.svc:9000/backup/demo",
+		RESTIC_PASSWORD:       "src-password",
+		TMPDIR:                "/tmp/src",
+		AWS_ACCESS_KEY_ID:     "minio",
+		AWS_SECRET_ACCESS_KEY: "minio-secret",
+	}
+	gcsEnv := map[string]string{
+		RESTIC_REPOSITORY:              "gs:offsite:/demo",
+		RESTIC_PASSWORD:                "dst-password",
+		TMPDIR:                         "/tmp/dst",
+		GOOGLE_PROJECT_ID:              "project",
+		GOOGLE_APPLICATION_CREDENTIALS: "/tmp/dst/gcs.json",
+	}
+	env, err := copyEnv(srcEnv, gcsEnv)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{
+			RESTIC_REPOSITORY2:             "gs:offsite:/demo",
+			RESTIC_PASSWORD2:               "dst-password",
+			GOOGLE_PROJECT_ID:              "project",
+			GOOGLE_APPLICATION_CREDENTIALS: "/tmp/dst/gcs.json",
+		}, env)
+	}
+
+	s3Env := map[string]string{
+		RESTIC_REPOSITORY:     "s3:s3.amazonaws.com/offsite/demo",
+		RESTIC_PASSWORD:       "dst-password",
+		AWS_ACCESS_KEY_ID:     "aws",
+		AWS_SECRET_ACCESS_KEY: "aws-secret",
+	}
+	_, err = copyEnv(srcEnv, s3Env)
+	assert.Error(t, err)
+}
+
+func TestParseCredentials(t *testing.T) {
+	env, err := parseCredentials([]byte(`{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"}`))
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{AWS_ACCESS_KEY_ID: "id", AWS_SECRET_ACCESS_KEY: "secret"}, env)
+	}
+
+	env, err = parseCredentials([]byte("# vault agent\nexport AWS_ACCESS_KEY_ID=id\nAWS_SESSION_TOKEN=\"token\"\n"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{AWS_ACCESS_KEY_ID: "id", AWS_SESSION_TOKEN: "token"}, env)
+	}
+
+	// the content of the file must not leak through the error
+	_, err = parseCredentials([]byte("AWS_ACCESS_KEY_ID=id\nsecret-token"))
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "secret-token")
+	}
+	_, err = parseCredentials([]byte(`{"token": secret-token}`))
+	if assert.Error(t, err) {
+		assert.NotContains(t, err.Error(), "secret-token")
+	}
+}
+
+func TestDisableCredentialProviders(t *testing.T) {
+	secretDir, err := ioutil.TempDir("", "stash-credentials")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(secretDir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, CREDENTIAL_PROVIDER_COMMAND), []byte("/bin/credentials"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, CREDENTIAL_PROVIDER_FILE), []byte("/var/run/secrets/credentials"), 0644))
+
+	w := &ResticWrapper{config: SetupOptions{SecretDir: secretDir}}
+	w.setupCredentialProviders()
+	assert.Len(t, w.credentialProviders, 2)
+
+	w = &ResticWrapper{config: SetupOptions{SecretDir: secretDir, DisableCredentialProviders: true}}
+	w.setupCredentialProviders()
+	assert.Empty(t, w.credentialProviders)
+}
+
+func TestWebIdentityCredentials(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "AssumeRoleWithWebIdentity", r.Form.Get("Action"))
+		assert.Equal(t, "arn:aws:iam::123456789012:role/stash", r.Form.Get("RoleArn"))
+		assert.Equal(t, "web-identity-token", r.Form.Get("WebIdentityToken"))
+		fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+  <AssumeRoleWithWebIdentityResult>
+    <Credentials>
+      <AccessKeyId>id</AccessKeyId>
+      <SecretAccessKey>secret</SecretAccessKey>
+      <SessionToken>token</SessionToken>
+      <Expiration>%s</Expiration>
+    </Credentials>
+  </AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`, expiration.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	secretDir, err := ioutil.TempDir("", "stash-credentials")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(secretDir)
+	tokenFile := filepath.Join(secretDir, "token")
+	files := map[string]string{
+		AWS_ROLE_ARN:                "arn:aws:iam::123456789012:role/stash",
+		AWS_WEB_IDENTITY_TOKEN_FILE: tokenFile,
+		AWS_STS_ENDPOINT:            server.URL,
+		"token":                     "web-identity-token\n",
+	}
+	for k, v := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(secretDir, k), []byte(v), 0600))
+	}
+
+	provider := newWebIdentityCredentialProvider(secretDir)
+	if !assert.NotNil(t, provider) {
+		return
+	}
+	for i := 0; i < 2; i++ {
+		env, err := provider.Credentials()
+		if assert.NoError(t, err) {
+			assert.Equal(t, map[string]string{
+				AWS_ACCESS_KEY_ID:     "id",
+				AWS_SECRET_ACCESS_KEY: "secret",
+				AWS_SESSION_TOKEN:     "token",
+			}, env)
+		}
+	}
+	// the credentials are cached until they are about to expire
+	assert.Equal(t, 1, requests)
+}
+
+func TestRemoveDeadLocks(t *testing.T) {
+	w := &ResticWrapper{}
+	locks := []Lock{{ID: "1", PID: 1}, {ID: "2", PID: 2}, {ID: "3", PID: 3}}
+	var deleted []string
+	removed, err := w.RemoveDeadLocks(locks, func(lock Lock) (bool, error) {
+		return lock.PID != 2, nil
+	}, func(lock Lock) error {
+		deleted = append(deleted, lock.ID)
+		return nil
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Lock{{ID: "1", PID: 1}, {ID: "3", PID: 3}}, removed)
+		assert.Equal(t, []string{"1", "3"}, deleted)
+	}
+
+	// nothing is removed if no holder is dead
+	removed, err = w.RemoveDeadLocks(locks, func(lock Lock) (bool, error) {
+		return false, nil
+	}, nil)
+	if assert.NoError(t, err) {
+		assert.Empty(t, removed)
+	}
+}
+
+func TestMissingSnapshotIDs(t *testing.T) {
+	now := time.Date(2019, 10, 15, 12, 0, 0, 0, time.UTC)
+	snapshots := []Snapshot{
+		{ID: "s1", Time: now, Hostname: "host-0", Tree: "t1"},
+		{ID: "s2", Time: now.Add(time.Hour), Hostname: "host-0", Tree: "t2"},
+		{ID: "s3", Time: now.Add(time.Hour), Hostname: "host-1", Tree: "t3"},
+	}
+	// copied snapshots get new IDs and keep the time, the host and the tree
+	existing := []Snapshot{
+		{ID: "c1", Time: now.In(time.FixedZone("UTC+6", 6*60*60)), Hostname: "host-0", Tree: "t1"},
+		{ID: "c2", Time: now.Add(time.Hour), Hostname: "host-1", Tree: "t2"},
+	}
+	assert.Equal(t, []string{"s2", "s3"}, missingSnapshotIDs(snapshots, existing))
+	assert.Equal(t, []string{}, missingSnapshotIDs(nil, existing))
+}
+
 func TestBackupRestoreStdin(t *testing.T) {
 	tempDir, err := ioutil.TempDir("", "stash-unit-test-")
 	if err != nil {
@@ -1,9 +1,15 @@
 package restic
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/appscode/go/log"
 	"k8s.io/apimachinery/pkg/util/errors"
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 )
@@ -17,12 +23,13 @@ func (w *ResticWrapper) RunRestore(restoreOptions RestoreOptions) (*RestoreOutpu
 		Hostname: restoreOptions.Host,
 	}
 
-	err := w.runRestore(restoreOptions)
+	snapshots, err := w.runRestore(restoreOptions)
 	if err != nil {
 		return nil, err
 	}
 
 	// Restore successful. Now, calculate total session duration.
+	restoreStats.Snapshots = snapshots
 	restoreStats.Duration = time.Since(startTime).String()
 	restoreStats.Phase = api_v1beta1.HostRestoreSucceeded
 
@@ -70,7 +77,7 @@ func (w *ResticWrapper) RunParallelRestore(restoreOptions []RestoreOptions, maxC
 			nw := w.Copy()
 
 			// run restore
-			err := nw.runRestore(opt)
+			snapshots, err := nw.runRestore(opt)
 			if err != nil {
 				mu.Lock()
 				restoreErrs = append(restoreErrs, err)
@@ -78,7 +85,8 @@ func (w *ResticWrapper) RunParallelRestore(restoreOptions []RestoreOptions, maxC
 				return
 			}
 			hostStats := api_v1beta1.HostRestoreStats{
-				Hostname: opt.Host,
+				Hostname:  opt.Host,
+				Snapshots: snapshots,
 			}
 			hostStats.Duration = time.Since(startTime).String()
 			hostStats.Phase = api_v1beta1.HostRestoreSucceeded
@@ -193,24 +201,164 @@ func (w *ResticWrapper) ParallelDump(dumpOptions []DumpOptions, maxConcurrency i
 	return restoreOutput, errors.NewAggregate(restoreErrs)
 }
 
-func (w *ResticWrapper) runRestore(restoreOptions RestoreOptions) error {
+// runRestore restores the data of a host and returns the ids of the restored snapshots.
+func (w *ResticWrapper) runRestore(restoreOptions RestoreOptions) ([]string, error) {
+	var restoredSnapshots []string
 	if len(restoreOptions.Snapshots) != 0 {
 		for _, snapshot := range restoreOptions.Snapshots {
 			// if snapshot is specified then host and path does not matter.
-			if _, err := w.restore("", "", snapshot, restoreOptions.Destination); err != nil {
-				return err
+			if len(restoreOptions.PathMapping) == 0 {
+				if _, err := w.restore("", "", snapshot, restoreOptions.Destination, restoreOptions.Includes, restoreOptions.Excludes); err != nil {
+					return nil, err
+				}
+				restoredSnapshots = append(restoredSnapshots, snapshot)
+				continue
+			}
+			// only the mapped paths of the snapshot are restored
+			for _, src := range sortedKeys(restoreOptions.PathMapping) {
+				includes := restoreOptions.Includes
+				if len(includes) == 0 && len(restoreOptions.Excludes) == 0 {
+					includes = []string{src}
+				}
+				if err := w.restoreToMappedPath("", "", snapshot, src, restoreOptions.PathMapping[src], includes, restoreOptions.Excludes); err != nil {
+					return nil, err
+				}
 			}
+			restoredSnapshots = append(restoredSnapshots, snapshot)
 		}
 	} else if len(restoreOptions.RestorePaths) != 0 {
+		// resolve the snapshot of each path beforehand so that the restored snapshots can be recorded
+		snapshots, err := w.listSnapshots(nil)
+		if err != nil {
+			return nil, err
+		}
 		for _, path := range restoreOptions.RestorePaths {
-			if _, err := w.restore(path, restoreOptions.SourceHost, "", restoreOptions.Destination); err != nil {
+			snapshot, err := selectSnapshot(snapshots, path, restoreOptions)
+			if err != nil {
+				return nil, err
+			}
+			log.Infof("Restoring path %s from snapshot %s taken at %s", path, snapshot.ID, snapshot.Time.UTC().Format(time.RFC3339))
+			if dst, ok := restoreOptions.PathMapping[path]; ok {
+				err = w.restoreToMappedPath(path, "", snapshot.ID, path, dst, restoreOptions.Includes, restoreOptions.Excludes)
+			} else {
+				_, err = w.restore(path, "", snapshot.ID, restoreOptions.Destination, restoreOptions.Includes, restoreOptions.Excludes)
+			}
+			if err != nil {
+				return nil, err
+			}
+			restoredSnapshots = append(restoredSnapshots, snapshot.ID)
+		}
+	}
+	return restoredSnapshots, nil
+}
+
+// selectSnapshot returns the latest snapshot of the path taken from the source host that matches
+// the "before", "after" and "tags" selectors of the restore options.
+func selectSnapshot(snapshots []Snapshot, path string, restoreOptions RestoreOptions) (*Snapshot, error) {
+	var selected *Snapshot
+	for i := range snapshots {
+		snapshot := &snapshots[i]
+		if restoreOptions.SourceHost != "" && snapshot.Hostname != restoreOptions.SourceHost {
+			continue
+		}
+		if !containsAll(snapshot.Paths, []string{path}) || !containsAll(snapshot.Tags, restoreOptions.Tags) {
+			continue
+		}
+		if restoreOptions.Before != nil && !snapshot.Time.Before(*restoreOptions.Before) {
+			continue
+		}
+		if restoreOptions.After != nil && !snapshot.Time.After(*restoreOptions.After) {
+			continue
+		}
+		if selected == nil || snapshot.Time.After(selected.Time) {
+			selected = snapshot
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("no snapshot found for path %s of host %s matching the selectors", path, restoreOptions.SourceHost)
+	}
+	return selected, nil
+}
+
+func containsAll(list, items []string) bool {
+	for _, item := range items {
+		found := false
+		for _, v := range list {
+			if v == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// restoreToMappedPath restores the content of the backed up path src into the directory dst.
+// Restic always restores a file at "<target>/<original path>". So, the data is restored in a staging directory first
+// and then the content of src is moved into dst. The staging directory is created inside dst so that the files
+// are moved within the same file system.
+func (w *ResticWrapper) restoreToMappedPath(path, host, snapshotID, src, dst string, includes, excludes []string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	stagingDir, err := ioutil.TempDir(dst, ".stash-restore-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingDir)
+
+	if _, err = w.restore(path, host, snapshotID, stagingDir, includes, excludes); err != nil {
+		return err
+	}
+	restoredDir := filepath.Join(stagingDir, src)
+	if _, err = os.Stat(restoredDir); os.IsNotExist(err) {
+		log.Warningf("Nothing has been restored for path %s", src)
+		return nil
+	}
+	log.Infof("Moving restored data of path %s into %s", src, dst)
+	return moveInto(restoredDir, dst)
+}
+
+// moveInto moves the content of the directory src into the directory dst.
+// The existing files of dst are overwritten and the existing directories are merged.
+func moveInto(src, dst string) error {
+	entries, err := ioutil.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		from := filepath.Join(src, entry.Name())
+		to := filepath.Join(dst, entry.Name())
+		if existing, err := os.Lstat(to); err == nil {
+			if entry.IsDir() && existing.IsDir() {
+				if err = moveInto(from, to); err != nil {
+					return err
+				}
+				continue
+			}
+			if err = os.RemoveAll(to); err != nil {
 				return err
 			}
 		}
+		if err = os.Rename(from, to); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func (restoreOutput *RestoreOutput) upsertHostRestoreStats(hostStats api_v1beta1.HostRestoreStats) {
 
 	// check if a entry already exist for this host in restoreOutput. If exist then update it.
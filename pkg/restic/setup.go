@@ -6,14 +6,19 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/appscode/go/log"
 	storage "kmodules.xyz/objectstore-api/api/v1"
 )
 
 const (
 	RESTIC_REPOSITORY = "RESTIC_REPOSITORY"
 	RESTIC_PASSWORD   = "RESTIC_PASSWORD"
-	TMPDIR            = "TMPDIR"
+	// destination repository of "copy" command
+	RESTIC_REPOSITORY2 = "RESTIC_REPOSITORY2"
+	RESTIC_PASSWORD2   = "RESTIC_PASSWORD2"
+	TMPDIR             = "TMPDIR"
 
 	AWS_ACCESS_KEY_ID     = "AWS_ACCESS_KEY_ID"
 	AWS_SECRET_ACCESS_KEY = "AWS_SECRET_ACCESS_KEY"
@@ -61,6 +66,8 @@ const (
 	// ref: https://github.com/restic/restic/blob/master/doc/manual_rest.rst#temporary-files
 	resticTempDir  = "restic-tmp"
 	resticCacheDir = "restic-cache"
+	// local repository used to copy snapshots between backends that can't be accessed by the same process
+	resticCopyDir = "restic-copy"
 )
 
 func (w *ResticWrapper) setupEnv() error {
@@ -89,6 +96,9 @@ func (w *ResticWrapper) setupEnv() error {
 		}
 	}
 
+	// credential provider hook can provide the credentials of any backend
+	w.setupCredentialProviders()
+
 	//path = strings.TrimPrefix(path, "/")
 
 	switch w.config.Provider {
@@ -110,6 +120,12 @@ func (w *ResticWrapper) setupEnv() error {
 		if v, err := ioutil.ReadFile(filepath.Join(w.config.SecretDir, AWS_SECRET_ACCESS_KEY)); err == nil {
 			w.sh.SetEnv(AWS_SECRET_ACCESS_KEY, string(v))
 		}
+		// IAM role for the ServiceAccount of the pod. it is used only when no static key has been given.
+		if _, found := w.sh.Env[AWS_ACCESS_KEY_ID]; !found && !w.config.DisableCredentialProviders {
+			if provider := newWebIdentityCredentialProvider(w.config.SecretDir); provider != nil {
+				w.credentialProviders = append(w.credentialProviders, provider)
+			}
+		}
 
 	case storage.ProviderGCS:
 		r := fmt.Sprintf("gs:%s:/%s", w.config.Bucket, w.config.Path)
@@ -123,6 +139,8 @@ func (w *ResticWrapper) setupEnv() error {
 			// json key file exists
 			w.sh.SetEnv(GOOGLE_APPLICATION_CREDENTIALS, filepath.Join(w.config.SecretDir, GOOGLE_SERVICE_ACCOUNT_JSON_KEY))
 		}
+		// if no json key has been given, restic uses the default credentials of the pod
+		// (i.e. GKE workload identity through the metadata server)
 
 	case storage.ProviderAzure:
 		r := fmt.Sprintf("azure:%s:/%s", w.config.Bucket, w.config.Path)
@@ -134,6 +152,12 @@ func (w *ResticWrapper) setupEnv() error {
 		if v, err := ioutil.ReadFile(filepath.Join(w.config.SecretDir, AZURE_ACCOUNT_KEY)); err == nil {
 			w.sh.SetEnv(AZURE_ACCOUNT_KEY, string(v))
 		}
+		if v, err := ioutil.ReadFile(filepath.Join(w.config.SecretDir, AZURE_ACCOUNT_SAS)); err == nil {
+			w.sh.SetEnv(AZURE_ACCOUNT_SAS, string(v))
+			// SAS token is supported since restic 0.12.0
+			log.Infof("Using %s as the storage secret has %s", ResticNewerCMD, AZURE_ACCOUNT_SAS)
+			w.useNewerRestic = true
+		}
 
 	case storage.ProviderSwift:
 		r := fmt.Sprintf("swift:%s:/%s", w.config.Bucket, w.config.Path)
@@ -265,3 +289,61 @@ func (w *ResticWrapper) setupEnv() error {
 
 	return nil
 }
+
+// ValidateSecretKeys checks whether the storage secret has the keys that setupEnv needs to access the backend of
+// the provider. The credentials are not required if they are fetched by the credential provider hook, and the S3
+// keys are not required if the credentials of the pod (i.e. IAM role) are used.
+func ValidateSecretKeys(provider string, data map[string][]byte) error {
+	has := func(keys ...string) bool {
+		for _, key := range keys {
+			if _, ok := data[key]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+	var missing []string
+	require := func(keys ...string) {
+		for _, key := range keys {
+			if !has(key) {
+				missing = append(missing, key)
+			}
+		}
+	}
+
+	require(RESTIC_PASSWORD)
+	if !has(CREDENTIAL_PROVIDER_COMMAND) && !has(CREDENTIAL_PROVIDER_FILE) {
+		switch provider {
+		case storage.ProviderS3:
+			if has(AWS_ACCESS_KEY_ID) || has(AWS_SECRET_ACCESS_KEY) {
+				require(AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY)
+			}
+		case storage.ProviderGCS:
+			require(GOOGLE_PROJECT_ID)
+		case storage.ProviderAzure:
+			require(AZURE_ACCOUNT_NAME)
+			if !has(AZURE_ACCOUNT_KEY) && !has(AZURE_ACCOUNT_SAS) {
+				missing = append(missing, fmt.Sprintf("%s or %s", AZURE_ACCOUNT_KEY, AZURE_ACCOUNT_SAS))
+			}
+		case storage.ProviderSwift:
+			if !has(ST_AUTH, ST_USER, ST_KEY) &&
+				!has(OS_AUTH_URL, OS_USERNAME, OS_PASSWORD) &&
+				!has(OS_AUTH_URL, OS_APPLICATION_CREDENTIAL_ID, OS_APPLICATION_CREDENTIAL_SECRET) &&
+				!has(OS_AUTH_URL, OS_APPLICATION_CREDENTIAL_NAME, OS_APPLICATION_CREDENTIAL_SECRET) &&
+				!has(OS_STORAGE_URL, OS_AUTH_TOKEN) {
+				missing = append(missing, "the keys of any of the Swift authentication methods")
+			}
+		case storage.ProviderB2:
+			require(B2_ACCOUNT_ID, B2_ACCOUNT_KEY)
+		case storage.ProviderRest:
+			if has(REST_SERVER_PASSWORD) {
+				require(REST_SERVER_USERNAME)
+			}
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("storage secret is missing %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
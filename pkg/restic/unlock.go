@@ -1,6 +1,117 @@
 package restic
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lock holds the information of a lock of the repository
+type Lock struct {
+	ID        string    `json:"-"`
+	Time      time.Time `json:"time"`
+	Exclusive bool      `json:"exclusive"`
+	Hostname  string    `json:"hostname"`
+	Username  string    `json:"username"`
+	PID       int       `json:"pid"`
+}
+
 func (w *ResticWrapper) UnlockRepository() error {
 	_, err := w.unlock()
 	return err
 }
+
+// ListLocks returns the locks currently present in the repository. It does not lock the repository itself.
+func (w *ResticWrapper) ListLocks() ([]Lock, error) {
+	out, err := w.listLocks()
+	if err != nil {
+		return nil, err
+	}
+	locks := make([]Lock, 0)
+	for _, id := range strings.Fields(string(out)) {
+		data, err := w.catLock(id)
+		if err != nil {
+			return nil, err
+		}
+		lock, err := parseLock(id, data)
+		if err != nil {
+			return nil, err
+		}
+		locks = append(locks, lock)
+	}
+	return locks, nil
+}
+
+// RemoveDeadLocks removes the locks for which isDead returns true and returns the removed locks. The locks are
+// removed one by one with remove so that a lock created after the locks have been listed is never touched.
+// If remove is nil (i.e. the lock files of the backend can't be removed directly), "restic unlock" is used
+// which only removes the locks that restic itself considers stale (i.e. not refreshed for 30 minutes).
+func (w *ResticWrapper) RemoveDeadLocks(locks []Lock, isDead func(lock Lock) (bool, error), remove func(lock Lock) error) ([]Lock, error) {
+	var dead []Lock
+	for _, lock := range locks {
+		ok, err := isDead(lock)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			dead = append(dead, lock)
+		}
+	}
+	if len(dead) == 0 {
+		return nil, nil
+	}
+	if remove != nil {
+		var removed []Lock
+		for _, lock := range dead {
+			if err := remove(lock); err != nil {
+				return removed, err
+			}
+			removed = append(removed, lock)
+		}
+		return removed, nil
+	}
+	if _, err := w.unlockStale(); err != nil {
+		return nil, err
+	}
+	// find out which of the dead locks restic has removed
+	remaining, err := w.ListLocks()
+	if err != nil {
+		return nil, err
+	}
+	return removedLocks(dead, remaining), nil
+}
+
+// IsLockError returns true if the error has occurred because the repository has been locked by another process
+func IsLockError(err error) bool {
+	return err != nil && IsLockErrorMessage(err.Error())
+}
+
+// IsLockErrorMessage returns true if the message reports that the repository has been locked by another process
+func IsLockErrorMessage(msg string) bool {
+	return strings.Contains(msg, "repository is already locked") ||
+		strings.Contains(msg, "unable to create lock")
+}
+
+func parseLock(id string, data []byte) (Lock, error) {
+	lock := Lock{}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return lock, fmt.Errorf("failed to parse lock %s. Reason: %v", id, err)
+	}
+	lock.ID = id
+	return lock, nil
+}
+
+func removedLocks(locks, remaining []Lock) []Lock {
+	present := make(map[string]bool, len(remaining))
+	for _, lock := range remaining {
+		present[lock.ID] = true
+	}
+	var removed []Lock
+	for _, lock := range locks {
+		if !present[lock.ID] {
+			removed = append(removed, lock)
+		}
+	}
+	return removed
+}
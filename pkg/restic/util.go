@@ -94,3 +94,32 @@ func formatSeconds(sec uint64) string {
 
 	return fmt.Sprintf("%d:%02d", min, sec)
 }
+
+// backendErrors are the fragments of the errors reported by restic when it can not connect to the backend
+// or when the backend rejects the credentials
+var backendErrors = []string{
+	"unable to open config file",
+	"unable to open repository",
+	"dial tcp",
+	"no such host",
+	"connection refused",
+	"i/o timeout",
+	"TLS handshake timeout",
+	"x509:",
+	"AccessDenied",
+	"InvalidAccessKeyId",
+	"SignatureDoesNotMatch",
+	"NoSuchBucket",
+	"AuthenticationFailed",
+	"invalid_grant",
+}
+
+// IsBackendErrorMessage returns true if the message reports that the backend is unreachable or has rejected the credentials
+func IsBackendErrorMessage(msg string) bool {
+	for _, e := range backendErrors {
+		if strings.Contains(msg, e) {
+			return true
+		}
+	}
+	return false
+}
@@ -19,6 +19,7 @@ import (
 	cs "stash.appscode.dev/stash/client/clientset/versioned"
 	stash_scheme "stash.appscode.dev/stash/client/clientset/versioned/scheme"
 	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/hooks"
 	"stash.appscode.dev/stash/pkg/restic"
 	"stash.appscode.dev/stash/pkg/status"
 	"stash.appscode.dev/stash/pkg/util"
@@ -57,7 +58,7 @@ func Restore(opt *Options) (*restic.RestoreOutput, error) {
 		return nil, fmt.Errorf("invalid RestoreSession. Target is nil")
 	}
 
-	repository, err := opt.StashClient.StashV1alpha1().Repositories(opt.Namespace).Get(restoreSession.Spec.Repository.Name, metav1.GetOptions{})
+	repository, err := opt.StashClient.StashV1alpha1().Repositories(restoreSession.RepositoryNamespace()).Get(restoreSession.Spec.Repository.Name, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +68,8 @@ func Restore(opt *Options) (*restic.RestoreOutput, error) {
 		SecretDir:   opt.SetupOpt.SecretDir,
 		EnableCache: opt.SetupOpt.EnableCache,
 		ScratchDir:  opt.SetupOpt.ScratchDir,
+
+		BandwidthLimit: restoreSession.Spec.BandwidthLimit,
 	}
 	setupOptions, err := util.SetupOptionsForRepository(*repository, extraOptions)
 	if err != nil {
@@ -130,7 +133,7 @@ func (opt *Options) electRestoreLeader(restoreSession *api_v1beta1.RestoreSessio
 				// run restore process
 				restoreOutput, restoreErr := opt.runRestore(restoreSession)
 				if restoreErr != nil {
-					e2 := opt.HandleRestoreFailure(restoreErr)
+					e2 := opt.HandleRestoreFailure(restoreOutput, restoreErr)
 					if e2 != nil {
 						restoreErr = errors.NewAggregate([]error{restoreErr, e2})
 					}
@@ -138,8 +141,7 @@ func (opt *Options) electRestoreLeader(restoreSession *api_v1beta1.RestoreSessio
 					cancel()
 					// fail the container so that it restart and re-try to restore
 					log.Fatalf("failed to complete restore. Reason: %v", restoreErr)
-				}
-				if restoreOutput != nil {
+				} else if restoreOutput != nil {
 					err = opt.HandleRestoreSuccess(restoreOutput)
 					if err != nil {
 						cancel()
@@ -171,8 +173,70 @@ func (opt *Options) runRestore(restoreSession *api_v1beta1.RestoreSession) (*res
 		return nil, err
 	}
 
-	// run restore process
-	return w.RunRestore(util.RestoreOptionsForHost(opt.Host, restoreSession.Spec.Rules))
+	restoreOptions := util.RestoreOptionsForHost(opt.Host, restoreSession.Spec.Rules)
+	if restoreSession.Spec.Hooks == nil {
+		// run restore process
+		return w.RunRestore(restoreOptions)
+	}
+	// run restore process along with the hooks
+	return opt.restoreWithHooks(w, restoreOptions, restoreSession.Spec.Hooks)
+}
+
+// restoreWithHooks executes the preRestore hook, restores the data and then executes the postRestore hook.
+// If the preRestore hook fails and its failure policy is "Fail", the data is not restored. However, the postRestore hook
+// is always executed. In case of failure, the returned RestoreOutput only holds the hook statistics of this host.
+func (opt *Options) restoreWithHooks(w *restic.ResticWrapper, restoreOptions restic.RestoreOptions, restoreHooks *api_v1beta1.RestoreHooks) (*restic.RestoreOutput, error) {
+	executor := &hooks.HookExecutor{
+		Config:     opt.Config,
+		KubeClient: opt.KubeClient,
+		Namespace:  opt.Namespace,
+		PodName:    os.Getenv(util.KeyPodName),
+	}
+
+	var (
+		hookStats     []api_v1beta1.HookStats
+		restoreOutput *restic.RestoreOutput
+		errs          []error
+	)
+
+	preRestoreStats, err := executor.Execute(api_v1beta1.HookPreRestore, restoreHooks.PreRestore)
+	if preRestoreStats != nil {
+		hookStats = append(hookStats, *preRestoreStats)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	} else {
+		restoreOutput, err = w.RunRestore(restoreOptions)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	postRestoreStats, err := executor.Execute(api_v1beta1.HookPostRestore, restoreHooks.PostRestore)
+	if postRestoreStats != nil {
+		hookStats = append(hookStats, *postRestoreStats)
+	}
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return &restic.RestoreOutput{
+			HostRestoreStats: []api_v1beta1.HostRestoreStats{
+				{
+					Hostname: opt.Host,
+					Hooks:    hookStats,
+				},
+			},
+		}, errors.NewAggregate(errs)
+	}
+
+	for i := range restoreOutput.HostRestoreStats {
+		if restoreOutput.HostRestoreStats[i].Hostname == opt.Host {
+			restoreOutput.HostRestoreStats[i].Hooks = hookStats
+		}
+	}
+	return restoreOutput, nil
 }
 
 func (c *Options) HandleRestoreSuccess(restoreOutput *restic.RestoreOutput) error {
@@ -197,7 +261,7 @@ func (c *Options) HandleRestoreSuccess(restoreOutput *restic.RestoreOutput) erro
 	return statusOpt.UpdatePostRestoreStatus(restoreOutput)
 }
 
-func (c *Options) HandleRestoreFailure(restoreErr error) error {
+func (c *Options) HandleRestoreFailure(failedOutput *restic.RestoreOutput, restoreErr error) error {
 	// write log
 	log.Warningf("Failed to complete restore process for RestoreSession %s. Reason: %v", c.RestoreSessionName, restoreErr)
 
@@ -216,6 +280,14 @@ func (c *Options) HandleRestoreFailure(restoreErr error) error {
 			},
 		},
 	}
+	// keep the statistics of the hooks that has been executed before the failure
+	if failedOutput != nil {
+		for _, hostStats := range failedOutput.HostRestoreStats {
+			if hostStats.Hostname == c.Host {
+				restoreOutput.HostRestoreStats[0].Hooks = hostStats.Hooks
+			}
+		}
+	}
 
 	statusOpt := status.UpdateStatusOptions{
 		Config:         c.Config,
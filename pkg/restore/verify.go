@@ -0,0 +1,256 @@
+package restore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/appscode/go/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/apis/core"
+	"stash.appscode.dev/stash/apis"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
+	v1beta1_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
+	"stash.appscode.dev/stash/pkg/eventer"
+	"stash.appscode.dev/stash/pkg/restic"
+	"stash.appscode.dev/stash/pkg/util"
+)
+
+const (
+	// EnvRestoreDir is the environment variable that holds the directory where the data has been restored
+	// for the exec checks of a verification.
+	EnvRestoreDir = "STASH_RESTORE_DIR"
+
+	// maxCheckOutputLength is the maximum length of the output of an exec check recorded as failure reason
+	maxCheckOutputLength = 512
+)
+
+// Verify restores the latest snapshots of the BackupConfiguration of a RestoreSession in verify mode into the scratch
+// directory and runs the checks against the restored data. The restored data is removed afterwards. The result is
+// recorded in the status of the BackupConfiguration and sent to the Pushgateway. An error is returned if the
+// verification has failed.
+func Verify(opt *Options, scratchDir string) error {
+	restoreSession, err := opt.StashClient.StashV1beta1().RestoreSessions(opt.Namespace).Get(opt.RestoreSessionName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if restoreSession.Spec.Verification == nil {
+		return fmt.Errorf("RestoreSession %s/%s is not in verify mode", restoreSession.Namespace, restoreSession.Name)
+	}
+
+	startTime := time.Now()
+	verificationStatus, verifyErr := opt.runVerification(restoreSession, scratchDir)
+	if verifyErr != nil {
+		verificationStatus.Phase = api_v1beta1.VerificationFailed
+		verificationStatus.Error = verifyErr.Error()
+	}
+	verificationStatus.RestoreSession = restoreSession.Name
+	verificationStatus.Duration = time.Since(startTime).String()
+	verificationStatus.LastVerificationTime = &metav1.Time{Time: time.Now()}
+
+	return opt.handleVerificationResult(restoreSession, verificationStatus)
+}
+
+func (opt *Options) runVerification(restoreSession *api_v1beta1.RestoreSession, scratchDir string) (*api_v1beta1.RestoreVerificationStatus, error) {
+	verificationStatus := &api_v1beta1.RestoreVerificationStatus{}
+
+	backupConfig, err := opt.StashClient.StashV1beta1().BackupConfigurations(restoreSession.Namespace).Get(restoreSession.Spec.Verification.BackupConfiguration.Name, metav1.GetOptions{})
+	if err != nil {
+		return verificationStatus, err
+	}
+	repository, err := opt.StashClient.StashV1alpha1().Repositories(restoreSession.RepositoryNamespace()).Get(restoreSession.Spec.Repository.Name, metav1.GetOptions{})
+	if err != nil {
+		return verificationStatus, err
+	}
+
+	extraOptions := util.ExtraOptions{
+		Host:        opt.Host,
+		SecretDir:   opt.SetupOpt.SecretDir,
+		EnableCache: opt.SetupOpt.EnableCache,
+		ScratchDir:  opt.SetupOpt.ScratchDir,
+
+		BandwidthLimit: restoreSession.Spec.BandwidthLimit,
+	}
+	setupOptions, err := util.SetupOptionsForRepository(*repository, extraOptions)
+	if err != nil {
+		return verificationStatus, err
+	}
+	w, err := restic.NewResticWrapper(setupOptions)
+	if err != nil {
+		return verificationStatus, err
+	}
+
+	// restore the latest snapshots of the backed up paths if the rules don't specify what to restore
+	restoreOptions := util.RestoreOptionsForHost(opt.Host, restoreSession.Spec.Rules)
+	if len(restoreOptions.Snapshots) == 0 && len(restoreOptions.RestorePaths) == 0 && backupConfig.Spec.Target != nil {
+		restoreOptions.RestorePaths = backupConfig.Spec.Target.Paths
+	}
+	if len(restoreOptions.Snapshots) == 0 && len(restoreOptions.RestorePaths) == 0 {
+		return verificationStatus, fmt.Errorf("nothing to verify. Reason: neither the rules nor the target of BackupConfiguration %s/%s specify any path", backupConfig.Namespace, backupConfig.Name)
+	}
+
+	// restore into a separate directory so that the scratch directory can be reused by the checks
+	restoreDir, err := ioutil.TempDir(scratchDir, "verify-")
+	if err != nil {
+		return verificationStatus, err
+	}
+	defer func() {
+		if err := os.RemoveAll(restoreDir); err != nil {
+			log.Warningf("Failed to remove restored data from %s. Reason: %v", restoreDir, err)
+		}
+	}()
+	restoreOptions.Destination = restoreDir
+	restoreOptions.PathMapping = nil
+
+	restoreOutput, err := w.RunRestore(restoreOptions)
+	if err != nil {
+		return verificationStatus, err
+	}
+	for _, hostStats := range restoreOutput.HostRestoreStats {
+		verificationStatus.Snapshots = append(verificationStatus.Snapshots, hostStats.Snapshots...)
+	}
+
+	// run the checks against the restored data
+	verificationStatus.Phase = api_v1beta1.VerificationSucceeded
+	for _, check := range restoreSession.Spec.Verification.Checks {
+		checkStatus := api_v1beta1.VerificationCheckStatus{
+			Name:  check.Name,
+			Phase: api_v1beta1.VerificationSucceeded,
+		}
+		if err := runCheck(check, restoreDir); err != nil {
+			log.Warningf("Check %q has failed. Reason: %v", check.Name, err)
+			checkStatus.Phase = api_v1beta1.VerificationFailed
+			checkStatus.Reason = err.Error()
+			verificationStatus.Phase = api_v1beta1.VerificationFailed
+		}
+		verificationStatus.Checks = append(verificationStatus.Checks, checkStatus)
+	}
+	return verificationStatus, nil
+}
+
+func (opt *Options) handleVerificationResult(restoreSession *api_v1beta1.RestoreSession, verificationStatus *api_v1beta1.RestoreVerificationStatus) error {
+	backupConfig, err := opt.StashClient.StashV1beta1().BackupConfigurations(restoreSession.Namespace).Get(restoreSession.Spec.Verification.BackupConfiguration.Name, metav1.GetOptions{})
+	if err == nil {
+		_, err = v1beta1_util.UpdateBackupConfigurationStatus(opt.StashClient.StashV1beta1(), backupConfig, func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus {
+			in.Verification = verificationStatus
+			return in
+		}, apis.EnableStatusSubresource)
+	}
+	if err != nil {
+		log.Errorf("Failed to record verification result in BackupConfiguration %s/%s. Reason: %v", restoreSession.Namespace, restoreSession.Spec.Verification.BackupConfiguration.Name, err)
+	}
+
+	if opt.Metrics.Enabled {
+		if merr := opt.Metrics.SendRestoreVerificationMetrics(opt.Config, restoreSession, verificationStatus); merr != nil {
+			log.Errorf("Failed to send restore verification metrics. Reason: %v", merr)
+		}
+	}
+
+	if verificationStatus.Phase == api_v1beta1.VerificationSucceeded {
+		eventer.CreateEventWithLog(
+			opt.KubeClient,
+			eventer.EventSourceRestoreVerifier,
+			restoreSession,
+			core.EventTypeNormal,
+			eventer.EventReasonRestoreVerificationSucceeded,
+			fmt.Sprintf("restore verification succeeded for BackupConfiguration %s", restoreSession.Spec.Verification.BackupConfiguration.Name),
+		)
+		return err
+	}
+
+	reason := verificationStatus.Error
+	if reason == "" {
+		var failedChecks []string
+		for _, check := range verificationStatus.Checks {
+			if check.Phase == api_v1beta1.VerificationFailed {
+				failedChecks = append(failedChecks, check.Name)
+			}
+		}
+		reason = fmt.Sprintf("checks %s have failed", strings.Join(failedChecks, ", "))
+	}
+	eventer.CreateEventWithLog(
+		opt.KubeClient,
+		eventer.EventSourceRestoreVerifier,
+		restoreSession,
+		core.EventTypeWarning,
+		eventer.EventReasonRestoreVerificationFailed,
+		fmt.Sprintf("restore verification failed for BackupConfiguration %s. Reason: %s", restoreSession.Spec.Verification.BackupConfiguration.Name, reason),
+	)
+	return fmt.Errorf("restore verification failed. Reason: %s", reason)
+}
+
+// runCheck runs a check against the data restored in restoreDir.
+// The paths of the checks are the original paths of the backed up files.
+func runCheck(check api_v1beta1.VerificationCheck, restoreDir string) error {
+	switch {
+	case check.FileCount != nil:
+		return checkFileCount(*check.FileCount, restoreDir)
+	case check.Checksum != nil:
+		return checkChecksum(*check.Checksum, restoreDir)
+	case check.Exec != nil:
+		return checkExec(*check.Exec, restoreDir)
+	}
+	return fmt.Errorf("no action has been specified")
+}
+
+func checkFileCount(check api_v1beta1.FileCountCheck, restoreDir string) error {
+	var count int64
+	err := filepath.Walk(filepath.Join(restoreDir, check.Path), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if check.Min != nil && count < *check.Min {
+		return fmt.Errorf("found %d files in %s. Expected at least %d", count, check.Path, *check.Min)
+	}
+	if check.Max != nil && count > *check.Max {
+		return fmt.Errorf("found %d files in %s. Expected at most %d", count, check.Path, *check.Max)
+	}
+	return nil
+}
+
+func checkChecksum(check api_v1beta1.ChecksumCheck, restoreDir string) error {
+	f, err := os.Open(filepath.Join(restoreDir, check.Path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(sum, check.SHA256) {
+		return fmt.Errorf("sha256 checksum of %s is %s. Expected %s", check.Path, sum, check.SHA256)
+	}
+	return nil
+}
+
+func checkExec(check api_v1beta1.ExecCheck, restoreDir string) error {
+	cmd := exec.Command(check.Command[0], check.Command[1:]...)
+	cmd.Dir = restoreDir
+	cmd.Env = append(os.Environ(), EnvRestoreDir+"="+restoreDir)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		output := strings.TrimSpace(string(out))
+		if len(output) > maxCheckOutputLength {
+			output = output[len(output)-maxCheckOutputLength:]
+		}
+		return fmt.Errorf("command has failed. Reason: %v. Output: %s", err, output)
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
 	"stash.appscode.dev/stash/pkg/controller"
 	"stash.appscode.dev/stash/pkg/eventer"
+	resolvedjobregistry "stash.appscode.dev/stash/pkg/registry/resolvedjob"
 	snapregistry "stash.appscode.dev/stash/pkg/registry/snapshot"
 )
 
@@ -116,6 +117,10 @@ func (c completedConfig) New() (*StashServer, error) {
 			ctrl.NewResticWebhook(),
 			ctrl.NewRecoveryWebhook(),
 			ctrl.NewRepositoryWebhook(),
+			ctrl.NewBackupConfigurationWebhook(),
+			ctrl.NewBackupBlueprintWebhook(),
+			ctrl.NewTaskWebhook(),
+			ctrl.NewFunctionWebhook(),
 			// ctrl.NewBackupSessionWebhook(),
 			ctrl.NewRestoreSessionWebhook(),
 		)
@@ -240,7 +245,18 @@ func (c completedConfig) New() (*StashServer, error) {
 	{
 		apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(repositories.GroupName, Scheme, metav1.ParameterCodec, Codecs)
 		v1alpha1storage := map[string]rest.Storage{}
-		v1alpha1storage[v1alpha1.ResourcePluralSnapshot] = snapregistry.NewREST(c.ExtraConfig.ClientConfig)
+		snapshotStorage := snapregistry.NewREST(c.ExtraConfig.ClientConfig)
+		if c.ExtraConfig.SnapshotIndexRefreshPeriod > 0 {
+			snapshotStorage.EnableIndex(c.ExtraConfig.SnapshotIndexRefreshPeriod)
+			s.GenericAPIServer.AddPostStartHookOrDie("snapshot-index",
+				func(context genericapiserver.PostStartHookContext) error {
+					go snapshotStorage.RunIndex(context.StopCh)
+					return nil
+				},
+			)
+		}
+		v1alpha1storage[v1alpha1.ResourcePluralSnapshot] = snapshotStorage
+		v1alpha1storage[v1alpha1.ResourcePluralResolvedJob] = resolvedjobregistry.NewREST(ctrl)
 		apiGroupInfo.VersionedResourcesStorageMap["v1alpha1"] = v1alpha1storage
 
 		if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
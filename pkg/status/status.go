@@ -3,13 +3,16 @@ package status
 import (
 	"fmt"
 	"path/filepath"
+	"time"
 
+	core_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/kubernetes/pkg/apis/core"
 	"stash.appscode.dev/stash/apis"
 	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 	cs "stash.appscode.dev/stash/client/clientset/versioned"
 	stash_util "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1alpha1/util"
 	stash_util_v1beta1 "stash.appscode.dev/stash/client/clientset/versioned/typed/stash/v1beta1/util"
@@ -92,8 +95,9 @@ func (o UpdateStatusOptions) UpdatePostBackupStatus(backupOutput *restic.BackupO
 		}
 	}
 
-	// if overall backup succeeded and repository status presents in backupOutput then update Repository status
-	if overallBackupSucceeded && backupOutput.RepositoryStats.Integrity != nil {
+	// if overall backup succeeded then update Repository status. the integrity and the size are not present
+	// in the backupOutput if they are left for the repository maintenance jobs.
+	if overallBackupSucceeded && o.Repository != "" {
 		repository, err := o.StashClient.StashV1alpha1().Repositories(o.Namespace).Get(o.Repository, metav1.GetOptions{})
 		if err != nil {
 			return err
@@ -103,8 +107,12 @@ func (o UpdateStatusOptions) UpdatePostBackupStatus(backupOutput *restic.BackupO
 			o.StashClient.StashV1alpha1(),
 			repository,
 			func(in *api.RepositoryStatus) *api.RepositoryStatus {
-				in.Integrity = backupOutput.RepositoryStats.Integrity
-				in.Size = backupOutput.RepositoryStats.Size
+				if backupOutput.RepositoryStats.Integrity != nil {
+					in.Integrity = backupOutput.RepositoryStats.Integrity
+				}
+				if backupOutput.RepositoryStats.Size != "" {
+					in.Size = backupOutput.RepositoryStats.Size
+				}
 				in.SnapshotCount = backupOutput.RepositoryStats.SnapshotCount
 				in.SnapshotsRemovedOnLastCleanup = backupOutput.RepositoryStats.SnapshotsRemovedOnLastCleanup
 
@@ -122,17 +130,52 @@ func (o UpdateStatusOptions) UpdatePostBackupStatus(backupOutput *restic.BackupO
 			return err
 		}
 	}
+
+	backupConfig, err := o.StashClient.StashV1beta1().BackupConfigurations(o.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err = o.updateBackendReachableCondition(backupConfig, backupOutput); err != nil {
+		return err
+	}
+
 	// if metrics enabled then send metrics to the Prometheus pushgateway
 	if o.Metrics.Enabled {
-		backupConfig, err := o.StashClient.StashV1beta1().BackupConfigurations(o.Namespace).Get(backupSession.Spec.BackupConfiguration.Name, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
 		return o.Metrics.SendBackupHostMetrics(o.Config, backupConfig, backupOutput)
 	}
 	return nil
 }
 
+// updateBackendReachableCondition records in the BackupConfiguration status whether the backend was reachable during the backup.
+// the condition is left unchanged if the hosts have failed for the reasons unrelated to the backend.
+func (o UpdateStatusOptions) updateBackendReachableCondition(backupConfig *api_v1beta1.BackupConfiguration, backupOutput *restic.BackupOutput) error {
+	status, reason, message := core_v1.ConditionUnknown, "", ""
+	for _, hostStats := range backupOutput.HostBackupStats {
+		if hostStats.Error == "" {
+			if status == core_v1.ConditionUnknown {
+				status, reason, message = core_v1.ConditionTrue, "BackupSucceeded", fmt.Sprintf("backup succeeded for host %s", hostStats.Hostname)
+			}
+		} else if restic.IsBackendErrorMessage(hostStats.Error) {
+			status, reason, message = core_v1.ConditionFalse, "BackendUnreachable", fmt.Sprintf("backup failed for host %s. Reason: %s", hostStats.Hostname, hostStats.Error)
+			break
+		}
+	}
+	if status == core_v1.ConditionUnknown {
+		return nil
+	}
+
+	_, err := stash_util_v1beta1.UpdateBackupConfigurationStatus(
+		o.StashClient.StashV1beta1(),
+		backupConfig,
+		func(in *api_v1beta1.BackupConfigurationStatus) *api_v1beta1.BackupConfigurationStatus {
+			in.SetCondition(api_v1beta1.BackendReachable, status, reason, message)
+			return in
+		},
+		apis.EnableStatusSubresource,
+	)
+	return err
+}
+
 func (o UpdateStatusOptions) UpdatePostRestoreStatus(restoreOutput *restic.RestoreOutput) error {
 	if restoreOutput == nil {
 		return fmt.Errorf("invalid restore output. Restore output must not be nil")
@@ -179,3 +222,147 @@ func (o UpdateStatusOptions) UpdatePostRestoreStatus(restoreOutput *restic.Resto
 	}
 	return nil
 }
+
+// UpdatePostMaintenanceStatus records the result of a maintenance operation in the status of the Repository and
+// writes an event to the Repository. The error of the maintenance operation is returned so that the job fails.
+func (o UpdateStatusOptions) UpdatePostMaintenanceStatus(operation restic.MaintenanceOperation, repoStats *restic.RepositoryStats, maintenanceErr error) error {
+	repository, err := o.StashClient.StashV1alpha1().Repositories(o.Namespace).Get(o.Repository, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if repoStats != nil {
+		_, err = stash_util.UpdateRepositoryStatus(
+			o.StashClient.StashV1alpha1(),
+			repository,
+			func(in *api.RepositoryStatus) *api.RepositoryStatus {
+				if repoStats.Integrity != nil {
+					in.Integrity = repoStats.Integrity
+				}
+				if maintenanceErr == nil {
+					in.Size = repoStats.Size
+					in.SnapshotCount = repoStats.SnapshotCount
+				}
+
+				currentTime := metav1.Now()
+				switch operation {
+				case restic.MaintenanceCheck:
+					// the check has run even if it has found errors
+					in.LastCheckTime = &currentTime
+				case restic.MaintenancePrune:
+					in.LastPruneTime = &currentTime
+				}
+				return in
+			},
+			apis.EnableStatusSubresource,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if maintenanceErr != nil {
+		eventer.CreateEventWithLog(
+			o.KubeClient,
+			eventer.EventSourceRepositoryMaintainer,
+			repository,
+			core.EventTypeWarning,
+			eventer.EventReasonRepositoryMaintenanceFailed,
+			fmt.Sprintf("%s of the repository has failed. Reason: %v", operation, maintenanceErr),
+		)
+		return maintenanceErr
+	}
+	eventer.CreateEventWithLog(
+		o.KubeClient,
+		eventer.EventSourceRepositoryMaintainer,
+		repository,
+		core.EventTypeNormal,
+		eventer.EventReasonRepositoryMaintenanceSucceeded,
+		fmt.Sprintf("%s of the repository has succeeded", operation),
+	)
+	return nil
+}
+
+// UpdatePostCopyStatus records the result of copying the snapshots of the Repository to a replica in the status of the
+// Repository, writes an event to the Repository and sends the replication metrics. The error of the copy is returned so that the job fails.
+func (o UpdateStatusOptions) UpdatePostCopyStatus(replica string, copyStats *restic.CopyStats, copyErr error) error {
+	repository, err := o.StashClient.StashV1alpha1().Repositories(o.Namespace).Get(o.Repository, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	var replicaStatus api.ReplicaStatus
+	updatedRepository, err := stash_util.UpdateRepositoryStatus(
+		o.StashClient.StashV1alpha1(),
+		repository,
+		func(in *api.RepositoryStatus) *api.RepositoryStatus {
+			idx := -1
+			for i := range in.Replicas {
+				if in.Replicas[i].Name == replica {
+					idx = i
+					break
+				}
+			}
+			if idx < 0 {
+				in.Replicas = append(in.Replicas, api.ReplicaStatus{Name: replica})
+				idx = len(in.Replicas) - 1
+			}
+			status := &in.Replicas[idx]
+
+			currentTime := metav1.Now()
+			status.LastCopyTime = &currentTime
+			if copyErr == nil {
+				status.Phase = api.ReplicationSucceeded
+				status.LastSuccessfulCopyTime = &currentTime
+				status.Reason = ""
+				status.Lag = (0 * time.Second).String()
+				if copyStats.LatestSnapshotTime != nil {
+					status.LatestCopiedSnapshotTime = &metav1.Time{Time: *copyStats.LatestSnapshotTime}
+				}
+			} else {
+				status.Phase = api.ReplicationFailed
+				status.Reason = copyErr.Error()
+				if copyStats != nil && copyStats.LatestSnapshotTime != nil && status.LatestCopiedSnapshotTime != nil {
+					status.Lag = copyStats.LatestSnapshotTime.Sub(status.LatestCopiedSnapshotTime.Time).Round(time.Second).String()
+				}
+			}
+			if copyStats != nil {
+				status.SnapshotsCopied = copyStats.SnapshotsCopied
+			}
+			replicaStatus = *status
+			return in
+		},
+		apis.EnableStatusSubresource,
+	)
+	if err != nil {
+		return err
+	}
+
+	if copyErr != nil {
+		eventer.CreateEventWithLog(
+			o.KubeClient,
+			eventer.EventSourceRepositoryMaintainer,
+			updatedRepository,
+			core.EventTypeWarning,
+			eventer.EventReasonRepositoryCopyFailed,
+			fmt.Sprintf("failed to copy snapshots to Repository %s. Reason: %v", replica, copyErr),
+		)
+	} else {
+		eventer.CreateEventWithLog(
+			o.KubeClient,
+			eventer.EventSourceRepositoryMaintainer,
+			updatedRepository,
+			core.EventTypeNormal,
+			eventer.EventReasonRepositoryCopySucceeded,
+			fmt.Sprintf("%d snapshots have been copied to Repository %s", replicaStatus.SnapshotsCopied, replica),
+		)
+	}
+
+	// if metrics enabled then send metrics to the Prometheus pushgateway
+	if o.Metrics.Enabled {
+		if err := o.Metrics.SendReplicationMetrics(updatedRepository, replicaStatus); err != nil && copyErr == nil {
+			return err
+		}
+	}
+	return copyErr
+}
@@ -98,8 +98,18 @@ func pvcBackupFunction(image docker.Docker) *api_v1beta1.Function {
 				"--scratch-dir=/tmp",
 				"--enable-cache=${ENABLE_CACHE:=true}",
 				"--max-connections=${MAX_CONNECTIONS:=0}",
+				"--limit-upload=${LIMIT_UPLOAD:=0}",
+				"--limit-download=${LIMIT_DOWNLOAD:=0}",
 				"--hostname=${HOSTNAME:=}",
 				"--backup-paths=${TARGET_PATHS}",
+				"--include=${TARGET_INCLUDE_PATTERNS:=}",
+				"--exclude=${TARGET_EXCLUDE_PATTERNS:=}",
+				"--iexclude=${TARGET_IEXCLUDE_PATTERNS:=}",
+				"--exclude-file=${TARGET_EXCLUDE_FILES:=}",
+				"--exclude-caches=${TARGET_EXCLUDE_CACHES:=false}",
+				"--exclude-if-present=${TARGET_EXCLUDE_IF_PRESENT:=}",
+				"--one-file-system=${TARGET_ONE_FILE_SYSTEM:=false}",
+				"--tags=${BACKUP_TAGS:=}",
 				"--retention-keep-last=${RETENTION_KEEP_LAST:=0}",
 				"--retention-keep-hourly=${RETENTION_KEEP_HOURLY:=0}",
 				"--retention-keep-daily=${RETENTION_KEEP_DAILY:=0}",
@@ -109,6 +119,8 @@ func pvcBackupFunction(image docker.Docker) *api_v1beta1.Function {
 				"--retention-keep-tags=${RETENTION_KEEP_TAGS:=}",
 				"--retention-prune=${RETENTION_PRUNE:=false}",
 				"--retention-dry-run=${RETENTION_DRY_RUN:=false}",
+				"--skip-check=${SKIP_REPOSITORY_CHECK:=false}",
+				"--skip-stats=${SKIP_REPOSITORY_STATS:=false}",
 				"--output-dir=${outputDir:=}",
 			},
 			VolumeMounts: []core.VolumeMount{
@@ -142,9 +154,18 @@ func pvcRestoreFunction(image docker.Docker) *api_v1beta1.Function {
 				"--scratch-dir=/tmp",
 				"--enable-cache=${ENABLE_CACHE:=true}",
 				"--max-connections=${MAX_CONNECTIONS:=0}",
+				"--limit-upload=${LIMIT_UPLOAD:=0}",
+				"--limit-download=${LIMIT_DOWNLOAD:=0}",
 				"--hostname=${HOSTNAME:=}",
 				"--restore-paths=${RESTORE_PATHS}",
 				"--snapshots=${RESTORE_SNAPSHOTS:=}",
+				"--destination=${RESTORE_DESTINATION:=}",
+				"--path-mapping=${RESTORE_PATH_MAPPING:=}",
+				"--include=${RESTORE_INCLUDE_PATTERNS:=}",
+				"--exclude=${RESTORE_EXCLUDE_PATTERNS:=}",
+				"--before=${RESTORE_BEFORE:=}",
+				"--after=${RESTORE_AFTER:=}",
+				"--tags=${RESTORE_TAGS:=}",
 				"--output-dir=${outputDir:=}",
 			},
 			VolumeMounts: []core.VolumeMount{
@@ -7,6 +7,7 @@ import (
 	batch "k8s.io/api/batch/v1"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	core_util "kmodules.xyz/client-go/core/v1"
 	"kmodules.xyz/client-go/tools/analytics"
 	"kmodules.xyz/client-go/tools/cli"
 	"kmodules.xyz/client-go/tools/clientcmd"
@@ -16,6 +17,7 @@ import (
 	api_v1beta1 "stash.appscode.dev/stash/apis/stash/v1beta1"
 	cs "stash.appscode.dev/stash/client/clientset/versioned"
 	"stash.appscode.dev/stash/pkg/docker"
+	"stash.appscode.dev/stash/pkg/restic"
 )
 
 func NewCheckJob(restic *api_v1alpha1.Restic, hostName, smartPrefix string, image docker.Docker) *batch.Job {
@@ -296,6 +298,195 @@ func NewPVCRestorerJob(rs *api_v1beta1.RestoreSession, repository *api_v1alpha1.
 	return jobTemplate, nil
 }
 
+// NewRestoreVerifierJob return a job definition to verify the backups using a RestoreSession in verify mode.
+// The snapshots are restored into an EmptyDir which is removed along with the pod.
+func NewRestoreVerifierJob(rs *api_v1beta1.RestoreSession, repository *api_v1alpha1.Repository, image docker.Docker) (*core.PodTemplateSpec, error) {
+	if rs.Spec.Verification == nil {
+		return nil, fmt.Errorf("RestoreSession %s/%s is not in verify mode", rs.Namespace, rs.Name)
+	}
+
+	// the snapshots of the host specified as sourceHost in the rules are verified
+	host := restic.DefaultHost
+	for _, rule := range rs.Spec.Rules {
+		if rule.SourceHost != "" {
+			host = rule.SourceHost
+			break
+		}
+	}
+
+	container := core.Container{
+		Name:  StashContainer,
+		Image: image.ToContainerImage(),
+		Args: append([]string{
+			"verify-restore",
+			"--restoresession=" + rs.Name,
+			"--namespace=" + rs.Namespace,
+			"--hostname=" + host,
+			"--scratch-dir=" + VerifyDirMountPath,
+			"--secret-dir=" + StashSecretMountDir,
+			fmt.Sprintf("--enable-cache=%v", !rs.Spec.TempDir.DisableCaching),
+			fmt.Sprintf("--max-connections=%v", repository.Spec.Backend.MaxConnections()),
+			"--metrics-enabled=true",
+			fmt.Sprintf("--enable-status-subresource=%v", apis.EnableStatusSubresource),
+			"--pushgateway-url=" + PushgatewayURL(),
+			fmt.Sprintf("--use-kubeapiserver-fqdn-for-aks=%v", clientcmd.UseKubeAPIServerFQDNForAKS()),
+			fmt.Sprintf("--enable-analytics=%v", cli.EnableAnalytics),
+		}, cli.LoggerOptions.ToFlags()...),
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      StashSecretVolume,
+				MountPath: StashSecretMountDir,
+			},
+			{
+				Name:      VerifyDirVolumeName,
+				MountPath: VerifyDirMountPath,
+			},
+		},
+	}
+
+	// mount tmp volume
+	container.VolumeMounts = UpsertTmpVolumeMount(container.VolumeMounts)
+
+	// if Repository uses local volume as backend, we have to mount it inside the job
+	if repository.Spec.Backend.Local != nil {
+		_, mnt := repository.Spec.Backend.Local.ToVolumeAndMount(LocalVolumeName)
+		container.VolumeMounts = append(container.VolumeMounts, mnt)
+	}
+
+	// Pass container RuntimeSettings from RestoreSession
+	if rs.Spec.RuntimeSettings.Container != nil {
+		container = ofst_util.ApplyContainerRuntimeSettings(container, *rs.Spec.RuntimeSettings.Container)
+	}
+
+	// restore process need to be run as root user to preserve file ownership which may be checked by the exec checks.
+	securityContext := &core.SecurityContext{
+		RunAsUser:  types.Int64P(0),
+		RunAsGroup: types.Int64P(0),
+	}
+	if rs.Spec.RuntimeSettings.Container != nil {
+		container.SecurityContext = UpsertSecurityContext(securityContext, rs.Spec.RuntimeSettings.Container.SecurityContext)
+	} else {
+		container.SecurityContext = securityContext
+	}
+
+	jobTemplate := &core.PodTemplateSpec{
+		Spec: core.PodSpec{
+			Containers:    []core.Container{container},
+			RestartPolicy: core.RestartPolicyNever,
+		},
+	}
+
+	// Upsert default pod level security context
+	jobTemplate.Spec.SecurityContext = UpsertDefaultPodSecurityContext(jobTemplate.Spec.SecurityContext)
+
+	// Pass pod RuntimeSettings from RestoreSession
+	if rs.Spec.RuntimeSettings.Pod != nil {
+		jobTemplate.Spec = ofst_util.ApplyPodRuntimeSettings(jobTemplate.Spec, *rs.Spec.RuntimeSettings.Pod)
+	}
+
+	// add an emptyDir volume for holding temporary files
+	jobTemplate.Spec.Volumes = UpsertTmpVolume(jobTemplate.Spec.Volumes, rs.Spec.TempDir)
+	// add an emptyDir volume where the snapshots will be restored
+	jobTemplate.Spec.Volumes = core_util.UpsertVolume(jobTemplate.Spec.Volumes, core.Volume{
+		Name: VerifyDirVolumeName,
+		VolumeSource: core.VolumeSource{
+			EmptyDir: &core.EmptyDirVolumeSource{
+				Medium:    rs.Spec.Verification.ScratchDir.Medium,
+				SizeLimit: rs.Spec.Verification.ScratchDir.SizeLimit,
+			},
+		},
+	})
+	// add storage secret as volume to the workload. this has been mounted on the container above.
+	jobTemplate.Spec.Volumes = UpsertSecretVolume(jobTemplate.Spec.Volumes, repository.Spec.Backend.StorageSecretName)
+	// if Repository uses local volume as backend, append this volume to the job
+	jobTemplate.Spec.Volumes = MergeLocalVolume(jobTemplate.Spec.Volumes, &repository.Spec.Backend)
+
+	return jobTemplate, nil
+}
+
+// NewRepositoryMaintenanceJob returns the template of the job that runs a maintenance operation on a Repository
+func NewRepositoryMaintenanceJob(repository *api_v1alpha1.Repository, operation restic.MaintenanceOperation, image docker.Docker) *core.PodTemplateSpec {
+	args := []string{
+		"maintain-repository",
+		"--repository=" + repository.Name,
+		"--namespace=" + repository.Namespace,
+		"--operation=" + string(operation),
+		"--secret-dir=" + StashSecretMountDir,
+		"--scratch-dir=/tmp",
+		"--enable-cache=true",
+		fmt.Sprintf("--enable-status-subresource=%v", apis.EnableStatusSubresource),
+	}
+	if m := repository.Spec.Maintenance; operation == restic.MaintenanceCheck && m != nil && m.Check != nil && m.Check.ReadDataSubset != "" {
+		args = append(args, "--read-data-subset="+m.Check.ReadDataSubset)
+	}
+	return newRepositoryJob(repository, args, image)
+}
+
+// NewRepositoryCopyJob creates a job that copies the new snapshots of a Repository to the given replica Repository.
+// The storage secret of the replica is read by the job itself.
+func NewRepositoryCopyJob(repository *api_v1alpha1.Repository, replica string, image docker.Docker) *core.PodTemplateSpec {
+	args := []string{
+		"copy-repository",
+		"--repository=" + repository.Name,
+		"--replica=" + replica,
+		"--namespace=" + repository.Namespace,
+		"--secret-dir=" + StashSecretMountDir,
+		"--scratch-dir=/tmp",
+		"--enable-cache=true",
+		"--metrics-enabled=true",
+		"--pushgateway-url=" + PushgatewayURL(),
+		fmt.Sprintf("--enable-status-subresource=%v", apis.EnableStatusSubresource),
+	}
+	return newRepositoryJob(repository, args, image)
+}
+
+func newRepositoryJob(repository *api_v1alpha1.Repository, args []string, image docker.Docker) *core.PodTemplateSpec {
+	args = append(args,
+		fmt.Sprintf("--use-kubeapiserver-fqdn-for-aks=%v", clientcmd.UseKubeAPIServerFQDNForAKS()),
+		fmt.Sprintf("--enable-analytics=%v", cli.EnableAnalytics),
+	)
+
+	container := core.Container{
+		Name:  StashContainer,
+		Image: image.ToContainerImage(),
+		Args:  append(args, cli.LoggerOptions.ToFlags()...),
+		VolumeMounts: []core.VolumeMount{
+			{
+				Name:      StashSecretVolume,
+				MountPath: StashSecretMountDir,
+			},
+		},
+	}
+
+	// mount tmp volume
+	container.VolumeMounts = UpsertTmpVolumeMount(container.VolumeMounts)
+
+	// if Repository uses local volume as backend, we have to mount it inside the job
+	if repository.Spec.Backend.Local != nil {
+		_, mnt := repository.Spec.Backend.Local.ToVolumeAndMount(LocalVolumeName)
+		container.VolumeMounts = append(container.VolumeMounts, mnt)
+	}
+
+	jobTemplate := &core.PodTemplateSpec{
+		Spec: core.PodSpec{
+			Containers:    []core.Container{container},
+			RestartPolicy: core.RestartPolicyNever,
+		},
+	}
+
+	// Upsert default pod level security context
+	jobTemplate.Spec.SecurityContext = UpsertDefaultPodSecurityContext(jobTemplate.Spec.SecurityContext)
+
+	// add an emptyDir volume for holding temporary files and the cache
+	jobTemplate.Spec.Volumes = UpsertTmpVolume(jobTemplate.Spec.Volumes, api_v1beta1.EmptyDirSettings{})
+	// add storage secret as volume to the workload. this has been mounted on the container above.
+	jobTemplate.Spec.Volumes = UpsertSecretVolume(jobTemplate.Spec.Volumes, repository.Spec.Backend.StorageSecretName)
+	// if Repository uses local volume as backend, append this volume to the job
+	jobTemplate.Spec.Volumes = MergeLocalVolume(jobTemplate.Spec.Volumes, &repository.Spec.Backend)
+
+	return jobTemplate
+}
+
 func NewVolumeSnapshotterJob(bs *api_v1beta1.BackupSession, bc *api_v1beta1.BackupConfiguration, image docker.Docker) (*core.PodTemplateSpec, error) {
 	container := core.Container{
 		Name:  StashContainer,
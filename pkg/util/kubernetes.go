@@ -32,6 +32,14 @@ const (
 	TmpDirVolumeName     = "tmp-dir"
 	TmpDirMountPath      = "/tmp"
 	PodinfoVolumeName    = "stash-podinfo"
+	VerifyDirVolumeName  = "stash-verify-dir"
+	VerifyDirMountPath   = "/stash-verify"
+	StepsDirVolumeName   = "stash-steps"
+	StepsDirMountPath    = "/stash-steps"
+	StepRunnerContainer  = "stash-step-runner"
+	// StepRunnerBinary is the path where the stash binary is installed for the step runner.
+	// The steps of a Task run through it when they have conditions or run in parallel.
+	StepRunnerBinary = StepsDirMountPath + "/stash"
 
 	RecoveryJobPrefix   = "stash-recovery-"
 	ScaledownCronPrefix = "stash-scaledown-cron-"
@@ -56,6 +64,8 @@ const (
 	ModelCronJob             = "cronjob"
 	LabelApp                 = "app"
 	LabelBackupConfiguration = apis.StashKey + "/backup-configuration"
+	LabelRepository          = apis.StashKey + "/repository"
+	LabelReplica             = apis.StashKey + "/replica"
 	StashSecretVolume        = "stash-secret-volume"
 	StashSecretMountDir      = "/etc/stash/repository/secret"
 
@@ -1,7 +1,14 @@
 package util
 
 import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
 	go_str "github.com/appscode/go/strings"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
 	api_v1alpha1 "stash.appscode.dev/stash/apis/stash/v1alpha1"
 	api "stash.appscode.dev/stash/apis/stash/v1beta1"
 	"stash.appscode.dev/stash/pkg/restic"
@@ -14,6 +21,8 @@ type ExtraOptions struct {
 	CacertFile  string
 	ScratchDir  string
 	EnableCache bool
+	// BandwidthLimit comes from the BackupConfiguration or the RestoreSession
+	BandwidthLimit *api.BandwidthLimit
 }
 
 func BackupOptionsForBackupConfig(backupConfig api.BackupConfiguration, extraOpt ExtraOptions) restic.BackupOptions {
@@ -23,10 +32,56 @@ func BackupOptionsForBackupConfig(backupConfig api.BackupConfiguration, extraOpt
 	}
 	if backupConfig.Spec.Target != nil {
 		backupOpt.BackupPaths = backupConfig.Spec.Target.Paths
+		backupOpt.FileFilter = backupConfig.Spec.Target.FileFilter
+	}
+	return backupOpt
+}
+
+// SkipRepositoryMaintenance skips the operations after backup that are run by the maintenance jobs of the Repository.
+// The snapshots are still removed according to the retention policy, but the data is not pruned.
+func SkipRepositoryMaintenance(backupOpt restic.BackupOptions, repository api_v1alpha1.Repository) restic.BackupOptions {
+	maintenance := repository.Spec.Maintenance
+	if maintenance == nil {
+		return backupOpt
+	}
+	backupOpt.SkipCheck = maintenance.Check != nil
+	// the key rotation job doesn't read the stats
+	backupOpt.SkipStats = maintenance.Check != nil || maintenance.Prune != nil
+	if maintenance.Prune != nil {
+		backupOpt.RetentionPolicy.Prune = false
 	}
 	return backupOpt
 }
 
+// SnapshotTags returns the tags of the snapshots taken for a BackupSession of a BackupConfiguration.
+// Every snapshot is tagged with the BackupSession, the BackupConfiguration and the target. The version of the
+// application is added too if it is known. The user specified tags of the BackupConfiguration are appended at the end.
+func SnapshotTags(backupConfig api.BackupConfiguration, backupSessionName, appVersion string) []string {
+	tags := []string{
+		snapshotTag(api.SnapshotTagBackupSession, backupSessionName),
+		snapshotTag(api.SnapshotTagBackupConfiguration, backupConfig.Name),
+	}
+	if backupConfig.Spec.Target != nil {
+		tags = append(tags,
+			snapshotTag(api.SnapshotTagTargetKind, backupConfig.Spec.Target.Ref.Kind),
+			snapshotTag(api.SnapshotTagTargetName, backupConfig.Spec.Target.Ref.Name),
+		)
+	}
+	if appVersion != "" {
+		tags = append(tags, snapshotTag(api.SnapshotTagAppVersion, appVersion))
+	}
+	for _, tag := range backupConfig.Spec.Tags {
+		if !go_str.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func snapshotTag(key, value string) string {
+	return key + "=" + value
+}
+
 func RestoreOptionForRestoreSession(restoreSession api.RestoreSession, extraOpt ExtraOptions) restic.RestoreOptions {
 	return RestoreOptionsForHost(extraOpt.Host, restoreSession.Spec.Rules)
 }
@@ -49,6 +104,17 @@ func RestoreOptionsForHost(hostname string, rules []api.Rule) restic.RestoreOpti
 				SourceHost:   sourceHost,
 				RestorePaths: rule.Paths,
 				Snapshots:    rule.Snapshots,
+				Destination:  rule.Destination,
+				PathMapping:  rule.PathMapping,
+				Includes:     rule.Includes,
+				Excludes:     rule.Excludes,
+				Tags:         rule.Tags,
+			}
+			if rule.Before != nil {
+				matchedRule.Before = &rule.Before.Time
+			}
+			if rule.After != nil {
+				matchedRule.After = &rule.After.Time
 			}
 			// if rule has empty targetHost then check further rules to see if any other rule with non-empty targetHost matches
 			if len(rule.TargetHosts) == 0 {
@@ -77,7 +143,7 @@ func SetupOptionsForRepository(repository api_v1alpha1.Repository, extraOpt Extr
 	}
 	endpoint, _ := repository.Spec.Backend.Endpoint()
 
-	return restic.SetupOptions{
+	setupOpt := restic.SetupOptions{
 		Provider:       provider,
 		Bucket:         bucket,
 		Path:           prefix,
@@ -87,5 +153,44 @@ func SetupOptionsForRepository(repository api_v1alpha1.Repository, extraOpt Extr
 		ScratchDir:     extraOpt.ScratchDir,
 		EnableCache:    extraOpt.EnableCache,
 		MaxConnections: repository.Spec.Backend.MaxConnections(),
-	}, nil
+	}
+	if extraOpt.BandwidthLimit != nil {
+		setupOpt.LimitUpload = int(extraOpt.BandwidthLimit.LimitUpload)
+		setupOpt.LimitDownload = int(extraOpt.BandwidthLimit.LimitDownload)
+	}
+	return setupOpt, nil
+}
+
+// NewResticWrapperForRepository writes the storage secret of the Repository into the "secret" directory
+// inside dir and configures a restic wrapper for the Repository that uses the "scratch" directory inside dir.
+// It is used by the operator to access the repository directly. So, the credential providers of the storage secret
+// are disabled. The caller must remove dir when it is done.
+func NewResticWrapperForRepository(kubeClient kubernetes.Interface, repository api_v1alpha1.Repository, dir string) (*restic.ResticWrapper, error) {
+	secret, err := kubeClient.CoreV1().Secrets(repository.Namespace).Get(repository.Spec.Backend.StorageSecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// write repository secrets in the secret dir
+	secretDir := filepath.Join(dir, "secret")
+	if err := os.MkdirAll(secretDir, 0755); err != nil {
+		return nil, err
+	}
+	for key, value := range secret.Data {
+		if err := ioutil.WriteFile(filepath.Join(secretDir, key), value, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	setupOpt, err := SetupOptionsForRepository(repository, ExtraOptions{
+		SecretDir:   secretDir,
+		EnableCache: false,
+		ScratchDir:  filepath.Join(dir, "scratch"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("setup option for repository failed, reason: %s", err)
+	}
+	// the credential providers of the storage secret must not run with the privileges of the operator
+	setupOpt.DisableCredentialProviders = true
+	return restic.NewResticWrapper(setupOpt)
 }
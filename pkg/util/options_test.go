@@ -0,0 +1,70 @@
+package util
+
+import (
+	"testing"
+
+	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+	"stash.appscode.dev/stash/pkg/restic"
+)
+
+func TestSkipRepositoryMaintenance(t *testing.T) {
+	testCases := []struct {
+		name          string
+		maintenance   *api.RepositoryMaintenance
+		wantSkipCheck bool
+		wantSkipStats bool
+		wantPrune     bool
+	}{
+		{
+			name:      "no maintenance runs everything after backup",
+			wantPrune: true,
+		},
+		{
+			name:          "check job skips the check after backup",
+			maintenance:   &api.RepositoryMaintenance{Check: &api.RepositoryCheck{Schedule: "@daily"}},
+			wantSkipCheck: true,
+			wantSkipStats: true,
+			wantPrune:     true,
+		},
+		{
+			name:          "prune job skips the prune after backup",
+			maintenance:   &api.RepositoryMaintenance{Prune: &api.RepositoryPrune{Schedule: "@weekly"}},
+			wantSkipStats: true,
+		},
+		{
+			name: "check and prune jobs skip both",
+			maintenance: &api.RepositoryMaintenance{
+				Check: &api.RepositoryCheck{Schedule: "@daily"},
+				Prune: &api.RepositoryPrune{Schedule: "@weekly"},
+			},
+			wantSkipCheck: true,
+			wantSkipStats: true,
+		},
+		{
+			name:        "key rotation job skips nothing",
+			maintenance: &api.RepositoryMaintenance{KeyRotation: &api.RepositoryKeyRotation{Schedule: "@monthly"}},
+			wantPrune:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			backupOpt := restic.BackupOptions{RetentionPolicy: api.RetentionPolicy{KeepLast: 5, Prune: true}}
+			repository := api.Repository{Spec: api.RepositorySpec{Maintenance: tc.maintenance}}
+
+			got := SkipRepositoryMaintenance(backupOpt, repository)
+			if got.SkipCheck != tc.wantSkipCheck {
+				t.Errorf("expected skipCheck: %v, found: %v", tc.wantSkipCheck, got.SkipCheck)
+			}
+			if got.SkipStats != tc.wantSkipStats {
+				t.Errorf("expected skipStats: %v, found: %v", tc.wantSkipStats, got.SkipStats)
+			}
+			if got.RetentionPolicy.Prune != tc.wantPrune {
+				t.Errorf("expected prune: %v, found: %v", tc.wantPrune, got.RetentionPolicy.Prune)
+			}
+			if got.RetentionPolicy.KeepLast != 5 {
+				t.Errorf("expected the retention policy to be kept, found keepLast: %d", got.RetentionPolicy.KeepLast)
+			}
+		})
+	}
+}
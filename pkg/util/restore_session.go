@@ -44,7 +44,8 @@ func FindRestoreSession(lister v1beta1_listers.RestoreSessionLister, w *wapi.Wor
 	result := make([]*v1beta1_api.RestoreSession, 0)
 	// keep only those RestoreSession that has this workload as target
 	for _, restoreSession := range restoreSessions {
-		if restoreSession.DeletionTimestamp == nil && IsRestoreTarget(restoreSession.Spec.Target, w) {
+		// RestoreSessions in verify mode never restore into the target
+		if restoreSession.DeletionTimestamp == nil && restoreSession.Spec.Verification == nil && IsRestoreTarget(restoreSession.Spec.Target, w) {
 			result = append(result, restoreSession)
 		}
 	}
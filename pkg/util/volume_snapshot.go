@@ -0,0 +1,133 @@
+package util
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	vs "github.com/kubernetes-csi/external-snapshotter/pkg/apis/volumesnapshot/v1alpha1"
+	"stash.appscode.dev/stash/apis"
+	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+// FilterVolumeSnapshotsForPVC returns the VolumeSnapshots that has been taken by Stash for the given PVC on behalf of the
+// given BackupConfiguration. Stash labels the VolumeSnapshots with the name of the BackupConfiguration and names them as
+// "<pvc name>-<BackupSession timestamp>". So, we consider only those VolumeSnapshots whose source is the PVC, that have
+// the label and whose name follows this format. This prevents deleting VolumeSnapshots that has been created manually by
+// the user or by another BackupConfiguration of the same PVC.
+func FilterVolumeSnapshotsForPVC(snapshots []vs.VolumeSnapshot, backupConfigName, pvcName string) []vs.VolumeSnapshot {
+	namePattern := regexp.MustCompile("^" + regexp.QuoteMeta(pvcName) + `-[0-9]+$`)
+
+	result := make([]vs.VolumeSnapshot, 0)
+	for _, snapshot := range snapshots {
+		if snapshot.Spec.Source == nil ||
+			snapshot.Spec.Source.Kind != apis.KindPersistentVolumeClaim ||
+			snapshot.Spec.Source.Name != pvcName {
+			continue
+		}
+		if snapshot.Labels[LabelBackupConfiguration] != backupConfigName {
+			continue
+		}
+		if namePattern.MatchString(snapshot.Name) {
+			result = append(result, snapshot)
+		}
+	}
+	return result
+}
+
+// ApplyRetentionPolicy decides which VolumeSnapshots should be kept and which should be removed according to the retention policy.
+// It follows the same semantics as restic's "forget" command. The snapshots are sorted from newest to oldest, then the newest
+// snapshot of each of the latest N hours/days/weeks/months/years are kept. "keepTags" is ignored as VolumeSnapshots does not have tags.
+// If no "keep" rule has been specified in the policy, all the snapshots are kept.
+func ApplyRetentionPolicy(policy api.RetentionPolicy, snapshots []vs.VolumeSnapshot) (keep, remove []vs.VolumeSnapshot) {
+	// sort the snapshots so that the newest snapshot comes first
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return volumeSnapshotCreationTime(snapshots[i]).After(volumeSnapshotCreationTime(snapshots[j]))
+	})
+
+	if !HasKeepRule(policy) {
+		return snapshots, nil
+	}
+
+	buckets := []struct {
+		count  int
+		bucket func(t time.Time, nr int) int
+		last   int
+	}{
+		{policy.KeepLast, bucketLast, -1},
+		{policy.KeepHourly, bucketHourly, -1},
+		{policy.KeepDaily, bucketDaily, -1},
+		{policy.KeepWeekly, bucketWeekly, -1},
+		{policy.KeepMonthly, bucketMonthly, -1},
+		{policy.KeepYearly, bucketYearly, -1},
+	}
+
+	for nr, snapshot := range snapshots {
+		keepSnapshot := false
+		t := volumeSnapshotCreationTime(snapshot)
+
+		// keep the snapshot if it is the first snapshot of a new bucket for any of the rules
+		for i, b := range buckets {
+			if b.count <= 0 {
+				continue
+			}
+			val := b.bucket(t, nr)
+			if val != b.last {
+				keepSnapshot = true
+				buckets[i].last = val
+				buckets[i].count--
+			}
+		}
+
+		if keepSnapshot {
+			keep = append(keep, snapshot)
+		} else {
+			remove = append(remove, snapshot)
+		}
+	}
+	return keep, remove
+}
+
+// HasKeepRule returns true if any of the "keep" rules has been specified in the retention policy.
+func HasKeepRule(policy api.RetentionPolicy) bool {
+	return policy.KeepLast > 0 ||
+		policy.KeepHourly > 0 ||
+		policy.KeepDaily > 0 ||
+		policy.KeepWeekly > 0 ||
+		policy.KeepMonthly > 0 ||
+		policy.KeepYearly > 0
+}
+
+// volumeSnapshotCreationTime returns the time when the snapshot was taken. If the snapshot has not been
+// created yet, it falls back to the creation timestamp of the VolumeSnapshot object.
+func volumeSnapshotCreationTime(snapshot vs.VolumeSnapshot) time.Time {
+	if snapshot.Status.CreationTime != nil {
+		return snapshot.Status.CreationTime.Time
+	}
+	return snapshot.CreationTimestamp.Time
+}
+
+func bucketLast(_ time.Time, nr int) int {
+	return nr
+}
+
+func bucketHourly(t time.Time, _ int) int {
+	return t.Year()*1000000 + int(t.Month())*10000 + t.Day()*100 + t.Hour()
+}
+
+func bucketDaily(t time.Time, _ int) int {
+	return t.Year()*10000 + int(t.Month())*100 + t.Day()
+}
+
+func bucketWeekly(t time.Time, _ int) int {
+	year, week := t.ISOWeek()
+	return year*100 + week
+}
+
+func bucketMonthly(t time.Time, _ int) int {
+	return t.Year()*100 + int(t.Month())
+}
+
+func bucketYearly(t time.Time, _ int) int {
+	return t.Year()
+}
@@ -0,0 +1,140 @@
+package util
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	vs "github.com/kubernetes-csi/external-snapshotter/pkg/apis/volumesnapshot/v1alpha1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"stash.appscode.dev/stash/apis"
+	api "stash.appscode.dev/stash/apis/stash/v1alpha1"
+)
+
+func newVolumeSnapshot(name, kind, pvcName string, created time.Time) vs.VolumeSnapshot {
+	creationTime := metav1.NewTime(created)
+	return vs.VolumeSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{LabelBackupConfiguration: "bc"},
+		},
+		Spec: vs.VolumeSnapshotSpec{
+			Source: &core.TypedLocalObjectReference{Kind: kind, Name: pvcName},
+		},
+		Status: vs.VolumeSnapshotStatus{CreationTime: &creationTime},
+	}
+}
+
+func volumeSnapshotNames(snapshots []vs.VolumeSnapshot) []string {
+	names := make([]string, 0, len(snapshots))
+	for _, s := range snapshots {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestFilterVolumeSnapshotsForPVC(t *testing.T) {
+	now := time.Date(2019, 10, 15, 12, 0, 0, 0, time.UTC)
+	snapshots := []vs.VolumeSnapshot{
+		newVolumeSnapshot("data-1571140800", apis.KindPersistentVolumeClaim, "data", now),
+		newVolumeSnapshot("data-manual", apis.KindPersistentVolumeClaim, "data", now),
+		newVolumeSnapshot("data-extra-1571140800", apis.KindPersistentVolumeClaim, "data-extra", now),
+		newVolumeSnapshot("data-1571140900", "VolumeSnapshot", "data", now),
+		{ObjectMeta: metav1.ObjectMeta{Name: "data-1571141000"}},
+	}
+	// snapshots of the same PVC taken manually or for another BackupConfiguration
+	unlabeled := newVolumeSnapshot("data-1571141100", apis.KindPersistentVolumeClaim, "data", now)
+	unlabeled.Labels = nil
+	otherBackupConfig := newVolumeSnapshot("data-1571141200", apis.KindPersistentVolumeClaim, "data", now)
+	otherBackupConfig.Labels[LabelBackupConfiguration] = "other-bc"
+	snapshots = append(snapshots, unlabeled, otherBackupConfig)
+
+	got := volumeSnapshotNames(FilterVolumeSnapshotsForPVC(snapshots, "bc", "data"))
+	want := []string{"data-1571140800"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, found %v", want, got)
+	}
+}
+
+func TestApplyRetentionPolicy(t *testing.T) {
+	base := time.Date(2019, 10, 15, 12, 0, 0, 0, time.UTC)
+	// two snapshots per day for the last four days, newest first: d0-b, d0-a, d1-b, d1-a, ...
+	daily := func() []vs.VolumeSnapshot {
+		var snapshots []vs.VolumeSnapshot
+		for day := 3; day >= 0; day-- {
+			for i, suffix := range []string{"a", "b"} {
+				created := base.AddDate(0, 0, -day).Add(time.Duration(i) * time.Hour)
+				snapshots = append(snapshots, newVolumeSnapshot(fmt.Sprintf("d%d-%s", day, suffix), apis.KindPersistentVolumeClaim, "data", created))
+			}
+		}
+		return snapshots
+	}
+	// one snapshot on the 1st and the 15th of the last three months
+	monthly := func() []vs.VolumeSnapshot {
+		var snapshots []vs.VolumeSnapshot
+		for month := 2; month >= 0; month-- {
+			for i, day := range []int{1, 15} {
+				created := time.Date(2019, time.Month(10-month), day, 0, 0, 0, 0, time.UTC)
+				snapshots = append(snapshots, newVolumeSnapshot(fmt.Sprintf("m%d-%c", month, 'a'+i), apis.KindPersistentVolumeClaim, "data", created))
+			}
+		}
+		return snapshots
+	}
+
+	testCases := []struct {
+		name       string
+		policy     api.RetentionPolicy
+		snapshots  []vs.VolumeSnapshot
+		wantKeep   []string
+		wantRemove []string
+	}{
+		{
+			name:      "no keep rule keeps everything",
+			policy:    api.RetentionPolicy{Name: "none"},
+			snapshots: daily(),
+			wantKeep:  []string{"d0-b", "d0-a", "d1-b", "d1-a", "d2-b", "d2-a", "d3-b", "d3-a"},
+		},
+		{
+			name:       "keep last",
+			policy:     api.RetentionPolicy{Name: "last", KeepLast: 3},
+			snapshots:  daily(),
+			wantKeep:   []string{"d0-b", "d0-a", "d1-b"},
+			wantRemove: []string{"d1-a", "d2-b", "d2-a", "d3-b", "d3-a"},
+		},
+		{
+			name:       "keep daily keeps the newest snapshot of each day",
+			policy:     api.RetentionPolicy{Name: "daily", KeepDaily: 2},
+			snapshots:  daily(),
+			wantKeep:   []string{"d0-b", "d1-b"},
+			wantRemove: []string{"d0-a", "d1-a", "d2-b", "d2-a", "d3-b", "d3-a"},
+		},
+		{
+			name:       "keep last and daily are combined",
+			policy:     api.RetentionPolicy{Name: "combined", KeepLast: 1, KeepDaily: 3},
+			snapshots:  daily(),
+			wantKeep:   []string{"d0-b", "d1-b", "d2-b"},
+			wantRemove: []string{"d0-a", "d1-a", "d2-a", "d3-b", "d3-a"},
+		},
+		{
+			name:       "keep monthly keeps the newest snapshot of each month",
+			policy:     api.RetentionPolicy{Name: "monthly", KeepMonthly: 2},
+			snapshots:  monthly(),
+			wantKeep:   []string{"m0-b", "m1-b"},
+			wantRemove: []string{"m0-a", "m1-a", "m2-b", "m2-a"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			keep, remove := ApplyRetentionPolicy(tc.policy, tc.snapshots)
+			if got := volumeSnapshotNames(keep); !reflect.DeepEqual(got, tc.wantKeep) {
+				t.Errorf("expected to keep %v, found %v", tc.wantKeep, got)
+			}
+			if got := volumeSnapshotNames(remove); len(got) != len(tc.wantRemove) || (len(got) > 0 && !reflect.DeepEqual(got, tc.wantRemove)) {
+				t.Errorf("expected to remove %v, found %v", tc.wantRemove, got)
+			}
+		})
+	}
+}
@@ -18,7 +18,7 @@ func (f *Invocation) RestoreSession(repoName string, targetref v1beta1.TargetRef
 			Namespace: f.namespace,
 		},
 		Spec: v1beta1.RestoreSessionSpec{
-			Repository: core.LocalObjectReference{
+			Repository: v1beta1.RepositoryRef{
 				Name: repoName,
 			},
 			Rules: rules,